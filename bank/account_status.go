@@ -0,0 +1,75 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+
+	"example.com/banking/db"
+)
+
+// accountStatusTransitions lists for every account status the statuses it
+// can be reached from. Closed is final and only reached by CloseAccount.
+var accountStatusTransitions = map[string][]string{
+	db.AccountActive:       {db.AccountFrozen, db.AccountDebitBlocked, db.AccountDormant},
+	db.AccountFrozen:       {db.AccountActive, db.AccountDebitBlocked, db.AccountDormant},
+	db.AccountDebitBlocked: {db.AccountActive, db.AccountFrozen},
+	db.AccountDormant:      {db.AccountActive},
+	db.AccountClosed:       {db.AccountActive, db.AccountDormant},
+}
+
+// ChangeAccountStatus freezes, blocks, marks dormant or reactivates an
+// account.
+func (b *bankService) ChangeAccountStatus(ctx context.Context, claims *Claims, accId string, req ChangeAccountStatusRequest) (err error) {
+	from, ok := accountStatusTransitions[req.Status]
+	if !ok || req.Status == db.AccountClosed {
+		return ErrInvalidAccountStatus
+	}
+
+	reason, err := validateReason(req.Reason)
+	if err != nil {
+		return
+	}
+
+	b.logger.Infof("User: %v setting status of account: %v to %v\n", claims.UserID, accId, req.Status)
+	err = b.store.SetAccountStatus(ctx, accId, from, req.Status, reason)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditAccountStatusChanged,
+		ActorID: &claims.UserID,
+		Subject: accId,
+		IP:      req.IP,
+		Details: fmt.Sprintf("status: %v, reason: %v", req.Status, reason),
+	})
+	return
+}
+
+// CloseAccount closes an account for good. The balance must be zero, unless
+// the remainder is to be paid out.
+func (b *bankService) CloseAccount(ctx context.Context, claims *Claims, accId string, req CloseAccountRequest) (closure db.AccountClosure, err error) {
+	reason, err := validateReason(req.Reason)
+	if err != nil {
+		return
+	}
+	if req.PayoutAccountID == accId {
+		err = ErrSameAccount
+		return
+	}
+
+	b.logger.Infof("User: %v closing account: %v\n", claims.UserID, accId)
+	closure, err = b.store.CloseAccount(ctx, accId, accountStatusTransitions[db.AccountClosed], req.PayoutAccountID, req.PayOutRemainder, reason)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditAccountClosed,
+		ActorID: &claims.UserID,
+		Subject: accId,
+		IP:      req.IP,
+		Details: fmt.Sprintf("paid out: %v, reason: %v", closure.PaidOut, reason),
+	})
+	return
+}
@@ -1,7 +1,106 @@
 package bank
 
 import (
+	"time"
+
 	"github.com/dgrijalva/jwt-go"
+
+	"example.com/banking/money"
+)
+
+// Roles seeded by the migrations. What a role may do is configured in the
+// role_permissions table.
+const (
+	RoleAccountant    = "accountant"
+	RoleCustomer      = "customer"
+	RoleTeller        = "teller"
+	RoleBranchManager = "branch_manager"
+	RoleAuditor       = "auditor"
+)
+
+// Account types customers can open, the rules of each type are stored in the
+// account_types table
+const (
+	AccountTypeSavings      = "savings"
+	AccountTypeCurrent      = "current"
+	AccountTypeFixedDeposit = "fixed_deposit"
+)
+
+// Reason codes of reversals
+const (
+	ReversalDuplicate       = "duplicate"
+	ReversalIncorrectAmount = "incorrect_amount"
+	ReversalWrongAccount    = "wrong_account"
+	ReversalFraud           = "fraud"
+	ReversalCustomerDispute = "customer_dispute"
+	ReversalRefund          = "refund"
+)
+
+// Permissions checked by the routes
+const (
+	PermAccountsCreate        = "accounts:create"
+	PermAccountsList          = "accounts:list"
+	PermAccountsReadOwn       = "accounts:read:own"
+	PermAccountsDepositOwn    = "accounts:deposit:own"
+	PermAccountsWithdrawOwn   = "accounts:withdraw:own"
+	PermAccountsTransferOwn   = "accounts:transfer:own"
+	PermTransactionsReadOwn   = "transactions:read:own"
+	PermAccountsReadAny       = "accounts:read:any"
+	PermAccountsDepositAny    = "accounts:deposit:any"
+	PermAccountsWithdrawAny   = "accounts:withdraw:any"
+	PermAccountsTransferAny   = "accounts:transfer:any"
+	PermTransactionsReadAny   = "transactions:read:any"
+	PermAccountsStatusChange  = "accounts:status:change"
+	PermAccountsClose         = "accounts:close"
+	PermAccountsOverdraftSet  = "accounts:overdraft:set"
+	PermAccountsLimitsSet     = "accounts:limits:set"
+	PermFeesManage            = "fees:manage"
+	PermFXManage              = "fx:manage"
+	PermFXRead                = "fx:read"
+	PermHoldsManage           = "holds:manage"
+	PermTransactionsReverse   = "transactions:reverse"
+	PermStandingOrdersOwn     = "standing_orders:own"
+	PermUsersUnlock           = "users:unlock"
+	PermUsersMFAProvision     = "users:mfa:provision"
+	PermUsersRoleAssign       = "users:role:assign"
+	PermAuditRead             = "audit:read"
+	PermProfilePasswordChange = "profile:password:change"
+	PermProfileMFA            = "profile:mfa"
+	PermSessionLogout         = "session:logout"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditLoginSucceeded = "login_succeeded"
+	AuditLoginFailed    = "login_failed"
+	AuditLoginBlocked   = "login_blocked"
+	AuditUserUnlocked   = "user_unlocked"
+	AuditMFAProvisioned = "mfa_provisioned"
+
+	AuditUserRoleChanged = "user_role_changed"
+
+	// Staff access to customer accounts, the details hold the reason
+	AuditStaffAccountViewed      = "staff_account_viewed"
+	AuditStaffDeposit            = "staff_deposit"
+	AuditStaffWithdrawal         = "staff_withdrawal"
+	AuditStaffTransfer           = "staff_transfer"
+	AuditStaffTransactionsViewed = "staff_transactions_viewed"
+
+	AuditAccountStatusChanged = "account_status_changed"
+	AuditAccountClosed        = "account_closed"
+
+	AuditOverdraftLimitChanged = "overdraft_limit_changed"
+	AuditDebitLimitsChanged    = "debit_limits_changed"
+
+	AuditFeeRuleCreated = "fee_rule_created"
+	AuditFXRateCreated  = "fx_rate_created"
+	AuditFeeRuleUpdated = "fee_rule_updated"
+
+	AuditHoldPlaced   = "hold_placed"
+	AuditHoldCaptured = "hold_captured"
+	AuditHoldReleased = "hold_released"
+
+	AuditTransactionReversed = "transaction_reversed"
 )
 
 type PingResponse struct {
@@ -11,30 +110,300 @@ type PingResponse struct {
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// IP of the client, used to limit failed logins
+	IP string `json:"-"`
+}
+
+type LoginResponse struct {
+	Message               string    `json:"message"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	// RecoveryCodes are returned once, when the login completed an MFA enrolment
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	// MFA is set instead of the tokens when the login needs a TOTP code
+	MFA *MFAChallenge `json:"-"`
 }
 
+// MFAChallenge is the first step of a login with MFA. The token is exchanged
+// together with a TOTP code for the access and refresh tokens.
+type MFAChallenge struct {
+	Message   string    `json:"message"`
+	MFAToken  string    `json:"mfa_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MFAEnrolment is the secret to add to an authenticator app, as is and as an
+// otpauth:// URI for QR codes.
+type MFAEnrolment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type MFALoginRequest struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+	// IP of the client, used to limit failed logins
+	IP string `json:"-"`
+}
+
+type ConfirmMFARequest struct {
+	Code string `json:"code"`
+}
+
+type ConfirmMFAResponse struct {
+	Message       string   `json:"message"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// Claims of an access token. The standard jti claim (Id) identifies the token
+// and SessionID the login it was issued for, so either can be revoked.
 type Claims struct {
-	UserID string
-	Role   string
+	UserID    string
+	Role      string
+	SessionID string
 	jwt.StandardClaims
 }
 
 type CreateAccountRequest struct {
 	Email       string `json:"email"`
 	PhoneNumber string `json:"phone_number"`
+	// AccountType of the first account, savings if not given
+	AccountType string `json:"account_type"`
+	// Currency is the ISO 4217 code of the account, the default currency if
+	// not given
+	Currency string `json:"currency"`
 }
 
 type CreateAccountResponse struct {
-	Email     string `json:"email"`
-	Password  string `json:"password"`
-	AccountID string `json:"account_id"`
+	Email       string `json:"email"`
+	Password    string `json:"password"`
+	AccountID   string `json:"account_id"`
+	AccountType string `json:"account_type"`
+	Currency    string `json:"currency"`
+}
+
+type OpenAccountRequest struct {
+	AccountType string `json:"account_type"`
+	Currency    string `json:"currency"`
 }
 
+type SetUserRoleRequest struct {
+	Role string `json:"role"`
+	// IP of the client
+	IP string `json:"-"`
+}
+
+type ChangeAccountStatusRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+	// IP of the client
+	IP string `json:"-"`
+}
+
+// CloseAccountRequest closes an account. A remaining balance is paid out
+// only if PayOutRemainder is set: into PayoutAccountID if given, otherwise in
+// cash.
+type CloseAccountRequest struct {
+	Reason          string `json:"reason"`
+	PayOutRemainder bool   `json:"pay_out_remainder"`
+	PayoutAccountID string `json:"payout_account_id"`
+	// IP of the client
+	IP string `json:"-"`
+}
+
+type SetOverdraftLimitRequest struct {
+	Limit  money.Amount `json:"limit"`
+	Reason string       `json:"reason"`
+	// IP of the client
+	IP string `json:"-"`
+}
+
+// SetDebitLimitsRequest sets the debit limits of an account. A limit that is
+// not given falls back to the limit of the account type.
+type SetDebitLimitsRequest struct {
+	SingleDebitLimit  *money.Amount `json:"single_debit_limit"`
+	DailyDebitLimit   *money.Amount `json:"daily_debit_limit"`
+	MonthlyDebitLimit *money.Amount `json:"monthly_debit_limit"`
+	Reason            string        `json:"reason"`
+	// IP of the client
+	IP string `json:"-"`
+}
+
+// DepositWithdrawAmountRequest moves Amount into or out of an account. When
+// Currency is given it must be the account's.
 type DepositWithdrawAmountRequest struct {
-	Amount float32 `json:"amount"`
+	Amount   money.Amount `json:"amount"`
+	Currency string       `json:"currency"`
+}
+
+// TransferAmountRequest moves Amount, in the currency of the account, to
+// another account. When Currency is given it must be the account's.
+type TransferAmountRequest struct {
+	ToAccountID string       `json:"to_account_id"`
+	Amount      money.Amount `json:"amount"`
+	Currency    string       `json:"currency"`
+}
+
+// StaffAccess describes why a staff member works on a customer's account.
+// It is written to the audit log.
+type StaffAccess struct {
+	Reason string `json:"reason"`
+	// IP of the client
+	IP string `json:"-"`
+}
+
+type StaffDepositWithdrawAmountRequest struct {
+	DepositWithdrawAmountRequest
+	Reason string `json:"reason"`
+}
+
+type StaffTransferAmountRequest struct {
+	TransferAmountRequest
+	Reason string `json:"reason"`
+}
+
+type StaffGetTransactionDetailsRequest struct {
+	GetTransactionDetailsRequest
+	Reason string `json:"reason"`
 }
 
 type GetTransactionDetailsRequest struct {
 	StartDate string `json:"start_date"`
 	EndDate   string `json:"end_date"`
 }
+
+// TransactionHistoryRequest selects a page of the transactions of an account.
+// Empty fields match every transaction. Cursor is the next_cursor of the
+// previous page.
+type TransactionHistoryRequest struct {
+	StartDate string
+	EndDate   string
+	Types     []string
+	MinAmount *money.Amount
+	MaxAmount *money.Amount
+	Sort      string
+	Cursor    string
+	Limit     int
+}
+
+// InterestRun is the outcome of accruing interest for a business date.
+type InterestRun struct {
+	BusinessDate string `json:"business_date"`
+	Accounts     int    `json:"accounts"`
+	Accrued      int    `json:"accrued"`
+	Posted       int    `json:"posted"`
+	Failed       int    `json:"failed"`
+}
+
+// FeeRuleRequest creates or replaces a fee rule. Rules are active unless
+// Active is false; an empty AccountType applies the rule to every type.
+// Amount and MinBalance are in Currency, the default currency if not given.
+type FeeRuleRequest struct {
+	Name         string        `json:"name"`
+	Trigger      string        `json:"trigger"`
+	AccountType  string        `json:"account_type"`
+	Currency     string        `json:"currency"`
+	Amount       money.Amount  `json:"amount"`
+	FreePerMonth int           `json:"free_per_month"`
+	MinBalance   *money.Amount `json:"min_balance"`
+	Active       *bool         `json:"active"`
+}
+
+// CreateStandingOrderRequest asks to pay Amount to another account, once on
+// StartDate or every Interval days, weeks, months or years from it. Dates
+// are given as yyyy-mm-dd, StartDate defaults to today.
+type CreateStandingOrderRequest struct {
+	ToAccountID    string       `json:"to_account_id"`
+	Amount         money.Amount `json:"amount"`
+	Frequency      string       `json:"frequency"`
+	Interval       int          `json:"interval"`
+	StartDate      string       `json:"start_date"`
+	EndDate        string       `json:"end_date"`
+	MaxOccurrences *int         `json:"max_occurrences"`
+}
+
+// StandingOrderRun is the outcome of executing the standing orders that
+// were due. Retrying orders are tried again later, rejected ones gave up on
+// their occurrence.
+type StandingOrderRun struct {
+	Due      int `json:"due"`
+	Paid     int `json:"paid"`
+	Retrying int `json:"retrying"`
+	Rejected int `json:"rejected"`
+	Failed   int `json:"failed"`
+}
+
+// CreateHoldRequest reserves Amount of an account until ExpiresAt, for the
+// configured default expiry if not given. Reference identifies the payment
+// the money is held for, e.g. a card authorisation, and is unique per
+// account.
+type CreateHoldRequest struct {
+	Amount    money.Amount `json:"amount"`
+	Currency  string       `json:"currency"`
+	Reference string       `json:"reference"`
+	ExpiresAt *time.Time   `json:"expires_at"`
+}
+
+// CaptureHoldRequest settles a hold with Amount, at most the held amount.
+// The whole hold is captured when Amount is not given.
+type CaptureHoldRequest struct {
+	Amount *money.Amount `json:"amount"`
+}
+
+// ReverseTransactionRequest reverses Amount of a credit or debit, all that
+// is left of it when not given. ReasonCode says why and must be given.
+type ReverseTransactionRequest struct {
+	Amount     *money.Amount `json:"amount"`
+	ReasonCode string        `json:"reason_code"`
+}
+
+// FXRateRequest sets the exchange rate of a pair of currencies from
+// EffectiveFrom on, right away if not given. Spread is a fraction of the
+// rate, e.g. 0.01 for one percent.
+type FXRateRequest struct {
+	BaseCurrency  string       `json:"base_currency"`
+	QuoteCurrency string       `json:"quote_currency"`
+	Rate          money.Amount `json:"rate"`
+	Spread        money.Amount `json:"spread"`
+	EffectiveFrom *time.Time   `json:"effective_from"`
+}
+
+// StatementRun is the outcome of writing the statements of every account for
+// a month.
+type StatementRun struct {
+	Month    string `json:"month"`
+	Accounts int    `json:"accounts"`
+	Written  int    `json:"written"`
+	Failed   int    `json:"failed"`
+}
+
+// FeeRun is the outcome of charging the monthly fees of a month. Unpaid fees
+// are those the balance did not cover.
+type FeeRun struct {
+	Month   string `json:"month"`
+	Due     int    `json:"due"`
+	Charged int    `json:"charged"`
+	Unpaid  int    `json:"unpaid"`
+	Failed  int    `json:"failed"`
+}
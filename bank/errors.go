@@ -1,7 +1,56 @@
 package bank
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrUnauthorized = errors.New("unauthorized")
+
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+
+	ErrInvalidMFACode     = errors.New("invalid mfa code")
+	ErrMFAAlreadyEnrolled = errors.New("mfa is already enrolled")
+	ErrMFANotEnrolled     = errors.New("mfa enrolment has not been started")
+	ErrMFANotProvisioned  = errors.New("mfa is required, an administrator must provision the enrolment")
+	ErrInvalidAmount      = errors.New("invalid amount")
+	ErrSameAccount        = errors.New("cannot transfer to the same account")
+	ErrReasonRequired     = errors.New("a reason must be given for the operation")
+	ErrInvalidRole        = errors.New("invalid role")
+
+	ErrInvalidAccountStatus = errors.New("invalid account status")
+	ErrInvalidBusinessDate  = errors.New("business date must be in the past")
+	ErrInvalidFeeRule       = errors.New("invalid fee rule")
+	ErrInvalidStandingOrder = errors.New("invalid standing order")
+	ErrInvalidCurrency      = errors.New("invalid currency")
+	ErrCurrencyMismatch     = errors.New("currency does not match the account")
+	ErrInvalidFXRate        = errors.New("invalid exchange rate")
+	ErrInvalidHold          = errors.New("invalid hold")
+	ErrInvalidReversal      = errors.New("invalid reversal")
+
+	ErrInvalidTransactionFilter = errors.New("invalid transaction filter")
+	ErrInvalidStatementMonth    = errors.New("statement month must be over")
+
+	ErrWeakPassword      = errors.New("password does not meet the password policy")
+	ErrIncorrectPassword = errors.New("current password is incorrect")
+	ErrInvalidResetToken = errors.New("password reset token is invalid or has expired")
+
+	ErrIdempotencyKeyConflict   = errors.New("idempotency key was already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still in progress")
 )
+
+// LoginBlockedError is returned by Login while failed attempts block logins
+// for the email or client.
+type LoginBlockedError struct {
+	RetryAt time.Time
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%v, retry after %v", ErrTooManyLoginAttempts, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return ErrTooManyLoginAttempts
+}
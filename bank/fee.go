@@ -0,0 +1,166 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/banking/db"
+)
+
+var feeTriggers = []string{db.FeeTriggerWithdrawal, db.FeeTriggerDeposit, db.FeeTriggerMonthly}
+
+// logFees logs the fees charged for a withdrawal or deposit.
+func (b *bankService) logFees(accId string, fees []db.Transaction) {
+	for _, f := range fees {
+		b.logger.Infof("Charged fee: %v to account: %v for transaction: %v\n", f.Amount, accId, *f.LinkedTransactionID)
+	}
+}
+
+// feeRule checks a fee rule request and turns it into a rule.
+func feeRule(req FeeRuleRequest) (r db.FeeRule, err error) {
+	r = db.FeeRule{
+		Name:         strings.TrimSpace(req.Name),
+		Trigger:      req.Trigger,
+		FreePerMonth: req.FreePerMonth,
+		MinBalance:   req.MinBalance,
+		Active:       req.Active == nil || *req.Active,
+	}
+	if req.AccountType != "" {
+		r.AccountType = &req.AccountType
+	}
+
+	switch {
+	case r.Name == "" || len(r.Name) > 100:
+		err = fmt.Errorf("%w: name must be between 1 and 100 characters long", ErrInvalidFeeRule)
+	case !containsString(feeTriggers, r.Trigger):
+		err = fmt.Errorf("%w: trigger must be one of %v", ErrInvalidFeeRule, strings.Join(feeTriggers, ", "))
+	case r.FreePerMonth < 0 || (r.Trigger == db.FeeTriggerMonthly && r.FreePerMonth > 0):
+		err = fmt.Errorf("%w: free_per_month must not be negative, and is not allowed for monthly fees", ErrInvalidFeeRule)
+	case r.MinBalance != nil && r.MinBalance.Sign() < 0:
+		err = fmt.Errorf("%w: min_balance must not be negative", ErrInvalidFeeRule)
+	}
+	if err != nil {
+		return
+	}
+
+	// the rule applies to the accounts in its currency
+	cur, err := validateCurrency(strings.TrimSpace(req.Currency))
+	if err != nil {
+		return
+	}
+	r.Currency = cur.Code
+	if r.MinBalance != nil {
+		if err = cur.Check(*r.MinBalance); err != nil {
+			err = fmt.Errorf("%w: min_balance: %v", ErrInvalidFeeRule, err)
+			return
+		}
+		if r.MinBalance.Cmp(maxAmount) > 0 {
+			err = fmt.Errorf("%w: min_balance must not exceed %v", ErrInvalidFeeRule, maxAmount)
+			return
+		}
+	}
+
+	r.Amount, err = validateAmount(req.Amount, cur)
+	return
+}
+
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *bankService) GetFeeRules(ctx context.Context) (rules []db.FeeRule, err error) {
+	rules, err = b.store.GetFeeRules(ctx)
+	return
+}
+
+// CreateFeeRule adds a fee rule, it applies to transactions from then on.
+func (b *bankService) CreateFeeRule(ctx context.Context, claims *Claims, req FeeRuleRequest) (rule db.FeeRule, err error) {
+	r, err := feeRule(req)
+	if err != nil {
+		return
+	}
+
+	b.logger.Infof("User: %v creating fee rule: %v\n", claims.UserID, r.Name)
+	rule, err = b.store.CreateFeeRule(ctx, r)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditFeeRuleCreated,
+		ActorID: &claims.UserID,
+		Subject: fmt.Sprint(rule.ID),
+		Details: fmt.Sprintf("trigger: %v, amount: %v", rule.Trigger, rule.Amount),
+	})
+	return
+}
+
+// UpdateFeeRule changes a fee rule. Setting active to false stops charging
+// it, fees already charged are kept.
+func (b *bankService) UpdateFeeRule(ctx context.Context, claims *Claims, ruleID int64, req FeeRuleRequest) (rule db.FeeRule, err error) {
+	r, err := feeRule(req)
+	if err != nil {
+		return
+	}
+	r.ID = ruleID
+
+	b.logger.Infof("User: %v updating fee rule: %v\n", claims.UserID, ruleID)
+	rule, err = b.store.UpdateFeeRule(ctx, r)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditFeeRuleUpdated,
+		ActorID: &claims.UserID,
+		Subject: fmt.Sprint(rule.ID),
+		Details: fmt.Sprintf("trigger: %v, amount: %v, active: %v", rule.Trigger, rule.Amount, rule.Active),
+	})
+	return
+}
+
+// ChargeMonthlyFees charges the monthly fees for the month of the given
+// date. Every fee is charged once per month, so running it again only
+// charges the fees that were not charged before.
+func (b *bankService) ChargeMonthlyFees(ctx context.Context, month time.Time) (run FeeRun, err error) {
+	period := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	run.Month = period.Format("2006-01")
+	// the month must be over, fees use the balance at its end
+	if !period.AddDate(0, 1, 0).Before(time.Now()) {
+		err = ErrInvalidBusinessDate
+		return
+	}
+
+	charges, err := b.store.GetMonthlyFeeCharges(ctx, period)
+	if err != nil {
+		return
+	}
+	run.Due = len(charges)
+
+	for _, c := range charges {
+		charged, err := b.store.ChargeMonthlyFee(ctx, c)
+		switch {
+		case err == db.ErrInsufficientFunds:
+			run.Unpaid++
+		case err != nil:
+			b.logger.Errorf("Err charging fee rule: %v to account: %v, month: %v, err: %v", c.RuleID, c.AccountID, run.Month, err)
+			run.Failed++
+		case charged.Sign() > 0:
+			run.Charged++
+		}
+	}
+
+	b.logger.Infof("Charged monthly fees for month: %v, due: %v, charged: %v, unpaid: %v, failed: %v\n",
+		run.Month, run.Due, run.Charged, run.Unpaid, run.Failed)
+	if run.Failed > 0 {
+		err = fmt.Errorf("monthly fees failed for %d of %d charges", run.Failed, run.Due)
+	}
+	return
+}
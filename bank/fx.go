@@ -0,0 +1,102 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/banking/db"
+	"example.com/banking/money"
+)
+
+// validateCurrency checks the currency of a new account, the default
+// currency if none is given.
+func validateCurrency(code string) (cur money.Currency, err error) {
+	if code == "" {
+		return money.DefaultCurrency, nil
+	}
+
+	cur, err = money.LookupCurrency(code)
+	if err != nil {
+		err = fmt.Errorf("%w: %v", ErrInvalidCurrency, err)
+	}
+	return
+}
+
+// accountCurrency returns the currency of the user's account. A currency
+// given with a request must be the one of the account.
+func (b *bankService) accountCurrency(ctx context.Context, accId, userID, requested string) (cur money.Currency, err error) {
+	acc, err := b.store.GetAccountDetails(ctx, accId, userID)
+	if err != nil {
+		return
+	}
+
+	if cur, err = money.LookupCurrency(acc.Currency); err != nil {
+		return
+	}
+	if requested != "" && !strings.EqualFold(requested, cur.Code) {
+		err = fmt.Errorf("%w: the account holds %v", ErrCurrencyMismatch, cur.Code)
+	}
+	return
+}
+
+func (b *bankService) GetFXRates(ctx context.Context) (rates []db.FXRate, err error) {
+	rates, err = b.store.GetFXRates(ctx)
+	return
+}
+
+// CreateFXRate sets the exchange rate of a pair of currencies. It applies
+// to transfers from its effective time until the next rate of the pair.
+func (b *bankService) CreateFXRate(ctx context.Context, claims *Claims, req FXRateRequest) (rate db.FXRate, err error) {
+	base, err := validateCurrency(strings.TrimSpace(req.BaseCurrency))
+	if err != nil {
+		return
+	}
+	quote, err := validateCurrency(strings.TrimSpace(req.QuoteCurrency))
+	if err != nil {
+		return
+	}
+
+	r := db.FXRate{
+		BaseCurrency:  base.Code,
+		QuoteCurrency: quote.Code,
+		Rate:          req.Rate,
+		Spread:        req.Spread,
+		EffectiveFrom: time.Now(),
+		CreatedBy:     &claims.UserID,
+	}
+	if req.EffectiveFrom != nil {
+		r.EffectiveFrom = *req.EffectiveFrom
+	}
+
+	switch {
+	case req.BaseCurrency == "" || req.QuoteCurrency == "":
+		err = fmt.Errorf("%w: base_currency and quote_currency must be given", ErrInvalidFXRate)
+	case base == quote:
+		err = fmt.Errorf("%w: base_currency and quote_currency must differ", ErrInvalidFXRate)
+	case r.Rate.Sign() <= 0:
+		err = fmt.Errorf("%w: rate must be greater than zero", ErrInvalidFXRate)
+	case r.Spread.Sign() < 0 || r.Spread.Cmp(money.New(1, 0)) >= 0:
+		err = fmt.Errorf("%w: spread must be a fraction of the rate, from 0 to below 1", ErrInvalidFXRate)
+	case r.EffectiveFrom.Before(time.Now().Add(-time.Minute)):
+		err = fmt.Errorf("%w: effective_from must not be in the past", ErrInvalidFXRate)
+	}
+	if err != nil {
+		return
+	}
+
+	b.logger.Infof("User: %v setting the %v/%v rate to %v from %v\n", claims.UserID, r.BaseCurrency, r.QuoteCurrency, r.Rate, r.EffectiveFrom)
+	rate, err = b.store.CreateFXRate(ctx, r)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditFXRateCreated,
+		ActorID: &claims.UserID,
+		Subject: fmt.Sprintf("%v/%v", rate.BaseCurrency, rate.QuoteCurrency),
+		Details: fmt.Sprintf("rate: %v, spread: %v, effective from: %v", rate.Rate, rate.Spread, rate.EffectiveFrom.Format(time.RFC3339)),
+	})
+	return
+}
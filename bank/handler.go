@@ -1,11 +1,17 @@
 package bank
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net"
 	"net/http"
 	"net/mail"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,12 +19,29 @@ import (
 
 	"example.com/banking/api"
 	"example.com/banking/db"
+	"example.com/banking/money"
+	"example.com/banking/statement"
 )
 
 func PingHandler(rw http.ResponseWriter, req *http.Request) {
 	api.Success(rw, http.StatusOK, api.Response{Message: "pong"})
 }
 
+// JWKSHandler publishes the public keys tokens are signed with, so that other
+// services can verify them.
+func JWKSHandler(rw http.ResponseWriter, req *http.Request) {
+	api.Success(rw, http.StatusOK, jwtKeys.publicKeys())
+}
+
+// clientIP returns the IP address the request came from.
+func clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
 func LoginHandler(s Service) http.HandlerFunc {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		var uAuth LoginRequest
@@ -38,244 +61,343 @@ func LoginHandler(s Service) http.HandlerFunc {
 			return
 		}
 		uAuth.Email = strings.Trim(uAuth.Email, " ")
+		uAuth.IP = clientIP(req)
 
-		tokenString, tokenExpirationTime, err := s.Login(req.Context(), uAuth)
+		tokens, err := s.Login(req.Context(), uAuth)
 		if err != nil {
 			if err == ErrUnauthorized {
 				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
 				return
 			}
+
+			if err == ErrMFANotProvisioned {
+				api.Error(rw, http.StatusForbidden, api.Response{Message: err.Error()})
+				return
+			}
+
+			var blocked *LoginBlockedError
+			if errors.As(err, &blocked) {
+				retryAfter := int(math.Ceil(time.Until(blocked.RetryAt).Seconds()))
+				rw.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				api.Error(rw, http.StatusTooManyRequests, api.Response{Message: err.Error()})
+				return
+			}
 			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
 			return
 		}
 
+		if tokens.MFA != nil {
+			tokens.MFA.Message = "MFA code required"
+			api.Success(rw, http.StatusOK, tokens.MFA)
+			return
+		}
+
 		http.SetCookie(rw, &http.Cookie{
 			Name:    "token",
-			Value:   tokenString,
-			Expires: tokenExpirationTime,
+			Value:   tokens.AccessToken,
+			Expires: tokens.AccessTokenExpiresAt,
 		})
 
-		api.Success(rw, http.StatusOK, api.Response{Message: "Successfully logged in"})
+		tokens.Message = "Successfully logged in"
+		api.Success(rw, http.StatusOK, tokens)
 	})
 }
 
-func CreateAccountHandler(s Service) http.HandlerFunc {
+func LoginMFAHandler(s Service) http.HandlerFunc {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		cookie, err := req.Cookie("token")
+		var mfaReq MFALoginRequest
+
+		err := json.NewDecoder(req.Body).Decode(&mfaReq)
 		if err != nil {
-			if err == http.ErrNoCookie {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "JWT token not present in the cookie"})
-				return
-			}
-			api.Error(rw, http.StatusInternalServerError,
-				api.Response{Message: fmt.Sprintf("Internal Server Error: %v", err.Error())})
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 			return
 		}
-		tokenString := cookie.Value
-
-		// Authenticate and verify the authorization
-		claims, err := ValidateJWT(tokenString)
-		if err != nil || claims.Role != "accountant" {
-			api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+		if mfaReq.MFAToken == "" || (mfaReq.Code == "" && mfaReq.RecoveryCode == "") {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - MFA token and a code or recovery code must be provided"})
 			return
 		}
+		mfaReq.IP = clientIP(req)
 
-		var accReq CreateAccountRequest
-
-		err = json.NewDecoder(req.Body).Decode(&accReq)
+		tokens, err := s.LoginMFA(req.Context(), mfaReq)
 		if err != nil {
-			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Internal Server Error"})
-			return
-		}
+			if errors.Is(err, ErrUnauthorized) {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
 
-		// Validate if the user email and phonenumber is correct
-		if accReq.Email == "" || accReq.PhoneNumber == "" {
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Email address and phone number must be provided"})
-			return
-		}
-		if _, err := mail.ParseAddress(accReq.Email); err != nil {
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid email address"})
-			return
-		}
-		accReq.Email = strings.Trim(accReq.Email, " ")
-		re := regexp.MustCompile(`^\d{10}$`)
-		if !re.MatchString(accReq.PhoneNumber) {
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Phone number must contain 10 digits"})
+			var blocked *LoginBlockedError
+			if errors.As(err, &blocked) {
+				retryAfter := int(math.Ceil(time.Until(blocked.RetryAt).Seconds()))
+				rw.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				api.Error(rw, http.StatusTooManyRequests, api.Response{Message: err.Error()})
+				return
+			}
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
 			return
 		}
 
-		accRes, err := s.CreateAccount(req.Context(), accReq)
+		http.SetCookie(rw, &http.Cookie{
+			Name:    "token",
+			Value:   tokens.AccessToken,
+			Expires: tokens.AccessTokenExpiresAt,
+		})
+
+		tokens.Message = "Successfully logged in"
+		api.Success(rw, http.StatusOK, tokens)
+	})
+}
+
+func EnrolMFAHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		enrolment, err := s.EnrolMFA(req.Context(), claims)
 		if err != nil {
-			if err.Error() == "account exists for the given email" {
-				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Account exists for the given email"})
+			if err == ErrMFAAlreadyEnrolled {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
 				return
 			}
-			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error - Failure creating user account"})
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
 			return
 		}
 
-		api.Success(rw, http.StatusOK, accRes)
+		api.Success(rw, http.StatusOK, enrolment)
 	})
 }
 
-func GetAccountsHandler(s Service) http.HandlerFunc {
+func ConfirmMFAHandler(s Service) http.HandlerFunc {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		cookie, err := req.Cookie("token")
+		claims := requestClaims(req)
+
+		var confirmReq ConfirmMFARequest
+		err := json.NewDecoder(req.Body).Decode(&confirmReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		recoveryCodes, err := s.ConfirmMFA(req.Context(), claims, confirmReq.Code)
 		if err != nil {
-			if err == http.ErrNoCookie {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: err.Error()})
+			if err == ErrInvalidMFACode {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 				return
 			}
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+
+			if err == ErrMFAAlreadyEnrolled || err == ErrMFANotEnrolled {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
 			return
 		}
-		tokenString := cookie.Value
 
-		// Authenticate and verify the authorization
-		claims, err := ValidateJWT(tokenString)
+		api.Success(rw, http.StatusOK, ConfirmMFAResponse{
+			Message:       "Successfully enrolled in MFA, keep the recovery codes in a safe place",
+			RecoveryCodes: recoveryCodes,
+		})
+	})
+}
+
+func RefreshTokenHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var refreshReq RefreshTokenRequest
+
+		err := json.NewDecoder(req.Body).Decode(&refreshReq)
 		if err != nil {
 			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 			return
 		}
-
-		if claims.Role != "accountant" {
-			api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+		if refreshReq.RefreshToken == "" {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Refresh token must be provided"})
 			return
 		}
 
-		accounts, err := s.GetAccountList(req.Context())
+		tokens, err := s.RefreshToken(req.Context(), refreshReq.RefreshToken)
 		if err != nil {
-			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			if err == ErrUnauthorized {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
 			return
 		}
 
-		api.Success(rw, http.StatusOK, accounts)
+		http.SetCookie(rw, &http.Cookie{
+			Name:    "token",
+			Value:   tokens.AccessToken,
+			Expires: tokens.AccessTokenExpiresAt,
+		})
+
+		tokens.Message = "Successfully refreshed token"
+		api.Success(rw, http.StatusOK, tokens)
 	})
 }
 
-func GetAccountDetailsHandler(s Service) http.HandlerFunc {
+func LogoutHandler(s Service) http.HandlerFunc {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		cookie, err := req.Cookie("token")
+		claims := requestClaims(req)
+
+		err := s.Logout(req.Context(), claims)
 		if err != nil {
-			if err == http.ErrNoCookie {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: err.Error()})
-				return
-			}
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
 			return
 		}
-		tokenString := cookie.Value
 
-		// Authenticate and verify the authorization
-		claims, err := ValidateJWT(tokenString)
+		http.SetCookie(rw, &http.Cookie{
+			Name:   "token",
+			Value:  "",
+			MaxAge: -1,
+		})
+
+		api.Success(rw, http.StatusOK, api.Response{Message: "Successfully logged out"})
+	})
+}
+
+func ChangePasswordHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		var changeReq ChangePasswordRequest
+		err := json.NewDecoder(req.Body).Decode(&changeReq)
 		if err != nil {
 			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 			return
 		}
+		if changeReq.OldPassword == "" || changeReq.NewPassword == "" {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Old and new password must be provided"})
+			return
+		}
 
-		params := mux.Vars(req)
-		accID := params["account_id"]
-
-		acc, err := s.GetAccountDetails(req.Context(), accID, claims.UserID)
+		err = s.ChangePassword(req.Context(), claims, changeReq)
 		if err != nil {
-			if err == db.ErrAccountNotExist {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+			if errors.Is(err, ErrWeakPassword) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 				return
 			}
 
-			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			if err == ErrIncorrectPassword {
+				api.Error(rw, http.StatusForbidden, api.Response{Message: "Err - Current password is incorrect"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
 			return
 		}
 
-		api.Success(rw, http.StatusOK, acc)
+		api.Success(rw, http.StatusOK, api.Response{Message: "Successfully changed password"})
 	})
 }
 
-func DepositAmountHandler(s Service) http.HandlerFunc {
+func ForgotPasswordHandler(s Service) http.HandlerFunc {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		cookie, err := req.Cookie("token")
+		var forgotReq ForgotPasswordRequest
+
+		err := json.NewDecoder(req.Body).Decode(&forgotReq)
 		if err != nil {
-			if err == http.ErrNoCookie {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: err.Error()})
-				return
-			}
 			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 			return
 		}
-		tokenString := cookie.Value
+		forgotReq.Email = strings.Trim(forgotReq.Email, " ")
+		if _, err := mail.ParseAddress(forgotReq.Email); err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid email address"})
+			return
+		}
 
-		// Authenticate and verify the authorization
-		claims, err := ValidateJWT(tokenString)
+		err = s.ForgotPassword(req.Context(), forgotReq.Email)
 		if err != nil {
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
 			return
 		}
 
-		params := mux.Vars(req)
-		accId := params["account_id"]
+		api.Success(rw, http.StatusAccepted, api.Response{Message: "If an account exists for the email, a password reset token has been sent to it"})
+	})
+}
 
-		var depositAmountRequest DepositWithdrawAmountRequest
-		err = json.NewDecoder(req.Body).Decode(&depositAmountRequest)
+func ResetPasswordHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var resetReq ResetPasswordRequest
+
+		err := json.NewDecoder(req.Body).Decode(&resetReq)
 		if err != nil {
 			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 			return
 		}
+		if resetReq.Token == "" || resetReq.NewPassword == "" {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Reset token and new password must be provided"})
+			return
+		}
 
-		err = s.DepositAmount(req.Context(), accId, claims.UserID, depositAmountRequest.Amount)
+		err = s.ResetPassword(req.Context(), resetReq)
 		if err != nil {
-			if err == db.ErrAccountNotExist {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+			if errors.Is(err, ErrWeakPassword) || err == ErrInvalidResetToken {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 				return
 			}
 
-			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
 			return
 		}
 
-		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully credited account with amount %v", depositAmountRequest.Amount)})
-
+		api.Success(rw, http.StatusOK, api.Response{Message: "Successfully reset password"})
 	})
 }
 
-func WithdrawAmountHandler(s Service) http.HandlerFunc {
+func UnlockUserHandler(s Service) http.HandlerFunc {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		cookie, err := req.Cookie("token")
-		if err != nil {
-			if err == http.ErrNoCookie {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: err.Error()})
-				return
-			}
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		userID := params["user_id"]
+		if !regexp.MustCompile(`^\d+$`).MatchString(userID) {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid user id"})
 			return
 		}
-		tokenString := cookie.Value
 
-		// Authenticate and verify the authorization
-		claims, err := ValidateJWT(tokenString)
+		err := s.UnlockUser(req.Context(), claims, userID)
 		if err != nil {
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			if err == db.ErrUserNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - User does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
 			return
 		}
 
+		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully unlocked user %v", userID)})
+	})
+}
+
+func SetUserRoleHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
 		params := mux.Vars(req)
-		accId := params["account_id"]
+		userID := params["user_id"]
+		if !regexp.MustCompile(`^\d+$`).MatchString(userID) {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid user id"})
+			return
+		}
 
-		var withdrawAmountRequest DepositWithdrawAmountRequest
-		err = json.NewDecoder(req.Body).Decode(&withdrawAmountRequest)
+		var roleReq SetUserRoleRequest
+		err := json.NewDecoder(req.Body).Decode(&roleReq)
 		if err != nil {
 			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 			return
 		}
+		roleReq.IP = clientIP(req)
 
-		err = s.WithdrawAmount(req.Context(), accId, claims.UserID, withdrawAmountRequest.Amount)
+		err = s.SetUserRole(req.Context(), claims, userID, roleReq)
 		if err != nil {
-			if err == db.ErrInsufficientFunds {
-				api.Error(rw, http.StatusOK, api.Response{Message: err.Error()})
+			if errors.Is(err, ErrInvalidRole) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 				return
 			}
 
-			if err == db.ErrAccountNotExist {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+			if err == db.ErrUserNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - User does not exist"})
 				return
 			}
 
@@ -283,79 +405,1404 @@ func WithdrawAmountHandler(s Service) http.HandlerFunc {
 			return
 		}
 
-		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully debited account with amount %v", withdrawAmountRequest.Amount)})
+		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully set role of user %v to %v", userID, strings.TrimSpace(roleReq.Role))})
 	})
 }
 
-func GetTransactionDetailsHandler(b Service) http.HandlerFunc {
+func ProvisionMFAHandler(s Service) http.HandlerFunc {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		cookie, err := req.Cookie("token")
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		userID := params["user_id"]
+		if !regexp.MustCompile(`^\d+$`).MatchString(userID) {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid user id"})
+			return
+		}
+
+		enrolment, err := s.ProvisionMFA(req.Context(), claims, userID)
 		if err != nil {
-			if err == http.ErrNoCookie {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: err.Error()})
+			if err == db.ErrUserNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - User does not exist"})
 				return
 			}
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+
+			if err == ErrMFAAlreadyEnrolled {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
 			return
 		}
-		tokenString := cookie.Value
 
-		// Authenticate and verify the authorization
-		claims, err := ValidateJWT(tokenString)
+		api.Success(rw, http.StatusCreated, enrolment)
+	})
+}
+
+func CreateAccountHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var accReq CreateAccountRequest
+
+		err := json.NewDecoder(req.Body).Decode(&accReq)
 		if err != nil {
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Internal Server Error"})
 			return
 		}
 
-		params := mux.Vars(req)
-		accId := params["account_id"]
-
-		var transactionDetailsRequest GetTransactionDetailsRequest
-		err = json.NewDecoder(req.Body).Decode(&transactionDetailsRequest)
-		if err != nil {
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+		// Validate if the user email and phonenumber is correct
+		if accReq.Email == "" || accReq.PhoneNumber == "" {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Email address and phone number must be provided"})
+			return
+		}
+		if _, err := mail.ParseAddress(accReq.Email); err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid email address"})
+			return
+		}
+		accReq.Email = strings.Trim(accReq.Email, " ")
+		re := regexp.MustCompile(`^\d{10}$`)
+		if !re.MatchString(accReq.PhoneNumber) {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Phone number must contain 10 digits"})
 			return
 		}
 
-		// Validate the formats of the start and end date
-		startDateTime, err := time.Parse("2006-01-02", transactionDetailsRequest.StartDate)
+		accRes, err := s.CreateAccount(req.Context(), accReq)
 		if err != nil {
-			err = fmt.Errorf("error parsing startdate: %v", transactionDetailsRequest.StartDate)
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			if err.Error() == "account exists for the given email" {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Account exists for the given email"})
+				return
+			}
+			if errors.Is(err, ErrInvalidCurrency) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountTypeNotExist {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid account type"})
+				return
+			}
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error - Failure creating user account"})
 			return
 		}
-		endDateTime, err := time.Parse("2006-01-02", transactionDetailsRequest.EndDate)
-		if err != nil {
-			err = fmt.Errorf("error parsing enddate %v", transactionDetailsRequest.EndDate)
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+
+		api.Success(rw, http.StatusOK, accRes)
+	})
+}
+
+func OpenAccountHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		params := mux.Vars(req)
+		customerID := params["customer_id"]
+		if !regexp.MustCompile(`^\d+$`).MatchString(customerID) {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid customer id"})
 			return
 		}
 
-		// Validate the difference between the days should be between 1-30 days
-		if startDateTime == endDateTime || startDateTime.After(endDateTime) {
-			err = fmt.Errorf("start date: %v must be less than end date: %v",
-				transactionDetailsRequest.StartDate, transactionDetailsRequest.EndDate)
+		var openReq OpenAccountRequest
+		err := json.NewDecoder(req.Body).Decode(&openReq)
+		if err != nil {
 			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
 			return
 		}
-		diffStartAndEndDate := endDateTime.Sub(startDateTime)
-		if diffStartAndEndDate.Hours()/24 > 30 {
-			err = fmt.Errorf("difference between the start date and end date must be less than or equal to 30 days")
-			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+		if openReq.AccountType == "" {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Account type must be provided"})
 			return
 		}
 
-		transactions, err := b.GetTransactionDetails(req.Context(), accId, claims.UserID, transactionDetailsRequest.StartDate, transactionDetailsRequest.EndDate)
+		acc, err := s.OpenAccount(req.Context(), customerID, openReq)
 		if err != nil {
-			if err == db.ErrAccountNotExist {
-				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+			if errors.Is(err, ErrInvalidCurrency) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountTypeNotExist {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid account type"})
+				return
+			}
+
+			if err == db.ErrUserNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Customer does not exist"})
 				return
 			}
 
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error - Failure opening account"})
+			return
+		}
+
+		api.Success(rw, http.StatusCreated, acc)
+	})
+}
+
+func GetUserAccountsHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		accounts, err := s.GetUserAccounts(req.Context(), claims.UserID)
+		if err != nil {
 			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
 			return
 		}
 
-		api.Success(rw, http.StatusOK, transactions)
+		api.Success(rw, http.StatusOK, accounts)
+	})
+}
+
+func GetAccountsHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		accounts, err := s.GetAccountList(req.Context())
+		if err != nil {
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, accounts)
+	})
+}
+
+func GetAccountDetailsHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accID := params["account_id"]
+
+		acc, err := s.GetAccountDetails(req.Context(), accID, claims.UserID)
+		if err != nil {
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, acc)
+	})
+}
+
+func DepositAmountHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var depositAmountRequest DepositWithdrawAmountRequest
+		err := json.NewDecoder(req.Body).Decode(&depositAmountRequest)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		err = s.DepositAmount(req.Context(), accId, claims.UserID, depositAmountRequest.Amount, depositAmountRequest.Currency)
+		if err != nil {
+			if errors.Is(err, ErrInvalidAmount) || errors.Is(err, ErrCurrencyMismatch) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			// the balance after a deposit must cover its fees
+			if err == db.ErrCreditNotAllowed || err == db.ErrInsufficientFunds {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully credited account with amount %v", depositAmountRequest.Amount)})
+
+	})
+}
+
+func WithdrawAmountHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var withdrawAmountRequest DepositWithdrawAmountRequest
+		err := json.NewDecoder(req.Body).Decode(&withdrawAmountRequest)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		err = s.WithdrawAmount(req.Context(), accId, claims.UserID, withdrawAmountRequest.Amount, withdrawAmountRequest.Currency)
+		if err != nil {
+			if errors.Is(err, ErrInvalidAmount) || errors.Is(err, ErrCurrencyMismatch) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if errors.Is(err, db.ErrLimitExceeded) {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrOperationNotAllowed || err == db.ErrDebitNotAllowed {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrInsufficientFunds {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully debited account with amount %v", withdrawAmountRequest.Amount)})
+	})
+}
+
+func TransferAmountHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var transferAmountRequest TransferAmountRequest
+		err := json.NewDecoder(req.Body).Decode(&transferAmountRequest)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+		if transferAmountRequest.ToAccountID == "" {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Destination account must be provided"})
+			return
+		}
+
+		transfer, err := s.TransferAmount(req.Context(), accId, claims.UserID, transferAmountRequest.ToAccountID, transferAmountRequest.Amount, transferAmountRequest.Currency)
+		if err != nil {
+			if errors.Is(err, ErrInvalidAmount) || errors.Is(err, ErrCurrencyMismatch) || err == ErrSameAccount {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if errors.Is(err, db.ErrLimitExceeded) {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrOperationNotAllowed || err == db.ErrDebitNotAllowed || err == db.ErrPayeeCreditNotAllowed ||
+				err == db.ErrFXRateNotExist || err == db.ErrAmountTooSmall {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrInsufficientFunds {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrPayeeNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Destination account does not exist"})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, transfer)
+	})
+}
+
+// validateDateRange checks the formats of the start and end date of a
+// transaction history, which may span up to 30 days.
+func validateDateRange(startDate, endDate string) (err error) {
+	startDateTime, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return fmt.Errorf("error parsing startdate: %v", startDate)
+	}
+	endDateTime, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return fmt.Errorf("error parsing enddate %v", endDate)
+	}
+
+	// Validate the difference between the days should be between 1-30 days
+	if startDateTime == endDateTime || startDateTime.After(endDateTime) {
+		return fmt.Errorf("start date: %v must be less than end date: %v", startDate, endDate)
+	}
+	diffStartAndEndDate := endDateTime.Sub(startDateTime)
+	if diffStartAndEndDate.Hours()/24 > 30 {
+		return fmt.Errorf("difference between the start date and end date must be less than or equal to 30 days")
+	}
+	return
+}
+
+func GetTransactionDetailsHandler(b Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var transactionDetailsRequest GetTransactionDetailsRequest
+		err := json.NewDecoder(req.Body).Decode(&transactionDetailsRequest)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		if err = validateDateRange(transactionDetailsRequest.StartDate, transactionDetailsRequest.EndDate); err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		transactions, err := b.GetTransactionDetails(req.Context(), accId, claims.UserID, transactionDetailsRequest.StartDate, transactionDetailsRequest.EndDate)
+		if err != nil {
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, transactions)
+	})
+}
+
+// GetTransactionHistoryHandler pages through the transactions of an account.
+// The optional query parameters start_date, end_date, type (repeated for
+// several types), min_amount and max_amount filter the transactions; sort
+// orders them asc or desc by time, and limit and cursor page through them.
+func GetTransactionHistoryHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		query := req.URL.Query()
+		historyReq := TransactionHistoryRequest{
+			StartDate: query.Get("start_date"),
+			EndDate:   query.Get("end_date"),
+			Types:     query["type"],
+			Sort:      query.Get("sort"),
+			Cursor:    query.Get("cursor"),
+		}
+
+		if value := query.Get("min_amount"); value != "" {
+			a, err := money.ParseAmount(value)
+			if err != nil {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid min_amount"})
+				return
+			}
+			historyReq.MinAmount = &a
+		}
+		if value := query.Get("max_amount"); value != "" {
+			a, err := money.ParseAmount(value)
+			if err != nil {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid max_amount"})
+				return
+			}
+			historyReq.MaxAmount = &a
+		}
+		if value := query.Get("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid limit"})
+				return
+			}
+			historyReq.Limit = n
+		}
+
+		page, err := s.GetTransactionHistory(req.Context(), accId, claims.UserID, historyReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidTransactionFilter) || err == db.ErrInvalidCursor {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, page)
+	})
+}
+
+// GetStatementHandler serves the statement of an account for a month, as PDF
+// or, with the query parameter format=csv, as CSV.
+func GetStatementHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		month, err := time.Parse("2006-01", params["month"])
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Month must be given as yyyy-mm"})
+			return
+		}
+		format := req.URL.Query().Get("format")
+		if format == "" {
+			format = statement.FormatPDF
+		}
+		if format != statement.FormatPDF && format != statement.FormatCSV {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Format must be pdf or csv"})
+			return
+		}
+
+		st, err := s.GetStatement(req.Context(), accId, claims.UserID, month)
+		if err != nil {
+			if err == ErrInvalidStatementMonth {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		var buf bytes.Buffer
+		if err = statement.Write(&buf, st, format); err != nil {
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		rw.Header().Add("Content-Type", statement.ContentType(format))
+		rw.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=%q", statement.FileName(accId, month, format)))
+		rw.WriteHeader(http.StatusOK)
+		rw.Write(buf.Bytes())
+	})
+}
+
+func StaffGetAccountDetailsHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accID := params["account_id"]
+		access := StaffAccess{Reason: req.URL.Query().Get("reason"), IP: clientIP(req)}
+
+		acc, err := s.StaffGetAccountDetails(req.Context(), claims, access, accID)
+		if err != nil {
+			if err == ErrReasonRequired {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, acc)
+	})
+}
+
+func StaffDepositAmountHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var depositAmountRequest StaffDepositWithdrawAmountRequest
+		err := json.NewDecoder(req.Body).Decode(&depositAmountRequest)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+		access := StaffAccess{Reason: depositAmountRequest.Reason, IP: clientIP(req)}
+
+		err = s.StaffDepositAmount(req.Context(), claims, access, accId, depositAmountRequest.Amount, depositAmountRequest.Currency)
+		if err != nil {
+			if errors.Is(err, ErrInvalidAmount) || errors.Is(err, ErrCurrencyMismatch) || err == ErrReasonRequired {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			// the balance after a deposit must cover its fees
+			if err == db.ErrCreditNotAllowed || err == db.ErrInsufficientFunds {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully credited account with amount %v", depositAmountRequest.Amount)})
+	})
+}
+
+func StaffWithdrawAmountHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var withdrawAmountRequest StaffDepositWithdrawAmountRequest
+		err := json.NewDecoder(req.Body).Decode(&withdrawAmountRequest)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+		access := StaffAccess{Reason: withdrawAmountRequest.Reason, IP: clientIP(req)}
+
+		err = s.StaffWithdrawAmount(req.Context(), claims, access, accId, withdrawAmountRequest.Amount, withdrawAmountRequest.Currency)
+		if err != nil {
+			if errors.Is(err, ErrInvalidAmount) || errors.Is(err, ErrCurrencyMismatch) || err == ErrReasonRequired {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if errors.Is(err, db.ErrLimitExceeded) {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrOperationNotAllowed || err == db.ErrDebitNotAllowed {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrInsufficientFunds {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully debited account with amount %v", withdrawAmountRequest.Amount)})
+	})
+}
+
+func StaffTransferAmountHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var transferAmountRequest StaffTransferAmountRequest
+		err := json.NewDecoder(req.Body).Decode(&transferAmountRequest)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+		if transferAmountRequest.ToAccountID == "" {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Destination account must be provided"})
+			return
+		}
+		access := StaffAccess{Reason: transferAmountRequest.Reason, IP: clientIP(req)}
+
+		transfer, err := s.StaffTransferAmount(req.Context(), claims, access, accId, transferAmountRequest.ToAccountID, transferAmountRequest.Amount, transferAmountRequest.Currency)
+		if err != nil {
+			if errors.Is(err, ErrInvalidAmount) || errors.Is(err, ErrCurrencyMismatch) || err == ErrSameAccount || err == ErrReasonRequired {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if errors.Is(err, db.ErrLimitExceeded) {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrOperationNotAllowed || err == db.ErrDebitNotAllowed || err == db.ErrPayeeCreditNotAllowed ||
+				err == db.ErrFXRateNotExist || err == db.ErrAmountTooSmall {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrInsufficientFunds {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrPayeeNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Destination account does not exist"})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, transfer)
+	})
+}
+
+func StaffGetTransactionDetailsHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var transactionDetailsRequest StaffGetTransactionDetailsRequest
+		err := json.NewDecoder(req.Body).Decode(&transactionDetailsRequest)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		if err = validateDateRange(transactionDetailsRequest.StartDate, transactionDetailsRequest.EndDate); err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+		access := StaffAccess{Reason: transactionDetailsRequest.Reason, IP: clientIP(req)}
+
+		transactions, err := s.StaffGetTransactionDetails(req.Context(), claims, access, accId, transactionDetailsRequest.StartDate, transactionDetailsRequest.EndDate)
+		if err != nil {
+			if err == ErrReasonRequired {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, transactions)
+	})
+}
+
+// GetAuditLogHandler lists audit entries, newest first. The optional query
+// parameters action, actor_id and subject filter the entries; limit and
+// before_id page through them.
+func GetAuditLogHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		f := db.AuditFilter{
+			Action:  query.Get("action"),
+			Subject: query.Get("subject"),
+		}
+
+		if actorID := query.Get("actor_id"); actorID != "" {
+			if !regexp.MustCompile(`^\d+$`).MatchString(actorID) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid actor id"})
+				return
+			}
+			f.ActorID = &actorID
+		}
+
+		if value := query.Get("before_id"); value != "" {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || n <= 0 {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid before_id"})
+				return
+			}
+			f.BeforeID = n
+		}
+		if value := query.Get("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid limit"})
+				return
+			}
+			f.Limit = n
+		}
+
+		entries, err := s.GetAuditLog(req.Context(), f)
+		if err != nil {
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, entries)
+	})
+}
+
+func ChangeAccountStatusHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var statusReq ChangeAccountStatusRequest
+		err := json.NewDecoder(req.Body).Decode(&statusReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+		statusReq.IP = clientIP(req)
+
+		err = s.ChangeAccountStatus(req.Context(), claims, accId, statusReq)
+		if err != nil {
+			if err == ErrInvalidAccountStatus || err == ErrReasonRequired {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountStatusConflict {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully changed account status to %v", statusReq.Status)})
+	})
+}
+
+func CloseAccountHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var closeReq CloseAccountRequest
+		err := json.NewDecoder(req.Body).Decode(&closeReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+		closeReq.IP = clientIP(req)
+
+		closure, err := s.CloseAccount(req.Context(), claims, accId, closeReq)
+		if err != nil {
+			if err == ErrReasonRequired || err == ErrSameAccount {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountStatusConflict || err == db.ErrBalanceNotZero || err == db.ErrHoldsActive {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrPayeeCreditNotAllowed || err == db.ErrCurrencyMismatch {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrPayeeNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Payout account does not exist"})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, closure)
+	})
+}
+
+func SetOverdraftLimitHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var overdraftReq SetOverdraftLimitRequest
+		err := json.NewDecoder(req.Body).Decode(&overdraftReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+		overdraftReq.IP = clientIP(req)
+
+		err = s.SetOverdraftLimit(req.Context(), claims, accId, overdraftReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidAmount) || err == ErrReasonRequired {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountStatusConflict {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrOperationNotAllowed {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, api.Response{Message: fmt.Sprintf("Successfully set overdraft limit to %v", overdraftReq.Limit)})
+	})
+}
+
+func CreateStandingOrderHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var orderReq CreateStandingOrderRequest
+		err := json.NewDecoder(req.Body).Decode(&orderReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		order, err := s.CreateStandingOrder(req.Context(), accId, claims.UserID, orderReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidStandingOrder) || errors.Is(err, ErrInvalidAmount) || err == ErrSameAccount {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrPayeeNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Destination account does not exist"})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusCreated, order)
+	})
+}
+
+func GetStandingOrdersHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		orders, err := s.GetStandingOrders(req.Context(), accId, claims.UserID)
+		if err != nil {
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, orders)
+	})
+}
+
+func GetStandingOrderExecutionsHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+		orderId := params["order_id"]
+
+		executions, err := s.GetStandingOrderExecutions(req.Context(), accId, orderId, claims.UserID)
+		if err != nil {
+			if err == db.ErrStandingOrderNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Standing order does not exist"})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, executions)
+	})
+}
+
+func CancelStandingOrderHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+		orderId := params["order_id"]
+
+		err := s.CancelStandingOrder(req.Context(), accId, orderId, claims.UserID)
+		if err != nil {
+			if err == db.ErrStandingOrderNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Standing order does not exist"})
+				return
+			}
+
+			if err == db.ErrStandingOrderNotActive {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, api.Response{Message: "Successfully cancelled the standing order"})
+	})
+}
+
+func GetAccountLimitsHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		limits, err := s.GetAccountLimits(req.Context(), accId, claims.UserID)
+		if err != nil {
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, limits)
+	})
+}
+
+func SetAccountLimitsHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var limitsReq SetDebitLimitsRequest
+		err := json.NewDecoder(req.Body).Decode(&limitsReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+		limitsReq.IP = clientIP(req)
+
+		err = s.SetAccountLimits(req.Context(), claims, accId, limitsReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidAmount) || err == ErrReasonRequired {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, api.Response{Message: "Successfully set the debit limits of the account"})
+	})
+}
+
+func GetFXRatesHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rates, err := s.GetFXRates(req.Context())
+		if err != nil {
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, rates)
+	})
+}
+
+func CreateFXRateHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		var rateReq FXRateRequest
+		err := json.NewDecoder(req.Body).Decode(&rateReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		rate, err := s.CreateFXRate(req.Context(), claims, rateReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidFXRate) || errors.Is(err, ErrInvalidCurrency) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusCreated, rate)
+	})
+}
+
+func GetFeeRulesHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rules, err := s.GetFeeRules(req.Context())
+		if err != nil {
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, rules)
+	})
+}
+
+func CreateFeeRuleHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		var ruleReq FeeRuleRequest
+		err := json.NewDecoder(req.Body).Decode(&ruleReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		rule, err := s.CreateFeeRule(req.Context(), claims, ruleReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidFeeRule) || errors.Is(err, ErrInvalidAmount) || errors.Is(err, ErrInvalidCurrency) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountTypeNotExist {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid account type"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusCreated, rule)
+	})
+}
+
+func UpdateFeeRuleHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		ruleID, err := strconv.ParseInt(params["rule_id"], 10, 64)
+		if err != nil || ruleID <= 0 {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid rule id"})
+			return
+		}
+
+		var ruleReq FeeRuleRequest
+		err = json.NewDecoder(req.Body).Decode(&ruleReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		rule, err := s.UpdateFeeRule(req.Context(), claims, ruleID, ruleReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidFeeRule) || errors.Is(err, ErrInvalidAmount) || errors.Is(err, ErrInvalidCurrency) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountTypeNotExist {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Invalid account type"})
+				return
+			}
+
+			if err == db.ErrFeeRuleNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Fee rule does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, rule)
+	})
+}
+
+func CreateHoldHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		var holdReq CreateHoldRequest
+		err := json.NewDecoder(req.Body).Decode(&holdReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		hold, err := s.CreateHold(req.Context(), claims, accId, holdReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidHold) || errors.Is(err, ErrInvalidAmount) || errors.Is(err, ErrCurrencyMismatch) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrHoldReferenceExists {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrInsufficientFunds || errors.Is(err, db.ErrLimitExceeded) ||
+				err == db.ErrOperationNotAllowed || err == db.ErrDebitNotAllowed {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusCreated, hold)
+	})
+}
+
+func GetHoldsHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		params := mux.Vars(req)
+		accId := params["account_id"]
+
+		holds, err := s.GetHolds(req.Context(), accId)
+		if err != nil {
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, holds)
+	})
+}
+
+func CaptureHoldHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+		holdId := params["hold_id"]
+
+		// the body is optional, without it the whole hold is captured
+		var captureReq CaptureHoldRequest
+		err := json.NewDecoder(req.Body).Decode(&captureReq)
+		if err != nil && err != io.EOF {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		hold, err := s.CaptureHold(req.Context(), claims, accId, holdId, captureReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidAmount) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrHoldNotActive {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrCaptureExceedsHold || err == db.ErrDebitNotAllowed {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrHoldNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Hold does not exist"})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, hold)
+	})
+}
+
+func ReleaseHoldHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+		holdId := params["hold_id"]
+
+		hold, err := s.ReleaseHold(req.Context(), claims, accId, holdId)
+		if err != nil {
+			if err == db.ErrHoldNotActive {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrHoldNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Hold does not exist"})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusOK, hold)
+	})
+}
+
+func ReverseTransactionHandler(s Service) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims := requestClaims(req)
+
+		params := mux.Vars(req)
+		accId := params["account_id"]
+		transactionId := params["transaction_id"]
+
+		var reverseReq ReverseTransactionRequest
+		err := json.NewDecoder(req.Body).Decode(&reverseReq)
+		if err != nil {
+			api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+			return
+		}
+
+		reversal, err := s.ReverseTransaction(req.Context(), claims, accId, transactionId, reverseReq)
+		if err != nil {
+			if errors.Is(err, ErrInvalidReversal) || errors.Is(err, ErrInvalidAmount) {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrTransactionReversed || err == db.ErrAccountStatusConflict {
+				api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrTransactionNotReversible || err == db.ErrAmountTooSmall || errors.Is(err, db.ErrReversalExceedsTransaction) {
+				api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				return
+			}
+
+			if err == db.ErrTransactionNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Transaction does not exist"})
+				return
+			}
+
+			if err == db.ErrAccountNotExist {
+				api.Error(rw, http.StatusNotFound, api.Response{Message: "Err - Account does not exist"})
+				return
+			}
+
+			api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+			return
+		}
+
+		api.Success(rw, http.StatusCreated, reversal)
 	})
 }
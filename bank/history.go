@@ -0,0 +1,82 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/banking/db"
+)
+
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 500
+)
+
+var transactionTypes = []string{"Credit", "Debit", "Fee", "Interest", "Overdraft Interest", db.TransactionCreditReversal, db.TransactionDebitReversal}
+
+// GetTransactionHistory pages through the transactions of an account, latest
+// first unless asked otherwise. The end date is inclusive.
+func (b *bankService) GetTransactionHistory(ctx context.Context, accId, userID string, req TransactionHistoryRequest) (page db.TransactionPage, err error) {
+	f := db.TransactionFilter{
+		Types:     req.Types,
+		MinAmount: req.MinAmount,
+		MaxAmount: req.MaxAmount,
+		Sort:      req.Sort,
+		Cursor:    req.Cursor,
+		Limit:     req.Limit,
+	}
+
+	if req.StartDate != "" {
+		if f.From, err = time.Parse("2006-01-02", req.StartDate); err != nil {
+			err = fmt.Errorf("%w: start_date must be a date like 2006-01-02", ErrInvalidTransactionFilter)
+			return
+		}
+	}
+	if req.EndDate != "" {
+		if f.To, err = time.Parse("2006-01-02", req.EndDate); err != nil {
+			err = fmt.Errorf("%w: end_date must be a date like 2006-01-02", ErrInvalidTransactionFilter)
+			return
+		}
+		f.To = f.To.AddDate(0, 0, 1)
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		err = fmt.Errorf("%w: start_date must not be after end_date", ErrInvalidTransactionFilter)
+		return
+	}
+
+	for _, t := range f.Types {
+		if !containsString(transactionTypes, t) {
+			err = fmt.Errorf("%w: type must be one of %v", ErrInvalidTransactionFilter, strings.Join(transactionTypes, ", "))
+			return
+		}
+	}
+	switch {
+	case f.MinAmount != nil && f.MinAmount.Sign() < 0, f.MaxAmount != nil && f.MaxAmount.Sign() < 0:
+		err = fmt.Errorf("%w: amounts must not be negative", ErrInvalidTransactionFilter)
+		return
+	case f.MinAmount != nil && f.MaxAmount != nil && f.MinAmount.Cmp(*f.MaxAmount) > 0:
+		err = fmt.Errorf("%w: min_amount must not be more than max_amount", ErrInvalidTransactionFilter)
+		return
+	}
+
+	switch f.Sort {
+	case "":
+		f.Sort = db.SortDescending
+	case db.SortAscending, db.SortDescending:
+	default:
+		err = fmt.Errorf("%w: sort must be %v or %v", ErrInvalidTransactionFilter, db.SortAscending, db.SortDescending)
+		return
+	}
+	if f.Limit <= 0 {
+		f.Limit = defaultHistoryLimit
+	}
+	if f.Limit > maxHistoryLimit {
+		f.Limit = maxHistoryLimit
+	}
+
+	b.logger.Infof("Getting transaction history of account: %v\n", accId)
+	page, err = b.store.GetTransactions(ctx, accId, userID, f)
+	return
+}
@@ -0,0 +1,153 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/config"
+	"example.com/banking/db"
+)
+
+// maxHoldReferenceLength is the size of the reference column of holds
+const maxHoldReferenceLength = 64
+
+// holdPolicy decides how long holds last.
+type holdPolicy struct {
+	defaultExpiry time.Duration
+	maxExpiry     time.Duration
+}
+
+func newHoldPolicy() holdPolicy {
+	c := config.Holds()
+	return holdPolicy{
+		defaultExpiry: c.DefaultExpiry(),
+		maxExpiry:     c.MaxExpiry(),
+	}
+}
+
+// expiry checks when a hold requested at now expires.
+func (p holdPolicy) expiry(requested *time.Time, now time.Time) (expiresAt time.Time, err error) {
+	expiresAt = now.Add(p.defaultExpiry)
+	if requested != nil {
+		expiresAt = *requested
+	}
+
+	switch {
+	case !expiresAt.After(now):
+		err = fmt.Errorf("%w: expires_at must be in the future", ErrInvalidHold)
+	case expiresAt.After(now.Add(p.maxExpiry)):
+		err = fmt.Errorf("%w: a hold may last %v at most", ErrInvalidHold, p.maxExpiry)
+	}
+	return
+}
+
+// CreateHold reserves money of an account for a payment that settles later.
+func (b *bankService) CreateHold(ctx context.Context, claims *Claims, accId string, req CreateHoldRequest) (hold db.Hold, err error) {
+	reference := strings.TrimSpace(req.Reference)
+	if reference == "" || len(reference) > maxHoldReferenceLength {
+		err = fmt.Errorf("%w: reference must be given, up to %d characters", ErrInvalidHold, maxHoldReferenceLength)
+		return
+	}
+
+	now := time.Now()
+	expiresAt, err := b.holdPolicy.expiry(req.ExpiresAt, now)
+	if err != nil {
+		return
+	}
+
+	cur, err := b.accountCurrency(ctx, accId, db.AnyOwner, req.Currency)
+	if err != nil {
+		return
+	}
+	amount, err := validateAmount(req.Amount, cur)
+	if err != nil {
+		return
+	}
+
+	b.logger.Infof("User: %v placing a hold of amount: %v on account: %v\n", claims.UserID, amount, accId)
+	hold, err = b.store.CreateHold(ctx, db.Hold{
+		ID:        uuidgen.New(),
+		AccountID: accId,
+		Amount:    amount,
+		Reference: reference,
+		ExpiresAt: expiresAt,
+		CreatedBy: &claims.UserID,
+	})
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditHoldPlaced,
+		ActorID: &claims.UserID,
+		Subject: accId,
+		Details: fmt.Sprintf("hold: %v, amount: %v, reference: %v, expires at: %v", hold.ID, hold.Amount, hold.Reference, hold.ExpiresAt.Format(time.RFC3339)),
+	})
+	return
+}
+
+func (b *bankService) GetHolds(ctx context.Context, accId string) (holds []db.Hold, err error) {
+	holds, err = b.store.GetAccountHolds(ctx, accId)
+	return
+}
+
+// CaptureHold settles a hold by debiting the account with the final amount
+// of the payment, releasing the rest of the hold.
+func (b *bankService) CaptureHold(ctx context.Context, claims *Claims, accId, holdId string, req CaptureHoldRequest) (hold db.Hold, err error) {
+	amount := req.Amount
+	if amount != nil {
+		cur, err := b.accountCurrency(ctx, accId, db.AnyOwner, "")
+		if err != nil {
+			return hold, err
+		}
+		a, err := validateAmount(*amount, cur)
+		if err != nil {
+			return hold, err
+		}
+		amount = &a
+	}
+
+	b.logger.Infof("User: %v capturing hold: %v on account: %v\n", claims.UserID, holdId, accId)
+	hold, err = b.store.CaptureHold(ctx, accId, holdId, amount)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditHoldCaptured,
+		ActorID: &claims.UserID,
+		Subject: accId,
+		Details: fmt.Sprintf("hold: %v, amount: %v, captured: %v, transaction: %v", hold.ID, hold.Amount, *hold.CapturedAmount, *hold.TransactionID),
+	})
+	return
+}
+
+// ReleaseHold cancels a hold, giving its money back to the account.
+func (b *bankService) ReleaseHold(ctx context.Context, claims *Claims, accId, holdId string) (hold db.Hold, err error) {
+	b.logger.Infof("User: %v releasing hold: %v on account: %v\n", claims.UserID, holdId, accId)
+	hold, err = b.store.ReleaseHold(ctx, accId, holdId)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditHoldReleased,
+		ActorID: &claims.UserID,
+		Subject: accId,
+		Details: fmt.Sprintf("hold: %v, amount: %v", hold.ID, hold.Amount),
+	})
+	return
+}
+
+// ExpireHolds releases the holds that expired by now and returns how many
+// there were.
+func (b *bankService) ExpireHolds(ctx context.Context, now time.Time) (expired int, err error) {
+	holds, err := b.store.ExpireHolds(ctx, now)
+	expired = len(holds)
+	b.logger.Infof("Released expired holds: %v\n", expired)
+	return
+}
@@ -0,0 +1,30 @@
+package bank
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoldPolicyExpiry(t *testing.T) {
+	p := holdPolicy{defaultExpiry: 7 * 24 * time.Hour, maxExpiry: 30 * 24 * time.Hour}
+	now := time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	expiresAt, err := p.expiry(nil, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, 7), expiresAt)
+
+	expiresAt, err = p.expiry(at(time.Hour), now)
+	assert.NoError(t, err)
+	assert.Equal(t, *at(time.Hour), expiresAt)
+
+	for _, requested := range []*time.Time{at(0), at(-time.Hour), at(31 * 24 * time.Hour)} {
+		_, err = p.expiry(requested, now)
+		assert.ErrorIs(t, err, ErrInvalidHold, requested.String())
+	}
+}
@@ -0,0 +1,117 @@
+package bank
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"example.com/banking/api"
+	"example.com/banking/app"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+// responseRecorder passes the response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Idempotent makes a mutating handler safe to retry. When the request carries
+// an Idempotency-Key header, the first response for that key is stored and
+// returned again for every retry of the same request, without calling next.
+// Reusing the key for a different request is rejected with 422. It must run
+// after Authenticate.
+func Idempotent(s Service) func(next http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			key := req.Header.Get(idempotencyKeyHeader)
+			if key == "" {
+				next(rw, req)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: "Err - Idempotency-Key must not be longer than 255 characters"})
+				return
+			}
+
+			// Keys are scoped to the caller, so this must run after Authenticate
+			claims, ok := ClaimsFromContext(req.Context())
+			if !ok {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			// one byte more than allowed is read to tell a body that is too
+			// large from one that fits exactly
+			body, err := io.ReadAll(io.LimitReader(req.Body, maxIdempotentRequestBytes+1))
+			if err != nil {
+				api.Error(rw, http.StatusBadRequest, api.Response{Message: err.Error()})
+				return
+			}
+			if len(body) > maxIdempotentRequestBytes {
+				api.Error(rw, http.StatusRequestEntityTooLarge, api.Response{Message: "Err - Request body must not be larger than 1MB"})
+				return
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			saved, replay, err := s.StartIdempotentRequest(req.Context(), claims.UserID, key, fingerprint(req, body))
+			if err != nil {
+				switch err {
+				case ErrIdempotencyKeyConflict:
+					api.Error(rw, http.StatusUnprocessableEntity, api.Response{Message: err.Error()})
+				case ErrIdempotencyKeyInProgress:
+					api.Error(rw, http.StatusConflict, api.Response{Message: err.Error()})
+				default:
+					api.Error(rw, http.StatusInternalServerError, api.Response{Message: err.Error()})
+				}
+				return
+			}
+
+			if replay {
+				rw.Header().Add("Content-Type", "application/json")
+				rw.Header().Add(idempotentReplayedHeader, "true")
+				rw.WriteHeader(*saved.StatusCode)
+				rw.Write(saved.ResponseBody)
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: rw}
+			next(recorder, req)
+
+			err = s.CompleteIdempotentRequest(req.Context(), claims.UserID, key, recorder.statusCode, recorder.body.Bytes())
+			if err != nil {
+				app.GetLogger().Errorf("Err saving response for idempotency key: %v, err: %v", key, err)
+			}
+		})
+	}
+}
+
+// fingerprint identifies a request by its method, path and body.
+func fingerprint(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
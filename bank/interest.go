@@ -0,0 +1,87 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"example.com/banking/db"
+	"example.com/banking/interest"
+)
+
+// AccrueInterest accrues a day of interest on every account that earns it,
+// or is overdrawn and charged overdraft interest, and posts the accrued
+// interest of the accounts whose posting period ends on the business date.
+// Running it again for the same date changes nothing, so past dates can be
+// backfilled.
+func (b *bankService) AccrueInterest(ctx context.Context, businessDate time.Time) (run InterestRun, err error) {
+	date := businessDate.Format("2006-01-02")
+	run.BusinessDate = date
+	// the day must be over, accruals use the balance at its end
+	if date >= time.Now().Format("2006-01-02") {
+		err = ErrInvalidBusinessDate
+		return
+	}
+
+	accounts, err := b.store.GetInterestAccounts(ctx, businessDate)
+	if err != nil {
+		return
+	}
+	run.Accounts = len(accounts)
+
+	for _, acc := range accounts {
+		accrued, posted, err := b.accrueAccountInterest(ctx, acc, businessDate)
+		if err != nil {
+			b.logger.Errorf("Err accruing interest for account: %v, business date: %v, err: %v", acc.AccountID, date, err)
+			run.Failed++
+			continue
+		}
+		if accrued {
+			run.Accrued++
+		}
+		if posted {
+			run.Posted++
+		}
+	}
+
+	b.logger.Infof("Accrued interest for business date: %v, accounts: %v, accrued: %v, posted: %v, failed: %v\n",
+		date, run.Accounts, run.Accrued, run.Posted, run.Failed)
+	if run.Failed > 0 {
+		err = fmt.Errorf("interest failed for %d of %d accounts", run.Failed, run.Accounts)
+	}
+	return
+}
+
+func (b *bankService) accrueAccountInterest(ctx context.Context, acc db.InterestAccount, businessDate time.Time) (accrued, posted bool, err error) {
+	product := interest.Product{
+		Name:             acc.Product,
+		AnnualRate:       acc.AnnualRate,
+		DayCount:         acc.DayCount,
+		Compounding:      acc.Compounding,
+		PostingFrequency: acc.PostingFrequency,
+		Kind:             acc.Kind,
+	}
+
+	if !acc.AccruedOnDate {
+		err = b.store.AddInterestAccrual(ctx, db.InterestAccrual{
+			AccountID:    acc.AccountID,
+			BusinessDate: businessDate,
+			Product:      acc.Product,
+			Balance:      acc.Balance,
+			Amount:       product.DailyAccrual(acc.Balance, acc.Accrued, businessDate),
+		})
+		if err != nil {
+			return
+		}
+		accrued = true
+	}
+
+	if product.IsPostingDate(businessDate) {
+		amount, err := b.store.PostInterest(ctx, acc.AccountID, businessDate)
+		if err != nil {
+			return accrued, false, err
+		}
+		posted = !amount.IsZero()
+	}
+	return
+}
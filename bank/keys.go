@@ -0,0 +1,196 @@
+package bank
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const defaultKeyID = "default"
+
+var ErrUnknownSigningKey = errors.New("unknown signing key")
+
+// signingKey is a JWT key together with the algorithm it is used with.
+// For HMAC keys the same secret signs and verifies.
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+type keySet struct {
+	signing  *signingKey
+	keys     map[string]*signingKey
+	tokenTTL time.Duration
+}
+
+var jwtKeys = &keySet{keys: map[string]*signingKey{}}
+
+// LoadSigningKeys sets up the keys used for issuing and validating JWTs.
+// Every key file in keysDir and the HMAC secret, if given, can verify tokens;
+// new tokens are signed with the key signingKeyID and live for tokenTTL.
+func LoadSigningKeys(keysDir, secret, signingKeyID string, tokenTTL time.Duration) (err error) {
+	ks := &keySet{
+		keys:     map[string]*signingKey{},
+		tokenTTL: tokenTTL,
+	}
+
+	if secret != "" {
+		ks.keys[defaultKeyID] = newHMACKey(defaultKeyID, []byte(secret))
+	}
+
+	if keysDir != "" {
+		files, err := os.ReadDir(keysDir)
+		if err != nil {
+			return fmt.Errorf("error reading jwt keys dir: %v", err)
+		}
+
+		for _, f := range files {
+			if f.IsDir() {
+				continue
+			}
+
+			key, err := readKeyFile(filepath.Join(keysDir, f.Name()))
+			if err != nil {
+				return err
+			}
+			if key != nil {
+				ks.keys[key.id] = key
+			}
+		}
+	}
+
+	signing, ok := ks.keys[signingKeyID]
+	if !ok {
+		return fmt.Errorf("%w: signing key %q is not configured", ErrUnknownSigningKey, signingKeyID)
+	}
+	ks.signing = signing
+
+	jwtKeys = ks
+	return
+}
+
+func newHMACKey(id string, secret []byte) *signingKey {
+	return &signingKey{
+		id:        id,
+		method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+// readKeyFile reads <kid>.pem (RSA or ECDSA private key) or <kid>.secret
+// (HMAC secret) files. Other files are ignored.
+func readKeyFile(path string) (key *signingKey, err error) {
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+	if ext != ".pem" && ext != ".secret" {
+		return
+	}
+	id := strings.TrimSuffix(name, ext)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	if ext == ".secret" {
+		return newHMACKey(id, []byte(strings.TrimSpace(string(data)))), nil
+	}
+
+	if rsaKey, e := jwt.ParseRSAPrivateKeyFromPEM(data); e == nil {
+		return &signingKey{id: id, method: jwt.SigningMethodRS256, signKey: rsaKey, verifyKey: &rsaKey.PublicKey}, nil
+	}
+
+	ecKey, err := jwt.ParseECPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading jwt key %v: not an RSA or ECDSA private key", name)
+	}
+
+	var method jwt.SigningMethod
+	switch ecKey.Curve.Params().BitSize {
+	case 256:
+		method = jwt.SigningMethodES256
+	case 384:
+		method = jwt.SigningMethodES384
+	case 521:
+		method = jwt.SigningMethodES512
+	default:
+		return nil, fmt.Errorf("error reading jwt key %v: unsupported curve", name)
+	}
+	return &signingKey{id: id, method: method, signKey: ecKey, verifyKey: &ecKey.PublicKey}, nil
+}
+
+// verificationKey picks the key a token claims to be signed with, and makes
+// sure the token uses the algorithm that key belongs to.
+func (ks *keySet) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSigningKey, kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %v for key %q", token.Method.Alg(), kid)
+	}
+	return key.verifyKey, nil
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// publicKeys returns the public part of every asymmetric key. HMAC secrets
+// are never published.
+func (ks *keySet) publicKeys() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range ks.keys {
+		switch pub := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			size := (pub.Curve.Params().BitSize + 7) / 8
+			set.Keys = append(set.Keys, JWK{
+				Kty: "EC",
+				Kid: key.id,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				Crv: pub.Curve.Params().Name,
+				X:   base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size))),
+				Y:   base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size))),
+			})
+		}
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
@@ -0,0 +1,129 @@
+package bank
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	uuidgen "github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKeyFiles(t *testing.T) string {
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rsa-2022-10.pem"), rsaPEM, 0600))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	ecPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ec-2022-11.pem"), ecPEM, 0600))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("ignored"), 0600))
+	return dir
+}
+
+func TestLoadSigningKeys_Rotation(t *testing.T) {
+	previous := jwtKeys
+	t.Cleanup(func() { jwtKeys = previous })
+
+	dir := writeKeyFiles(t)
+
+	require.NoError(t, LoadSigningKeys(dir, "", "rsa-2022-10", time.Minute))
+	rsaToken, expiresAt, err := generateJWT("1", RoleCustomer, uuidgen.New())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+
+	// rotate to the EC key, tokens signed with the RSA key stay valid
+	require.NoError(t, LoadSigningKeys(dir, "", "ec-2022-11", time.Minute))
+	ecToken, _, err := generateJWT("2", RoleCustomer, uuidgen.New())
+	require.NoError(t, err)
+
+	claims, err := parseJWT(rsaToken)
+	require.NoError(t, err)
+	assert.Equal(t, "1", claims.UserID)
+
+	claims, err = parseJWT(ecToken)
+	require.NoError(t, err)
+	assert.Equal(t, "2", claims.UserID)
+
+	parsed, _, err := new(jwt.Parser).ParseUnverified(ecToken, &Claims{})
+	require.NoError(t, err)
+	assert.Equal(t, "ec-2022-11", parsed.Header["kid"])
+	assert.Equal(t, "ES256", parsed.Method.Alg())
+
+	// once the RSA key is removed its tokens are rejected
+	require.NoError(t, os.Remove(filepath.Join(dir, "rsa-2022-10.pem")))
+	require.NoError(t, LoadSigningKeys(dir, "", "ec-2022-11", time.Minute))
+	_, err = parseJWT(rsaToken)
+	assert.Error(t, err)
+}
+
+func TestLoadSigningKeys_UnknownSigningKey(t *testing.T) {
+	previous := jwtKeys
+	t.Cleanup(func() { jwtKeys = previous })
+
+	err := LoadSigningKeys("", "secret", "missing", time.Minute)
+	assert.ErrorIs(t, err, ErrUnknownSigningKey)
+}
+
+func TestValidateJWT_RejectsAlgorithmMismatch(t *testing.T) {
+	previous := jwtKeys
+	t.Cleanup(func() { jwtKeys = previous })
+
+	dir := writeKeyFiles(t)
+	require.NoError(t, LoadSigningKeys(dir, "", "rsa-2022-10", time.Minute))
+
+	// an HS256 token keyed with the RSA public key must not validate
+	pub := jwtKeys.keys["rsa-2022-10"].verifyKey.(*rsa.PublicKey)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: "1", Role: RoleAccountant})
+	token.Header["kid"] = "rsa-2022-10"
+	forged, err := token.SignedString(x509.MarshalPKCS1PublicKey(pub))
+	require.NoError(t, err)
+
+	_, err = parseJWT(forged)
+	assert.Error(t, err)
+
+	// tokens without a key ID are rejected as well
+	token = jwt.NewWithClaims(jwt.SigningMethodRS256, &Claims{UserID: "1", Role: RoleAccountant})
+	unnamed, err := token.SignedString(jwtKeys.keys["rsa-2022-10"].signKey)
+	require.NoError(t, err)
+
+	_, err = parseJWT(unnamed)
+	assert.Error(t, err)
+}
+
+func TestKeySet_PublicKeys(t *testing.T) {
+	previous := jwtKeys
+	t.Cleanup(func() { jwtKeys = previous })
+
+	dir := writeKeyFiles(t)
+	require.NoError(t, LoadSigningKeys(dir, "secret", "default", time.Minute))
+
+	set := jwtKeys.publicKeys()
+	require.Len(t, set.Keys, 2, "the HMAC secret must not be published")
+
+	assert.Equal(t, "ec-2022-11", set.Keys[0].Kid)
+	assert.Equal(t, "EC", set.Keys[0].Kty)
+	assert.Equal(t, "P-256", set.Keys[0].Crv)
+	assert.Len(t, set.Keys[0].X, 43)
+
+	assert.Equal(t, "rsa-2022-10", set.Keys[1].Kid)
+	assert.Equal(t, "RSA", set.Keys[1].Kty)
+	assert.Equal(t, "RS256", set.Keys[1].Alg)
+	assert.Equal(t, "AQAB", set.Keys[1].E)
+}
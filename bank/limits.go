@@ -0,0 +1,74 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+
+	"example.com/banking/db"
+	"example.com/banking/money"
+)
+
+// GetAccountLimits returns the debit limits of the user's account and the
+// headroom left under them.
+func (b *bankService) GetAccountLimits(ctx context.Context, accId, userID string) (limits db.AccountLimits, err error) {
+	b.logger.Infof("Getting the debit limits of account: %v\n", accId)
+	limits, err = b.store.GetAccountLimits(ctx, accId, userID)
+	return
+}
+
+// SetAccountLimits sets debit limits of an account that replace the limits
+// of its type.
+func (b *bankService) SetAccountLimits(ctx context.Context, claims *Claims, accId string, req SetDebitLimitsRequest) (err error) {
+	reason, err := validateReason(req.Reason)
+	if err != nil {
+		return
+	}
+
+	cur, err := b.accountCurrency(ctx, accId, db.AnyOwner, "")
+	if err != nil {
+		return
+	}
+
+	limits := db.DebitLimits{}
+	for _, l := range []struct {
+		req   *money.Amount
+		limit **money.Amount
+	}{
+		{req.SingleDebitLimit, &limits.Single},
+		{req.DailyDebitLimit, &limits.Daily},
+		{req.MonthlyDebitLimit, &limits.Monthly},
+	} {
+		if l.req == nil {
+			continue
+		}
+		amount, err := validateAmount(*l.req, cur)
+		if err != nil {
+			return err
+		}
+		*l.limit = &amount
+	}
+
+	b.logger.Infof("User: %v setting debit limits of account: %v\n", claims.UserID, accId)
+	err = b.store.SetAccountDebitLimits(ctx, accId, limits)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditDebitLimitsChanged,
+		ActorID: &claims.UserID,
+		Subject: accId,
+		IP:      req.IP,
+		Details: fmt.Sprintf("single: %v, daily: %v, monthly: %v, reason: %v",
+			formatLimit(limits.Single), formatLimit(limits.Daily), formatLimit(limits.Monthly), reason),
+	})
+	return
+}
+
+// formatLimit shows a limit, or that the account type's limit applies.
+func formatLimit(limit *money.Amount) string {
+	if limit == nil {
+		return "account type default"
+	}
+	return limit.String()
+}
@@ -0,0 +1,62 @@
+package bank
+
+import (
+	"time"
+
+	"example.com/banking/config"
+	"example.com/banking/db"
+)
+
+// loginPolicy decides how long logins are blocked after failed attempts.
+type loginPolicy struct {
+	maxFailures      int
+	maxFailuresPerIP int
+	lockout          time.Duration
+	backoffBase      time.Duration
+}
+
+func newLoginPolicy() loginPolicy {
+	c := config.Login()
+	return loginPolicy{
+		maxFailures:      c.MaxFailedAttempts(),
+		maxFailuresPerIP: c.MaxFailedAttemptsPerIP(),
+		lockout:          c.Lockout(),
+		backoffBase:      c.BackoffBase(),
+	}
+}
+
+// delay is how long logins are blocked after the given number of consecutive
+// failures. It doubles with every failure, up to the lockout once the
+// maximum number of failures is reached.
+func (p loginPolicy) delay(kind string, failures int) time.Duration {
+	max := p.maxFailures
+	if kind == db.LoginAttemptIP {
+		max = p.maxFailuresPerIP
+	}
+	if failures <= 0 {
+		return 0
+	}
+	if max > 0 && failures >= max {
+		return p.lockout
+	}
+
+	if failures > 30 {
+		return p.lockout
+	}
+	d := p.backoffBase << (failures - 1)
+	if d > p.lockout {
+		d = p.lockout
+	}
+	return d
+}
+
+// blockedUntil returns when logins are allowed again after the given failed
+// attempts. It is in the past if they are allowed now.
+func (p loginPolicy) blockedUntil(attempts []db.LoginAttempt) (until time.Time) {
+	for _, a := range attempts {
+		if t := a.LastFailureAt.Add(p.delay(a.Kind, a.Failures)); t.After(until) {
+			until = t
+		}
+	}
+	return
+}
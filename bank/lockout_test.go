@@ -0,0 +1,46 @@
+package bank
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"example.com/banking/db"
+)
+
+func TestLoginPolicy_Delay(t *testing.T) {
+	p := loginPolicy{maxFailures: 5, maxFailuresPerIP: 20, lockout: 15 * time.Minute, backoffBase: time.Second}
+
+	tests := []struct {
+		kind     string
+		failures int
+		want     time.Duration
+	}{
+		{db.LoginAttemptEmail, 0, 0},
+		{db.LoginAttemptEmail, 1, time.Second},
+		{db.LoginAttemptEmail, 2, 2 * time.Second},
+		{db.LoginAttemptEmail, 4, 8 * time.Second},
+		{db.LoginAttemptEmail, 5, 15 * time.Minute},
+		{db.LoginAttemptIP, 5, 16 * time.Second},
+		{db.LoginAttemptIP, 11, 15 * time.Minute},
+		{db.LoginAttemptIP, 20, 15 * time.Minute},
+		{db.LoginAttemptIP, 100, 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, p.delay(tt.kind, tt.failures), "%v failures for %v", tt.failures, tt.kind)
+	}
+}
+
+func TestLoginPolicy_BlockedUntil(t *testing.T) {
+	p := loginPolicy{maxFailures: 5, maxFailuresPerIP: 20, lockout: 15 * time.Minute, backoffBase: time.Second}
+	last := time.Date(2022, 10, 17, 10, 0, 0, 0, time.UTC)
+
+	assert.True(t, p.blockedUntil(nil).IsZero())
+
+	until := p.blockedUntil([]db.LoginAttempt{
+		{Kind: db.LoginAttemptEmail, Failures: 5, LastFailureAt: last},
+		{Kind: db.LoginAttemptIP, Failures: 5, LastFailureAt: last.Add(time.Minute)},
+	})
+	assert.Equal(t, last.Add(15*time.Minute), until)
+}
@@ -0,0 +1,274 @@
+package bank
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"example.com/banking/config"
+	"example.com/banking/db"
+	"example.com/banking/totp"
+)
+
+const (
+	// mfaAudience marks MFA challenge tokens, so that they are never accepted
+	// as access tokens
+	mfaAudience       = "mfa"
+	recoveryCodeCount = 10
+	// codes from one period before or after the current one are accepted
+	totpSkew = 1
+)
+
+// generateMFAToken returns the challenge token for a user whose password was
+// checked. The email is kept as the subject to count failed codes against it.
+func generateMFAToken(user db.User, email string, ttl time.Duration) (tokenString string, expiresAt time.Time, err error) {
+	expiresAt = time.Now().Add(ttl)
+	claims := &Claims{
+		UserID: user.ID,
+		Role:   user.Type,
+		StandardClaims: jwt.StandardClaims{
+			Audience:  mfaAudience,
+			Subject:   email,
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+
+	tokenString, err = signClaims(claims)
+	return
+}
+
+func parseMFAToken(tokenString string) (claims *Claims, err error) {
+	claims, err = parseJWT(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Audience != mfaAudience {
+		return nil, fmt.Errorf("%w, err: not an mfa token", ErrUnauthorized)
+	}
+	return
+}
+
+// newRecoveryCodes returns random recovery codes, formatted as XXXX-XXXX, and
+// their hashes to store.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err = rand.Read(b); err != nil {
+			return
+		}
+		code := base32.StdEncoding.EncodeToString(b)
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return
+}
+
+// hashRecoveryCode hashes a recovery code, ignoring case, spaces and dashes.
+func hashRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashToken(code)
+}
+
+func (b *bankService) mfaRequired(role string) bool {
+	for _, r := range b.mfaRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// mfaStatus reports whether the user has an MFA enrolment, pending or
+// confirmed, and whether it is confirmed.
+func (b *bankService) mfaStatus(ctx context.Context, userID string) (started, enrolled bool, err error) {
+	m, err := b.store.GetMFA(ctx, userID)
+	if err == db.ErrMFANotEnrolled {
+		return false, false, nil
+	}
+	if err != nil {
+		return
+	}
+	return true, m.ConfirmedAt != nil, nil
+}
+
+// mfaChallenge starts the second step of a login. No secret is ever handed
+// out here, as knowing the password is not enough to be trusted with one: a
+// user who is not enrolled yet logs in with the first code of the enrolment
+// an administrator provisioned for them, which confirms it.
+func (b *bankService) mfaChallenge(user db.User, email string) (challenge *MFAChallenge, err error) {
+	challenge = &MFAChallenge{}
+	challenge.MFAToken, challenge.ExpiresAt, err = generateMFAToken(user, email, b.mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (b *bankService) startMFAEnrolment(ctx context.Context, userID, email string) (enrolment MFAEnrolment, err error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return
+	}
+
+	err = b.store.SaveMFASecret(ctx, userID, secret)
+	if err != nil {
+		if err == db.ErrMFAAlreadyEnrolled {
+			err = ErrMFAAlreadyEnrolled
+		}
+		return
+	}
+
+	enrolment = MFAEnrolment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(config.AppName(), email, secret),
+	}
+	return
+}
+
+// confirmMFAEnrolment completes a pending enrolment with the first code from
+// the authenticator app and returns new recovery codes.
+func (b *bankService) confirmMFAEnrolment(ctx context.Context, m db.MFA, code string) (recoveryCodes []string, err error) {
+	counter, ok := totp.Validate(m.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		err = ErrInvalidMFACode
+		return
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return
+	}
+
+	err = b.store.ConfirmMFA(ctx, m.UserID, counter, hashes)
+	if err == db.ErrMFAAlreadyEnrolled {
+		err = ErrMFAAlreadyEnrolled
+	}
+	return
+}
+
+// verifyMFA checks a TOTP code or a recovery code of an enrolled user. Each
+// code can only be used once.
+func (b *bankService) verifyMFA(ctx context.Context, m db.MFA, code, recoveryCode string) (err error) {
+	if recoveryCode != "" {
+		err = b.store.UseRecoveryCode(ctx, m.UserID, hashRecoveryCode(recoveryCode))
+		if err == db.ErrRecoveryCodeNotExist {
+			err = ErrInvalidMFACode
+		}
+		return
+	}
+
+	counter, ok := totp.Validate(m.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	err = b.store.UseMFACode(ctx, m.UserID, counter)
+	if err == db.ErrMFACodeUsed {
+		err = ErrInvalidMFACode
+	}
+	return
+}
+
+// LoginMFA completes a login that needs MFA: the challenge token from Login
+// is exchanged together with a TOTP code, or a recovery code, for the access
+// and refresh tokens. A wrong code counts as a failed login.
+func (b *bankService) LoginMFA(ctx context.Context, req MFALoginRequest) (tokens LoginResponse, err error) {
+	claims, err := parseMFAToken(req.MFAToken)
+	if err != nil {
+		return
+	}
+
+	u := LoginRequest{Email: claims.Subject, IP: req.IP}
+	if err = b.checkLoginBlocked(ctx, u); err != nil {
+		return
+	}
+
+	m, err := b.store.GetMFA(ctx, claims.UserID)
+	if err != nil {
+		if err == db.ErrMFANotEnrolled {
+			err = ErrUnauthorized
+		}
+		return
+	}
+
+	var recoveryCodes []string
+	if m.ConfirmedAt == nil {
+		recoveryCodes, err = b.confirmMFAEnrolment(ctx, m, req.Code)
+	} else {
+		err = b.verifyMFA(ctx, m, req.Code, req.RecoveryCode)
+	}
+	if err != nil {
+		if err == ErrInvalidMFACode {
+			b.recordLoginFailure(ctx, u)
+			err = ErrUnauthorized
+		}
+		return
+	}
+
+	tokens, err = b.startSession(ctx, db.User{ID: claims.UserID, Type: claims.Role}, u)
+	tokens.RecoveryCodes = recoveryCodes
+	return
+}
+
+// EnrolMFA starts an MFA enrolment for a logged in user. It is completed by
+// ConfirmMFA with the first code from the authenticator app.
+func (b *bankService) EnrolMFA(ctx context.Context, claims *Claims) (enrolment MFAEnrolment, err error) {
+	b.logger.Infof("Starting mfa enrolment for user: %v\n", claims.UserID)
+
+	user, err := b.store.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return
+	}
+
+	enrolment, err = b.startMFAEnrolment(ctx, user.ID, user.Email)
+	return
+}
+
+// ProvisionMFA starts an MFA enrolment for a user, whose secret the
+// administrator hands over to them out of band. It is how users of roles
+// that require MFA get enrolled, their first login with a code confirming it.
+// Claims are nil when run from the command line.
+func (b *bankService) ProvisionMFA(ctx context.Context, claims *Claims, userID string) (enrolment MFAEnrolment, err error) {
+	if claims == nil {
+		b.logger.Infof("Provisioning mfa enrolment for user: %v from the command line\n", userID)
+	} else {
+		b.logger.Infof("User: %v provisioning mfa enrolment for user: %v\n", claims.UserID, userID)
+	}
+
+	user, err := b.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	enrolment, err = b.startMFAEnrolment(ctx, user.ID, user.Email)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{Action: AuditMFAProvisioned, ActorID: actorID(claims), Subject: userID})
+	return
+}
+
+// ConfirmMFA completes the caller's MFA enrolment and returns their recovery
+// codes. From then on every login needs a code.
+func (b *bankService) ConfirmMFA(ctx context.Context, claims *Claims, code string) (recoveryCodes []string, err error) {
+	m, err := b.store.GetMFA(ctx, claims.UserID)
+	if err != nil {
+		if err == db.ErrMFANotEnrolled {
+			err = ErrMFANotEnrolled
+		}
+		return
+	}
+	if m.ConfirmedAt != nil {
+		err = ErrMFAAlreadyEnrolled
+		return
+	}
+
+	recoveryCodes, err = b.confirmMFAEnrolment(ctx, m, code)
+	return
+}
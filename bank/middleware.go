@@ -0,0 +1,97 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"example.com/banking/api"
+)
+
+type ctxKey int
+
+const claimsKey ctxKey = 0
+
+// tokenFromRequest returns the JWT from the Authorization bearer header or,
+// failing that, from the token cookie.
+func tokenFromRequest(req *http.Request) (tokenString string, ok bool) {
+	if header := req.Header.Get("Authorization"); header != "" {
+		tokenString = strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+		return tokenString, strings.HasPrefix(header, "Bearer ") && tokenString != ""
+	}
+
+	cookie, err := req.Cookie("token")
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	return cookie.Value, true
+}
+
+// Authenticate validates the caller's JWT and stores its claims in the
+// request context. Requests without a valid token, or with a revoked one, are
+// rejected with 401.
+func Authenticate(s Service) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			tokenString, ok := tokenFromRequest(req)
+			if !ok {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized - JWT token not present"})
+				return
+			}
+
+			claims, err := s.ValidateJWT(req.Context(), tokenString)
+			if err != nil {
+				if errors.Is(err, ErrUnauthorized) {
+					api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+					return
+				}
+				api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
+				return
+			}
+
+			next.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), claimsKey, claims)))
+		})
+	}
+}
+
+// RequirePermission lets the request through only if the authenticated
+// caller's role is granted the permission. It must run after Authenticate.
+func RequirePermission(s Service, permission string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			claims, ok := ClaimsFromContext(req.Context())
+			if !ok {
+				api.Error(rw, http.StatusUnauthorized, api.Response{Message: "Unauthorized"})
+				return
+			}
+
+			allowed, err := s.HasPermission(req.Context(), claims.Role, permission)
+			if err != nil {
+				api.Error(rw, http.StatusInternalServerError, api.Response{Message: "Err - Internal Server Error"})
+				return
+			}
+			if !allowed {
+				api.Error(rw, http.StatusForbidden, api.Response{Message: "Forbidden"})
+				return
+			}
+
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
+
+// ClaimsFromContext returns the claims stored by Authenticate.
+func ClaimsFromContext(ctx context.Context) (claims *Claims, ok bool) {
+	claims, ok = ctx.Value(claimsKey).(*Claims)
+	return
+}
+
+// requestClaims returns the caller's claims, or empty claims if the route is
+// not behind Authenticate, which no account belongs to.
+func requestClaims(req *http.Request) *Claims {
+	if claims, ok := ClaimsFromContext(req.Context()); ok {
+		return claims
+	}
+	return &Claims{}
+}
@@ -0,0 +1,193 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	uuidgen "github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"example.com/banking/app"
+	"example.com/banking/db"
+	"example.com/banking/db/mocks"
+)
+
+func TestAuthenticateAndRequirePermission(t *testing.T) {
+	customerToken, _, err := generateJWT("1", RoleCustomer, uuidgen.New())
+	require.NoError(t, err)
+	revokedToken, _, err := generateJWT("1", RoleCustomer, uuidgen.New())
+	require.NoError(t, err)
+	unavailableToken, _, err := generateJWT("1", RoleCustomer, uuidgen.New())
+	require.NoError(t, err)
+	revokedClaims, err := parseJWT(revokedToken)
+	require.NoError(t, err)
+	unavailableClaims, err := parseJWT(unavailableToken)
+	require.NoError(t, err)
+
+	store := &mocks.Storer{}
+	store.On("IsTokenRevoked", mock.Anything, revokedClaims.Id, revokedClaims.SessionID).Return(true, nil)
+	store.On("IsTokenRevoked", mock.Anything, unavailableClaims.Id, unavailableClaims.SessionID).Return(false, errors.New("connection refused"))
+	store.On("IsTokenRevoked", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	store.On("RoleHasPermission", mock.Anything, RoleCustomer, PermAccountsReadOwn).Return(true, nil)
+	store.On("RoleHasPermission", mock.Anything, RoleCustomer, PermAccountsList).Return(false, nil)
+	store.On("RoleHasPermission", mock.Anything, RoleCustomer, PermAuditRead).Return(false, errors.New("connection refused"))
+	service := NewBankService(store, app.GetLogger(), nil)
+	authenticate := Authenticate(service)
+
+	var gotClaims *Claims
+	handler := authenticate(RequirePermission(service, PermAccountsReadOwn)(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gotClaims, _ = ClaimsFromContext(req.Context())
+		rw.WriteHeader(http.StatusNoContent)
+	})))
+	noContent := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	listAccounts := authenticate(RequirePermission(service, PermAccountsList)(noContent))
+	readAudit := authenticate(RequirePermission(service, PermAuditRead)(noContent))
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		prepare    func(req *http.Request)
+		wantStatus int
+	}{
+		{
+			name:       "bearerHeader",
+			handler:    handler,
+			prepare:    func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+customerToken) },
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "cookie",
+			handler:    handler,
+			prepare:    func(req *http.Request) { req.AddCookie(&http.Cookie{Name: "token", Value: customerToken}) },
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "noToken",
+			handler:    handler,
+			prepare:    func(req *http.Request) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalidToken",
+			handler:    handler,
+			prepare:    func(req *http.Request) { req.Header.Set("Authorization", "Bearer abc.def.ghi") },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "revokedToken",
+			handler:    handler,
+			prepare:    func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+revokedToken) },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "revocationCheckFailed",
+			handler:    handler,
+			prepare:    func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+unavailableToken) },
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "notBearer",
+			handler:    handler,
+			prepare:    func(req *http.Request) { req.Header.Set("Authorization", "Basic "+customerToken) },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "permissionNotGranted",
+			handler:    listAccounts,
+			prepare:    func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+customerToken) },
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "permissionCheckFailed",
+			handler:    readAudit,
+			prepare:    func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+customerToken) },
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotClaims = nil
+			req := httptest.NewRequest(http.MethodGet, "/account/1", nil)
+			tt.prepare(req)
+			rw := httptest.NewRecorder()
+
+			tt.handler.ServeHTTP(rw, req)
+
+			assert.Equal(t, tt.wantStatus, rw.Code)
+			if tt.wantStatus == http.StatusNoContent {
+				require.NotNil(t, gotClaims)
+				assert.Equal(t, "1", gotClaims.UserID)
+			}
+		})
+	}
+}
+
+func TestRequirePermission_AssignedTeller(t *testing.T) {
+	role := RoleCustomer
+	store := &mocks.Storer{}
+	store.On("IsTokenRevoked", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	store.On("RoleHasPermission", mock.Anything, RoleCustomer, PermAccountsCreate).Return(false, nil)
+	store.On("RoleHasPermission", mock.Anything, RoleTeller, PermAccountsCreate).Return(true, nil)
+	store.On("SetUserRole", mock.Anything, "2", RoleTeller).
+		Run(func(args mock.Arguments) { role = args.String(2) }).
+		Return(nil).Once()
+	store.On("AddAuditEntry", mock.Anything, mock.Anything).Return(nil)
+	// the session takes the role from the user, as the store does
+	store.On("RotateRefreshToken", mock.Anything, mock.Anything, mock.AnythingOfType("db.RefreshToken")).
+		Return(func(ctx context.Context, tokenHash string, next db.RefreshToken) db.Session {
+			return db.Session{ID: "sess-1", UserID: "2", Role: role}
+		}, nil)
+	service := NewBankService(store, app.GetLogger(), nil)
+
+	createAccount := Authenticate(service)(RequirePermission(service, PermAccountsCreate)(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})))
+	call := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/account", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rw := httptest.NewRecorder()
+		createAccount.ServeHTTP(rw, req)
+		return rw.Code
+	}
+
+	tokens, err := service.RefreshToken(context.Background(), "before")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, call(tokens.AccessToken))
+
+	manager := &Claims{UserID: "1", Role: RoleBranchManager}
+	require.NoError(t, service.SetUserRole(context.Background(), manager, "2", SetUserRoleRequest{Role: RoleTeller}))
+
+	tokens, err = service.RefreshToken(context.Background(), "after")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, call(tokens.AccessToken))
+}
+
+func TestIdempotent_BodyTooLarge(t *testing.T) {
+	store := &mocks.Storer{}
+	service := NewBankService(store, app.GetLogger(), nil)
+	called := false
+	handler := Idempotent(service)(func(rw http.ResponseWriter, req *http.Request) {
+		called = true
+	})
+
+	body := strings.Repeat("a", maxIdempotentRequestBytes+1)
+	req := httptest.NewRequest(http.MethodPost, "/account/acc-1/deposit", strings.NewReader(body))
+	req.Header.Set(idempotencyKeyHeader, uuidgen.New())
+	req = req.WithContext(context.WithValue(req.Context(), claimsKey, &Claims{UserID: "1", Role: RoleCustomer}))
+	rw := httptest.NewRecorder()
+
+	handler(rw, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+	assert.False(t, called)
+	store.AssertNotCalled(t, "ReserveIdempotencyKey", mock.Anything, mock.Anything, mock.Anything)
+}
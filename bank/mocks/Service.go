@@ -11,6 +11,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	money "example.com/banking/money"
+
 	time "time"
 )
 
@@ -27,20 +29,20 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
-// CreateAccount provides a mock function with given fields: ctx, accReq
-func (_m *Service) CreateAccount(ctx context.Context, accReq bank.CreateAccountRequest) (bank.CreateAccountResponse, error) {
-	ret := _m.Called(ctx, accReq)
+// AccrueInterest provides a mock function with given fields: ctx, businessDate
+func (_m *Service) AccrueInterest(ctx context.Context, businessDate time.Time) (bank.InterestRun, error) {
+	ret := _m.Called(ctx, businessDate)
 
-	var r0 bank.CreateAccountResponse
-	if rf, ok := ret.Get(0).(func(context.Context, bank.CreateAccountRequest) bank.CreateAccountResponse); ok {
-		r0 = rf(ctx, accReq)
+	var r0 bank.InterestRun
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) bank.InterestRun); ok {
+		r0 = rf(ctx, businessDate)
 	} else {
-		r0 = ret.Get(0).(bank.CreateAccountResponse)
+		r0 = ret.Get(0).(bank.InterestRun)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, bank.CreateAccountRequest) error); ok {
-		r1 = rf(ctx, accReq)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, businessDate)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -48,37 +50,37 @@ func (_m *Service) CreateAccount(ctx context.Context, accReq bank.CreateAccountR
 	return r0, r1
 }
 
-// Service_CreateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccount'
-type Service_CreateAccount_Call struct {
+// Service_AccrueInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccrueInterest'
+type Service_AccrueInterest_Call struct {
 	*mock.Call
 }
 
-// CreateAccount is a helper method to define mock.On call
+// AccrueInterest is a helper method to define mock.On call
 //  - ctx context.Context
-//  - accReq bank.CreateAccountRequest
-func (_e *Service_Expecter) CreateAccount(ctx interface{}, accReq interface{}) *Service_CreateAccount_Call {
-	return &Service_CreateAccount_Call{Call: _e.mock.On("CreateAccount", ctx, accReq)}
+//  - businessDate time.Time
+func (_e *Service_Expecter) AccrueInterest(ctx interface{}, businessDate interface{}) *Service_AccrueInterest_Call {
+	return &Service_AccrueInterest_Call{Call: _e.mock.On("AccrueInterest", ctx, businessDate)}
 }
 
-func (_c *Service_CreateAccount_Call) Run(run func(ctx context.Context, accReq bank.CreateAccountRequest)) *Service_CreateAccount_Call {
+func (_c *Service_AccrueInterest_Call) Run(run func(ctx context.Context, businessDate time.Time)) *Service_AccrueInterest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bank.CreateAccountRequest))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Service_CreateAccount_Call) Return(accRes bank.CreateAccountResponse, err error) *Service_CreateAccount_Call {
-	_c.Call.Return(accRes, err)
+func (_c *Service_AccrueInterest_Call) Return(run bank.InterestRun, err error) *Service_AccrueInterest_Call {
+	_c.Call.Return(run, err)
 	return _c
 }
 
-// DepositAmount provides a mock function with given fields: ctx, accId, userID, amount
-func (_m *Service) DepositAmount(ctx context.Context, accId string, userID string, amount float32) error {
-	ret := _m.Called(ctx, accId, userID, amount)
+// CancelStandingOrder provides a mock function with given fields: ctx, accId, orderId, userID
+func (_m *Service) CancelStandingOrder(ctx context.Context, accId string, orderId string, userID string) error {
+	ret := _m.Called(ctx, accId, orderId, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) error); ok {
-		r0 = rf(ctx, accId, userID, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, accId, orderId, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -86,46 +88,46 @@ func (_m *Service) DepositAmount(ctx context.Context, accId string, userID strin
 	return r0
 }
 
-// Service_DepositAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DepositAmount'
-type Service_DepositAmount_Call struct {
+// Service_CancelStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelStandingOrder'
+type Service_CancelStandingOrder_Call struct {
 	*mock.Call
 }
 
-// DepositAmount is a helper method to define mock.On call
+// CancelStandingOrder is a helper method to define mock.On call
 //  - ctx context.Context
 //  - accId string
+//  - orderId string
 //  - userID string
-//  - amount float32
-func (_e *Service_Expecter) DepositAmount(ctx interface{}, accId interface{}, userID interface{}, amount interface{}) *Service_DepositAmount_Call {
-	return &Service_DepositAmount_Call{Call: _e.mock.On("DepositAmount", ctx, accId, userID, amount)}
+func (_e *Service_Expecter) CancelStandingOrder(ctx interface{}, accId interface{}, orderId interface{}, userID interface{}) *Service_CancelStandingOrder_Call {
+	return &Service_CancelStandingOrder_Call{Call: _e.mock.On("CancelStandingOrder", ctx, accId, orderId, userID)}
 }
 
-func (_c *Service_DepositAmount_Call) Run(run func(ctx context.Context, accId string, userID string, amount float32)) *Service_DepositAmount_Call {
+func (_c *Service_CancelStandingOrder_Call) Run(run func(ctx context.Context, accId string, orderId string, userID string)) *Service_CancelStandingOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(float32))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *Service_DepositAmount_Call) Return(err error) *Service_DepositAmount_Call {
+func (_c *Service_CancelStandingOrder_Call) Return(err error) *Service_CancelStandingOrder_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-// GetAccountDetails provides a mock function with given fields: ctx, accId, userID
-func (_m *Service) GetAccountDetails(ctx context.Context, accId string, userID string) (db.UserAccountDetails, error) {
-	ret := _m.Called(ctx, accId, userID)
+// CaptureHold provides a mock function with given fields: ctx, claims, accId, holdId, req
+func (_m *Service) CaptureHold(ctx context.Context, claims *bank.Claims, accId string, holdId string, req bank.CaptureHoldRequest) (db.Hold, error) {
+	ret := _m.Called(ctx, claims, accId, holdId, req)
 
-	var r0 db.UserAccountDetails
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) db.UserAccountDetails); ok {
-		r0 = rf(ctx, accId, userID)
+	var r0 db.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string, string, bank.CaptureHoldRequest) db.Hold); ok {
+		r0 = rf(ctx, claims, accId, holdId, req)
 	} else {
-		r0 = ret.Get(0).(db.UserAccountDetails)
+		r0 = ret.Get(0).(db.Hold)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, accId, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, string, string, bank.CaptureHoldRequest) error); ok {
+		r1 = rf(ctx, claims, accId, holdId, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -133,47 +135,126 @@ func (_m *Service) GetAccountDetails(ctx context.Context, accId string, userID s
 	return r0, r1
 }
 
-// Service_GetAccountDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountDetails'
-type Service_GetAccountDetails_Call struct {
+// Service_CaptureHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CaptureHold'
+type Service_CaptureHold_Call struct {
 	*mock.Call
 }
 
-// GetAccountDetails is a helper method to define mock.On call
+// CaptureHold is a helper method to define mock.On call
 //  - ctx context.Context
+//  - claims *bank.Claims
 //  - accId string
-//  - userID string
-func (_e *Service_Expecter) GetAccountDetails(ctx interface{}, accId interface{}, userID interface{}) *Service_GetAccountDetails_Call {
-	return &Service_GetAccountDetails_Call{Call: _e.mock.On("GetAccountDetails", ctx, accId, userID)}
+//  - holdId string
+//  - req bank.CaptureHoldRequest
+func (_e *Service_Expecter) CaptureHold(ctx interface{}, claims interface{}, accId interface{}, holdId interface{}, req interface{}) *Service_CaptureHold_Call {
+	return &Service_CaptureHold_Call{Call: _e.mock.On("CaptureHold", ctx, claims, accId, holdId, req)}
 }
 
-func (_c *Service_GetAccountDetails_Call) Run(run func(ctx context.Context, accId string, userID string)) *Service_GetAccountDetails_Call {
+func (_c *Service_CaptureHold_Call) Run(run func(ctx context.Context, claims *bank.Claims, accId string, holdId string, req bank.CaptureHoldRequest)) *Service_CaptureHold_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string), args[3].(string), args[4].(bank.CaptureHoldRequest))
 	})
 	return _c
 }
 
-func (_c *Service_GetAccountDetails_Call) Return(acc db.UserAccountDetails, err error) *Service_GetAccountDetails_Call {
-	_c.Call.Return(acc, err)
+func (_c *Service_CaptureHold_Call) Return(hold db.Hold, err error) *Service_CaptureHold_Call {
+	_c.Call.Return(hold, err)
 	return _c
 }
 
-// GetAccountList provides a mock function with given fields: ctx
-func (_m *Service) GetAccountList(ctx context.Context) ([]db.UserAccountDetails, error) {
-	ret := _m.Called(ctx)
+// ChangeAccountStatus provides a mock function with given fields: ctx, claims, accId, req
+func (_m *Service) ChangeAccountStatus(ctx context.Context, claims *bank.Claims, accId string, req bank.ChangeAccountStatusRequest) error {
+	ret := _m.Called(ctx, claims, accId, req)
 
-	var r0 []db.UserAccountDetails
-	if rf, ok := ret.Get(0).(func(context.Context) []db.UserAccountDetails); ok {
-		r0 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string, bank.ChangeAccountStatusRequest) error); ok {
+		r0 = rf(ctx, claims, accId, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.UserAccountDetails)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_ChangeAccountStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeAccountStatus'
+type Service_ChangeAccountStatus_Call struct {
+	*mock.Call
+}
+
+// ChangeAccountStatus is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - accId string
+//  - req bank.ChangeAccountStatusRequest
+func (_e *Service_Expecter) ChangeAccountStatus(ctx interface{}, claims interface{}, accId interface{}, req interface{}) *Service_ChangeAccountStatus_Call {
+	return &Service_ChangeAccountStatus_Call{Call: _e.mock.On("ChangeAccountStatus", ctx, claims, accId, req)}
+}
+
+func (_c *Service_ChangeAccountStatus_Call) Run(run func(ctx context.Context, claims *bank.Claims, accId string, req bank.ChangeAccountStatusRequest)) *Service_ChangeAccountStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string), args[3].(bank.ChangeAccountStatusRequest))
+	})
+	return _c
+}
+
+func (_c *Service_ChangeAccountStatus_Call) Return(err error) *Service_ChangeAccountStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// ChangePassword provides a mock function with given fields: ctx, claims, req
+func (_m *Service) ChangePassword(ctx context.Context, claims *bank.Claims, req bank.ChangePasswordRequest) error {
+	ret := _m.Called(ctx, claims, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, bank.ChangePasswordRequest) error); ok {
+		r0 = rf(ctx, claims, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type Service_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - req bank.ChangePasswordRequest
+func (_e *Service_Expecter) ChangePassword(ctx interface{}, claims interface{}, req interface{}) *Service_ChangePassword_Call {
+	return &Service_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, claims, req)}
+}
+
+func (_c *Service_ChangePassword_Call) Run(run func(ctx context.Context, claims *bank.Claims, req bank.ChangePasswordRequest)) *Service_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(bank.ChangePasswordRequest))
+	})
+	return _c
+}
+
+func (_c *Service_ChangePassword_Call) Return(err error) *Service_ChangePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// ChargeMonthlyFees provides a mock function with given fields: ctx, month
+func (_m *Service) ChargeMonthlyFees(ctx context.Context, month time.Time) (bank.FeeRun, error) {
+	ret := _m.Called(ctx, month)
+
+	var r0 bank.FeeRun
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) bank.FeeRun); ok {
+		r0 = rf(ctx, month)
+	} else {
+		r0 = ret.Get(0).(bank.FeeRun)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, month)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -181,45 +262,44 @@ func (_m *Service) GetAccountList(ctx context.Context) ([]db.UserAccountDetails,
 	return r0, r1
 }
 
-// Service_GetAccountList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountList'
-type Service_GetAccountList_Call struct {
+// Service_ChargeMonthlyFees_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChargeMonthlyFees'
+type Service_ChargeMonthlyFees_Call struct {
 	*mock.Call
 }
 
-// GetAccountList is a helper method to define mock.On call
+// ChargeMonthlyFees is a helper method to define mock.On call
 //  - ctx context.Context
-func (_e *Service_Expecter) GetAccountList(ctx interface{}) *Service_GetAccountList_Call {
-	return &Service_GetAccountList_Call{Call: _e.mock.On("GetAccountList", ctx)}
+//  - month time.Time
+func (_e *Service_Expecter) ChargeMonthlyFees(ctx interface{}, month interface{}) *Service_ChargeMonthlyFees_Call {
+	return &Service_ChargeMonthlyFees_Call{Call: _e.mock.On("ChargeMonthlyFees", ctx, month)}
 }
 
-func (_c *Service_GetAccountList_Call) Run(run func(ctx context.Context)) *Service_GetAccountList_Call {
+func (_c *Service_ChargeMonthlyFees_Call) Run(run func(ctx context.Context, month time.Time)) *Service_ChargeMonthlyFees_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *Service_GetAccountList_Call) Return(accounts []db.UserAccountDetails, err error) *Service_GetAccountList_Call {
-	_c.Call.Return(accounts, err)
+func (_c *Service_ChargeMonthlyFees_Call) Return(run bank.FeeRun, err error) *Service_ChargeMonthlyFees_Call {
+	_c.Call.Return(run, err)
 	return _c
 }
 
-// GetTransactionDetails provides a mock function with given fields: ctx, accId, userID, startDate, endDate
-func (_m *Service) GetTransactionDetails(ctx context.Context, accId string, userID string, startDate string, endDate string) ([]db.Transaction, error) {
-	ret := _m.Called(ctx, accId, userID, startDate, endDate)
+// CloseAccount provides a mock function with given fields: ctx, claims, accId, req
+func (_m *Service) CloseAccount(ctx context.Context, claims *bank.Claims, accId string, req bank.CloseAccountRequest) (db.AccountClosure, error) {
+	ret := _m.Called(ctx, claims, accId, req)
 
-	var r0 []db.Transaction
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) []db.Transaction); ok {
-		r0 = rf(ctx, accId, userID, startDate, endDate)
+	var r0 db.AccountClosure
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string, bank.CloseAccountRequest) db.AccountClosure); ok {
+		r0 = rf(ctx, claims, accId, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Transaction)
-		}
+		r0 = ret.Get(0).(db.AccountClosure)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
-		r1 = rf(ctx, accId, userID, startDate, endDate)
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, string, bank.CloseAccountRequest) error); ok {
+		r1 = rf(ctx, claims, accId, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -227,122 +307,2294 @@ func (_m *Service) GetTransactionDetails(ctx context.Context, accId string, user
 	return r0, r1
 }
 
-// Service_GetTransactionDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionDetails'
-type Service_GetTransactionDetails_Call struct {
+// Service_CloseAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseAccount'
+type Service_CloseAccount_Call struct {
 	*mock.Call
 }
 
-// GetTransactionDetails is a helper method to define mock.On call
+// CloseAccount is a helper method to define mock.On call
 //  - ctx context.Context
+//  - claims *bank.Claims
 //  - accId string
+//  - req bank.CloseAccountRequest
+func (_e *Service_Expecter) CloseAccount(ctx interface{}, claims interface{}, accId interface{}, req interface{}) *Service_CloseAccount_Call {
+	return &Service_CloseAccount_Call{Call: _e.mock.On("CloseAccount", ctx, claims, accId, req)}
+}
+
+func (_c *Service_CloseAccount_Call) Run(run func(ctx context.Context, claims *bank.Claims, accId string, req bank.CloseAccountRequest)) *Service_CloseAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string), args[3].(bank.CloseAccountRequest))
+	})
+	return _c
+}
+
+func (_c *Service_CloseAccount_Call) Return(closure db.AccountClosure, err error) *Service_CloseAccount_Call {
+	_c.Call.Return(closure, err)
+	return _c
+}
+
+// CompleteIdempotentRequest provides a mock function with given fields: ctx, userID, key, statusCode, body
+func (_m *Service) CompleteIdempotentRequest(ctx context.Context, userID string, key string, statusCode int, body []byte) error {
+	ret := _m.Called(ctx, userID, key, statusCode, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, []byte) error); ok {
+		r0 = rf(ctx, userID, key, statusCode, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_CompleteIdempotentRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteIdempotentRequest'
+type Service_CompleteIdempotentRequest_Call struct {
+	*mock.Call
+}
+
+// CompleteIdempotentRequest is a helper method to define mock.On call
+//  - ctx context.Context
 //  - userID string
-//  - startDate string
-//  - endDate string
-func (_e *Service_Expecter) GetTransactionDetails(ctx interface{}, accId interface{}, userID interface{}, startDate interface{}, endDate interface{}) *Service_GetTransactionDetails_Call {
-	return &Service_GetTransactionDetails_Call{Call: _e.mock.On("GetTransactionDetails", ctx, accId, userID, startDate, endDate)}
+//  - key string
+//  - statusCode int
+//  - body []byte
+func (_e *Service_Expecter) CompleteIdempotentRequest(ctx interface{}, userID interface{}, key interface{}, statusCode interface{}, body interface{}) *Service_CompleteIdempotentRequest_Call {
+	return &Service_CompleteIdempotentRequest_Call{Call: _e.mock.On("CompleteIdempotentRequest", ctx, userID, key, statusCode, body)}
 }
 
-func (_c *Service_GetTransactionDetails_Call) Run(run func(ctx context.Context, accId string, userID string, startDate string, endDate string)) *Service_GetTransactionDetails_Call {
+func (_c *Service_CompleteIdempotentRequest_Call) Run(run func(ctx context.Context, userID string, key string, statusCode int, body []byte)) *Service_CompleteIdempotentRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int), args[4].([]byte))
 	})
 	return _c
 }
 
-func (_c *Service_GetTransactionDetails_Call) Return(transactions []db.Transaction, err error) *Service_GetTransactionDetails_Call {
-	_c.Call.Return(transactions, err)
+func (_c *Service_CompleteIdempotentRequest_Call) Return(err error) *Service_CompleteIdempotentRequest_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-// Login provides a mock function with given fields: ctx, lReq
-func (_m *Service) Login(ctx context.Context, lReq bank.LoginRequest) (string, time.Time, error) {
-	ret := _m.Called(ctx, lReq)
+// ConfirmMFA provides a mock function with given fields: ctx, claims, code
+func (_m *Service) ConfirmMFA(ctx context.Context, claims *bank.Claims, code string) ([]string, error) {
+	ret := _m.Called(ctx, claims, code)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, bank.LoginRequest) string); ok {
-		r0 = rf(ctx, lReq)
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string) []string); ok {
+		r0 = rf(ctx, claims, code)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
-	var r1 time.Time
-	if rf, ok := ret.Get(1).(func(context.Context, bank.LoginRequest) time.Time); ok {
-		r1 = rf(ctx, lReq)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, string) error); ok {
+		r1 = rf(ctx, claims, code)
 	} else {
-		r1 = ret.Get(1).(time.Time)
+		r1 = ret.Error(1)
 	}
 
-	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, bank.LoginRequest) error); ok {
-		r2 = rf(ctx, lReq)
+	return r0, r1
+}
+
+// Service_ConfirmMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmMFA'
+type Service_ConfirmMFA_Call struct {
+	*mock.Call
+}
+
+// ConfirmMFA is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - code string
+func (_e *Service_Expecter) ConfirmMFA(ctx interface{}, claims interface{}, code interface{}) *Service_ConfirmMFA_Call {
+	return &Service_ConfirmMFA_Call{Call: _e.mock.On("ConfirmMFA", ctx, claims, code)}
+}
+
+func (_c *Service_ConfirmMFA_Call) Run(run func(ctx context.Context, claims *bank.Claims, code string)) *Service_ConfirmMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Service_ConfirmMFA_Call) Return(recoveryCodes []string, err error) *Service_ConfirmMFA_Call {
+	_c.Call.Return(recoveryCodes, err)
+	return _c
+}
+
+// CreateAccount provides a mock function with given fields: ctx, accReq
+func (_m *Service) CreateAccount(ctx context.Context, accReq bank.CreateAccountRequest) (bank.CreateAccountResponse, error) {
+	ret := _m.Called(ctx, accReq)
+
+	var r0 bank.CreateAccountResponse
+	if rf, ok := ret.Get(0).(func(context.Context, bank.CreateAccountRequest) bank.CreateAccountResponse); ok {
+		r0 = rf(ctx, accReq)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Get(0).(bank.CreateAccountResponse)
 	}
 
-	return r0, r1, r2
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bank.CreateAccountRequest) error); ok {
+		r1 = rf(ctx, accReq)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Service_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
-type Service_Login_Call struct {
+// Service_CreateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccount'
+type Service_CreateAccount_Call struct {
 	*mock.Call
 }
 
-// Login is a helper method to define mock.On call
+// CreateAccount is a helper method to define mock.On call
 //  - ctx context.Context
-//  - lReq bank.LoginRequest
-func (_e *Service_Expecter) Login(ctx interface{}, lReq interface{}) *Service_Login_Call {
-	return &Service_Login_Call{Call: _e.mock.On("Login", ctx, lReq)}
+//  - accReq bank.CreateAccountRequest
+func (_e *Service_Expecter) CreateAccount(ctx interface{}, accReq interface{}) *Service_CreateAccount_Call {
+	return &Service_CreateAccount_Call{Call: _e.mock.On("CreateAccount", ctx, accReq)}
 }
 
-func (_c *Service_Login_Call) Run(run func(ctx context.Context, lReq bank.LoginRequest)) *Service_Login_Call {
+func (_c *Service_CreateAccount_Call) Run(run func(ctx context.Context, accReq bank.CreateAccountRequest)) *Service_CreateAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(bank.LoginRequest))
+		run(args[0].(context.Context), args[1].(bank.CreateAccountRequest))
 	})
 	return _c
 }
 
-func (_c *Service_Login_Call) Return(tokenString string, tokenExpirationTime time.Time, err error) *Service_Login_Call {
-	_c.Call.Return(tokenString, tokenExpirationTime, err)
+func (_c *Service_CreateAccount_Call) Return(accRes bank.CreateAccountResponse, err error) *Service_CreateAccount_Call {
+	_c.Call.Return(accRes, err)
 	return _c
 }
 
-// WithdrawAmount provides a mock function with given fields: ctx, accId, userID, amount
-func (_m *Service) WithdrawAmount(ctx context.Context, accId string, userID string, amount float32) error {
-	ret := _m.Called(ctx, accId, userID, amount)
+// CreateFXRate provides a mock function with given fields: ctx, claims, req
+func (_m *Service) CreateFXRate(ctx context.Context, claims *bank.Claims, req bank.FXRateRequest) (db.FXRate, error) {
+	ret := _m.Called(ctx, claims, req)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) error); ok {
-		r0 = rf(ctx, accId, userID, amount)
+	var r0 db.FXRate
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, bank.FXRateRequest) db.FXRate); ok {
+		r0 = rf(ctx, claims, req)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.FXRate)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, bank.FXRateRequest) error); ok {
+		r1 = rf(ctx, claims, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Service_WithdrawAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithdrawAmount'
-type Service_WithdrawAmount_Call struct {
+// Service_CreateFXRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFXRate'
+type Service_CreateFXRate_Call struct {
 	*mock.Call
 }
 
-// WithdrawAmount is a helper method to define mock.On call
+// CreateFXRate is a helper method to define mock.On call
 //  - ctx context.Context
-//  - accId string
-//  - userID string
-//  - amount float32
-func (_e *Service_Expecter) WithdrawAmount(ctx interface{}, accId interface{}, userID interface{}, amount interface{}) *Service_WithdrawAmount_Call {
-	return &Service_WithdrawAmount_Call{Call: _e.mock.On("WithdrawAmount", ctx, accId, userID, amount)}
+//  - claims *bank.Claims
+//  - req bank.FXRateRequest
+func (_e *Service_Expecter) CreateFXRate(ctx interface{}, claims interface{}, req interface{}) *Service_CreateFXRate_Call {
+	return &Service_CreateFXRate_Call{Call: _e.mock.On("CreateFXRate", ctx, claims, req)}
 }
 
-func (_c *Service_WithdrawAmount_Call) Run(run func(ctx context.Context, accId string, userID string, amount float32)) *Service_WithdrawAmount_Call {
+func (_c *Service_CreateFXRate_Call) Run(run func(ctx context.Context, claims *bank.Claims, req bank.FXRateRequest)) *Service_CreateFXRate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(float32))
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(bank.FXRateRequest))
 	})
 	return _c
 }
 
-func (_c *Service_WithdrawAmount_Call) Return(err error) *Service_WithdrawAmount_Call {
-	_c.Call.Return(err)
+func (_c *Service_CreateFXRate_Call) Return(rate db.FXRate, err error) *Service_CreateFXRate_Call {
+	_c.Call.Return(rate, err)
+	return _c
+}
+
+// CreateFeeRule provides a mock function with given fields: ctx, claims, req
+func (_m *Service) CreateFeeRule(ctx context.Context, claims *bank.Claims, req bank.FeeRuleRequest) (db.FeeRule, error) {
+	ret := _m.Called(ctx, claims, req)
+
+	var r0 db.FeeRule
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, bank.FeeRuleRequest) db.FeeRule); ok {
+		r0 = rf(ctx, claims, req)
+	} else {
+		r0 = ret.Get(0).(db.FeeRule)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, bank.FeeRuleRequest) error); ok {
+		r1 = rf(ctx, claims, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_CreateFeeRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFeeRule'
+type Service_CreateFeeRule_Call struct {
+	*mock.Call
+}
+
+// CreateFeeRule is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - req bank.FeeRuleRequest
+func (_e *Service_Expecter) CreateFeeRule(ctx interface{}, claims interface{}, req interface{}) *Service_CreateFeeRule_Call {
+	return &Service_CreateFeeRule_Call{Call: _e.mock.On("CreateFeeRule", ctx, claims, req)}
+}
+
+func (_c *Service_CreateFeeRule_Call) Run(run func(ctx context.Context, claims *bank.Claims, req bank.FeeRuleRequest)) *Service_CreateFeeRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(bank.FeeRuleRequest))
+	})
+	return _c
+}
+
+func (_c *Service_CreateFeeRule_Call) Return(rule db.FeeRule, err error) *Service_CreateFeeRule_Call {
+	_c.Call.Return(rule, err)
+	return _c
+}
+
+// CreateHold provides a mock function with given fields: ctx, claims, accId, req
+func (_m *Service) CreateHold(ctx context.Context, claims *bank.Claims, accId string, req bank.CreateHoldRequest) (db.Hold, error) {
+	ret := _m.Called(ctx, claims, accId, req)
+
+	var r0 db.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string, bank.CreateHoldRequest) db.Hold); ok {
+		r0 = rf(ctx, claims, accId, req)
+	} else {
+		r0 = ret.Get(0).(db.Hold)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, string, bank.CreateHoldRequest) error); ok {
+		r1 = rf(ctx, claims, accId, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_CreateHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHold'
+type Service_CreateHold_Call struct {
+	*mock.Call
+}
+
+// CreateHold is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - accId string
+//  - req bank.CreateHoldRequest
+func (_e *Service_Expecter) CreateHold(ctx interface{}, claims interface{}, accId interface{}, req interface{}) *Service_CreateHold_Call {
+	return &Service_CreateHold_Call{Call: _e.mock.On("CreateHold", ctx, claims, accId, req)}
+}
+
+func (_c *Service_CreateHold_Call) Run(run func(ctx context.Context, claims *bank.Claims, accId string, req bank.CreateHoldRequest)) *Service_CreateHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string), args[3].(bank.CreateHoldRequest))
+	})
+	return _c
+}
+
+func (_c *Service_CreateHold_Call) Return(hold db.Hold, err error) *Service_CreateHold_Call {
+	_c.Call.Return(hold, err)
+	return _c
+}
+
+// CreateStandingOrder provides a mock function with given fields: ctx, accId, userID, req
+func (_m *Service) CreateStandingOrder(ctx context.Context, accId string, userID string, req bank.CreateStandingOrderRequest) (db.StandingOrder, error) {
+	ret := _m.Called(ctx, accId, userID, req)
+
+	var r0 db.StandingOrder
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bank.CreateStandingOrderRequest) db.StandingOrder); ok {
+		r0 = rf(ctx, accId, userID, req)
+	} else {
+		r0 = ret.Get(0).(db.StandingOrder)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bank.CreateStandingOrderRequest) error); ok {
+		r1 = rf(ctx, accId, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_CreateStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStandingOrder'
+type Service_CreateStandingOrder_Call struct {
+	*mock.Call
+}
+
+// CreateStandingOrder is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+//  - req bank.CreateStandingOrderRequest
+func (_e *Service_Expecter) CreateStandingOrder(ctx interface{}, accId interface{}, userID interface{}, req interface{}) *Service_CreateStandingOrder_Call {
+	return &Service_CreateStandingOrder_Call{Call: _e.mock.On("CreateStandingOrder", ctx, accId, userID, req)}
+}
+
+func (_c *Service_CreateStandingOrder_Call) Run(run func(ctx context.Context, accId string, userID string, req bank.CreateStandingOrderRequest)) *Service_CreateStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bank.CreateStandingOrderRequest))
+	})
+	return _c
+}
+
+func (_c *Service_CreateStandingOrder_Call) Return(order db.StandingOrder, err error) *Service_CreateStandingOrder_Call {
+	_c.Call.Return(order, err)
+	return _c
+}
+
+// DepositAmount provides a mock function with given fields: ctx, accId, userID, amount, currency
+func (_m *Service) DepositAmount(ctx context.Context, accId string, userID string, amount money.Amount, currency string) error {
+	ret := _m.Called(ctx, accId, userID, amount, currency)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Amount, string) error); ok {
+		r0 = rf(ctx, accId, userID, amount, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_DepositAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DepositAmount'
+type Service_DepositAmount_Call struct {
+	*mock.Call
+}
+
+// DepositAmount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+//  - amount money.Amount
+//  - currency string
+func (_e *Service_Expecter) DepositAmount(ctx interface{}, accId interface{}, userID interface{}, amount interface{}, currency interface{}) *Service_DepositAmount_Call {
+	return &Service_DepositAmount_Call{Call: _e.mock.On("DepositAmount", ctx, accId, userID, amount, currency)}
+}
+
+func (_c *Service_DepositAmount_Call) Run(run func(ctx context.Context, accId string, userID string, amount money.Amount, currency string)) *Service_DepositAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(money.Amount), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Service_DepositAmount_Call) Return(err error) *Service_DepositAmount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// EnrolMFA provides a mock function with given fields: ctx, claims
+func (_m *Service) EnrolMFA(ctx context.Context, claims *bank.Claims) (bank.MFAEnrolment, error) {
+	ret := _m.Called(ctx, claims)
+
+	var r0 bank.MFAEnrolment
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims) bank.MFAEnrolment); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		r0 = ret.Get(0).(bank.MFAEnrolment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims) error); ok {
+		r1 = rf(ctx, claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_EnrolMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrolMFA'
+type Service_EnrolMFA_Call struct {
+	*mock.Call
+}
+
+// EnrolMFA is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+func (_e *Service_Expecter) EnrolMFA(ctx interface{}, claims interface{}) *Service_EnrolMFA_Call {
+	return &Service_EnrolMFA_Call{Call: _e.mock.On("EnrolMFA", ctx, claims)}
+}
+
+func (_c *Service_EnrolMFA_Call) Run(run func(ctx context.Context, claims *bank.Claims)) *Service_EnrolMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims))
+	})
+	return _c
+}
+
+func (_c *Service_EnrolMFA_Call) Return(enrolment bank.MFAEnrolment, err error) *Service_EnrolMFA_Call {
+	_c.Call.Return(enrolment, err)
+	return _c
+}
+
+// ExecuteStandingOrders provides a mock function with given fields: ctx, now
+func (_m *Service) ExecuteStandingOrders(ctx context.Context, now time.Time) (bank.StandingOrderRun, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 bank.StandingOrderRun
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) bank.StandingOrderRun); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(bank.StandingOrderRun)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ExecuteStandingOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteStandingOrders'
+type Service_ExecuteStandingOrders_Call struct {
+	*mock.Call
+}
+
+// ExecuteStandingOrders is a helper method to define mock.On call
+//  - ctx context.Context
+//  - now time.Time
+func (_e *Service_Expecter) ExecuteStandingOrders(ctx interface{}, now interface{}) *Service_ExecuteStandingOrders_Call {
+	return &Service_ExecuteStandingOrders_Call{Call: _e.mock.On("ExecuteStandingOrders", ctx, now)}
+}
+
+func (_c *Service_ExecuteStandingOrders_Call) Run(run func(ctx context.Context, now time.Time)) *Service_ExecuteStandingOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Service_ExecuteStandingOrders_Call) Return(run bank.StandingOrderRun, err error) *Service_ExecuteStandingOrders_Call {
+	_c.Call.Return(run, err)
+	return _c
+}
+
+// ExpireHolds provides a mock function with given fields: ctx, now
+func (_m *Service) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ExpireHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireHolds'
+type Service_ExpireHolds_Call struct {
+	*mock.Call
+}
+
+// ExpireHolds is a helper method to define mock.On call
+//  - ctx context.Context
+//  - now time.Time
+func (_e *Service_Expecter) ExpireHolds(ctx interface{}, now interface{}) *Service_ExpireHolds_Call {
+	return &Service_ExpireHolds_Call{Call: _e.mock.On("ExpireHolds", ctx, now)}
+}
+
+func (_c *Service_ExpireHolds_Call) Run(run func(ctx context.Context, now time.Time)) *Service_ExpireHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Service_ExpireHolds_Call) Return(expired int, err error) *Service_ExpireHolds_Call {
+	_c.Call.Return(expired, err)
+	return _c
+}
+
+// ForgotPassword provides a mock function with given fields: ctx, email
+func (_m *Service) ForgotPassword(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_ForgotPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgotPassword'
+type Service_ForgotPassword_Call struct {
+	*mock.Call
+}
+
+// ForgotPassword is a helper method to define mock.On call
+//  - ctx context.Context
+//  - email string
+func (_e *Service_Expecter) ForgotPassword(ctx interface{}, email interface{}) *Service_ForgotPassword_Call {
+	return &Service_ForgotPassword_Call{Call: _e.mock.On("ForgotPassword", ctx, email)}
+}
+
+func (_c *Service_ForgotPassword_Call) Run(run func(ctx context.Context, email string)) *Service_ForgotPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Service_ForgotPassword_Call) Return(err error) *Service_ForgotPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// GetAccountDetails provides a mock function with given fields: ctx, accId, userID
+func (_m *Service) GetAccountDetails(ctx context.Context, accId string, userID string) (db.UserAccountDetails, error) {
+	ret := _m.Called(ctx, accId, userID)
+
+	var r0 db.UserAccountDetails
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) db.UserAccountDetails); ok {
+		r0 = rf(ctx, accId, userID)
+	} else {
+		r0 = ret.Get(0).(db.UserAccountDetails)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accId, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetAccountDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountDetails'
+type Service_GetAccountDetails_Call struct {
+	*mock.Call
+}
+
+// GetAccountDetails is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+func (_e *Service_Expecter) GetAccountDetails(ctx interface{}, accId interface{}, userID interface{}) *Service_GetAccountDetails_Call {
+	return &Service_GetAccountDetails_Call{Call: _e.mock.On("GetAccountDetails", ctx, accId, userID)}
+}
+
+func (_c *Service_GetAccountDetails_Call) Run(run func(ctx context.Context, accId string, userID string)) *Service_GetAccountDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Service_GetAccountDetails_Call) Return(acc db.UserAccountDetails, err error) *Service_GetAccountDetails_Call {
+	_c.Call.Return(acc, err)
+	return _c
+}
+
+// GetAccountLimits provides a mock function with given fields: ctx, accId, userID
+func (_m *Service) GetAccountLimits(ctx context.Context, accId string, userID string) (db.AccountLimits, error) {
+	ret := _m.Called(ctx, accId, userID)
+
+	var r0 db.AccountLimits
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) db.AccountLimits); ok {
+		r0 = rf(ctx, accId, userID)
+	} else {
+		r0 = ret.Get(0).(db.AccountLimits)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accId, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetAccountLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountLimits'
+type Service_GetAccountLimits_Call struct {
+	*mock.Call
+}
+
+// GetAccountLimits is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+func (_e *Service_Expecter) GetAccountLimits(ctx interface{}, accId interface{}, userID interface{}) *Service_GetAccountLimits_Call {
+	return &Service_GetAccountLimits_Call{Call: _e.mock.On("GetAccountLimits", ctx, accId, userID)}
+}
+
+func (_c *Service_GetAccountLimits_Call) Run(run func(ctx context.Context, accId string, userID string)) *Service_GetAccountLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Service_GetAccountLimits_Call) Return(limits db.AccountLimits, err error) *Service_GetAccountLimits_Call {
+	_c.Call.Return(limits, err)
+	return _c
+}
+
+// GetAccountList provides a mock function with given fields: ctx
+func (_m *Service) GetAccountList(ctx context.Context) ([]db.UserAccountDetails, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []db.UserAccountDetails
+	if rf, ok := ret.Get(0).(func(context.Context) []db.UserAccountDetails); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.UserAccountDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetAccountList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountList'
+type Service_GetAccountList_Call struct {
+	*mock.Call
+}
+
+// GetAccountList is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *Service_Expecter) GetAccountList(ctx interface{}) *Service_GetAccountList_Call {
+	return &Service_GetAccountList_Call{Call: _e.mock.On("GetAccountList", ctx)}
+}
+
+func (_c *Service_GetAccountList_Call) Run(run func(ctx context.Context)) *Service_GetAccountList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Service_GetAccountList_Call) Return(accounts []db.UserAccountDetails, err error) *Service_GetAccountList_Call {
+	_c.Call.Return(accounts, err)
+	return _c
+}
+
+// GetAuditLog provides a mock function with given fields: ctx, f
+func (_m *Service) GetAuditLog(ctx context.Context, f db.AuditFilter) ([]db.AuditEntry, error) {
+	ret := _m.Called(ctx, f)
+
+	var r0 []db.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, db.AuditFilter) []db.AuditEntry); ok {
+		r0 = rf(ctx, f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, db.AuditFilter) error); ok {
+		r1 = rf(ctx, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLog'
+type Service_GetAuditLog_Call struct {
+	*mock.Call
+}
+
+// GetAuditLog is a helper method to define mock.On call
+//  - ctx context.Context
+//  - f db.AuditFilter
+func (_e *Service_Expecter) GetAuditLog(ctx interface{}, f interface{}) *Service_GetAuditLog_Call {
+	return &Service_GetAuditLog_Call{Call: _e.mock.On("GetAuditLog", ctx, f)}
+}
+
+func (_c *Service_GetAuditLog_Call) Run(run func(ctx context.Context, f db.AuditFilter)) *Service_GetAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *Service_GetAuditLog_Call) Return(entries []db.AuditEntry, err error) *Service_GetAuditLog_Call {
+	_c.Call.Return(entries, err)
+	return _c
+}
+
+// GetFXRates provides a mock function with given fields: ctx
+func (_m *Service) GetFXRates(ctx context.Context) ([]db.FXRate, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []db.FXRate
+	if rf, ok := ret.Get(0).(func(context.Context) []db.FXRate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FXRate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetFXRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFXRates'
+type Service_GetFXRates_Call struct {
+	*mock.Call
+}
+
+// GetFXRates is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *Service_Expecter) GetFXRates(ctx interface{}) *Service_GetFXRates_Call {
+	return &Service_GetFXRates_Call{Call: _e.mock.On("GetFXRates", ctx)}
+}
+
+func (_c *Service_GetFXRates_Call) Run(run func(ctx context.Context)) *Service_GetFXRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Service_GetFXRates_Call) Return(rates []db.FXRate, err error) *Service_GetFXRates_Call {
+	_c.Call.Return(rates, err)
+	return _c
+}
+
+// GetFeeRules provides a mock function with given fields: ctx
+func (_m *Service) GetFeeRules(ctx context.Context) ([]db.FeeRule, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []db.FeeRule
+	if rf, ok := ret.Get(0).(func(context.Context) []db.FeeRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeeRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetFeeRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeeRules'
+type Service_GetFeeRules_Call struct {
+	*mock.Call
+}
+
+// GetFeeRules is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *Service_Expecter) GetFeeRules(ctx interface{}) *Service_GetFeeRules_Call {
+	return &Service_GetFeeRules_Call{Call: _e.mock.On("GetFeeRules", ctx)}
+}
+
+func (_c *Service_GetFeeRules_Call) Run(run func(ctx context.Context)) *Service_GetFeeRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Service_GetFeeRules_Call) Return(rules []db.FeeRule, err error) *Service_GetFeeRules_Call {
+	_c.Call.Return(rules, err)
+	return _c
+}
+
+// GetHolds provides a mock function with given fields: ctx, accId
+func (_m *Service) GetHolds(ctx context.Context, accId string) ([]db.Hold, error) {
+	ret := _m.Called(ctx, accId)
+
+	var r0 []db.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.Hold); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHolds'
+type Service_GetHolds_Call struct {
+	*mock.Call
+}
+
+// GetHolds is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+func (_e *Service_Expecter) GetHolds(ctx interface{}, accId interface{}) *Service_GetHolds_Call {
+	return &Service_GetHolds_Call{Call: _e.mock.On("GetHolds", ctx, accId)}
+}
+
+func (_c *Service_GetHolds_Call) Run(run func(ctx context.Context, accId string)) *Service_GetHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Service_GetHolds_Call) Return(holds []db.Hold, err error) *Service_GetHolds_Call {
+	_c.Call.Return(holds, err)
+	return _c
+}
+
+// GetStandingOrderExecutions provides a mock function with given fields: ctx, accId, orderId, userID
+func (_m *Service) GetStandingOrderExecutions(ctx context.Context, accId string, orderId string, userID string) ([]db.StandingOrderExecution, error) {
+	ret := _m.Called(ctx, accId, orderId, userID)
+
+	var r0 []db.StandingOrderExecution
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []db.StandingOrderExecution); ok {
+		r0 = rf(ctx, accId, orderId, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StandingOrderExecution)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, accId, orderId, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetStandingOrderExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStandingOrderExecutions'
+type Service_GetStandingOrderExecutions_Call struct {
+	*mock.Call
+}
+
+// GetStandingOrderExecutions is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - orderId string
+//  - userID string
+func (_e *Service_Expecter) GetStandingOrderExecutions(ctx interface{}, accId interface{}, orderId interface{}, userID interface{}) *Service_GetStandingOrderExecutions_Call {
+	return &Service_GetStandingOrderExecutions_Call{Call: _e.mock.On("GetStandingOrderExecutions", ctx, accId, orderId, userID)}
+}
+
+func (_c *Service_GetStandingOrderExecutions_Call) Run(run func(ctx context.Context, accId string, orderId string, userID string)) *Service_GetStandingOrderExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Service_GetStandingOrderExecutions_Call) Return(executions []db.StandingOrderExecution, err error) *Service_GetStandingOrderExecutions_Call {
+	_c.Call.Return(executions, err)
+	return _c
+}
+
+// GetStandingOrders provides a mock function with given fields: ctx, accId, userID
+func (_m *Service) GetStandingOrders(ctx context.Context, accId string, userID string) ([]db.StandingOrder, error) {
+	ret := _m.Called(ctx, accId, userID)
+
+	var r0 []db.StandingOrder
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []db.StandingOrder); ok {
+		r0 = rf(ctx, accId, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StandingOrder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accId, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetStandingOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStandingOrders'
+type Service_GetStandingOrders_Call struct {
+	*mock.Call
+}
+
+// GetStandingOrders is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+func (_e *Service_Expecter) GetStandingOrders(ctx interface{}, accId interface{}, userID interface{}) *Service_GetStandingOrders_Call {
+	return &Service_GetStandingOrders_Call{Call: _e.mock.On("GetStandingOrders", ctx, accId, userID)}
+}
+
+func (_c *Service_GetStandingOrders_Call) Run(run func(ctx context.Context, accId string, userID string)) *Service_GetStandingOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Service_GetStandingOrders_Call) Return(orders []db.StandingOrder, err error) *Service_GetStandingOrders_Call {
+	_c.Call.Return(orders, err)
+	return _c
+}
+
+// GetStatement provides a mock function with given fields: ctx, accId, userID, month
+func (_m *Service) GetStatement(ctx context.Context, accId string, userID string, month time.Time) (db.Statement, error) {
+	ret := _m.Called(ctx, accId, userID, month)
+
+	var r0 db.Statement
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) db.Statement); ok {
+		r0 = rf(ctx, accId, userID, month)
+	} else {
+		r0 = ret.Get(0).(db.Statement)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, accId, userID, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatement'
+type Service_GetStatement_Call struct {
+	*mock.Call
+}
+
+// GetStatement is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+//  - month time.Time
+func (_e *Service_Expecter) GetStatement(ctx interface{}, accId interface{}, userID interface{}, month interface{}) *Service_GetStatement_Call {
+	return &Service_GetStatement_Call{Call: _e.mock.On("GetStatement", ctx, accId, userID, month)}
+}
+
+func (_c *Service_GetStatement_Call) Run(run func(ctx context.Context, accId string, userID string, month time.Time)) *Service_GetStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Service_GetStatement_Call) Return(s db.Statement, err error) *Service_GetStatement_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+// GetTransactionDetails provides a mock function with given fields: ctx, accId, userID, startDate, endDate
+func (_m *Service) GetTransactionDetails(ctx context.Context, accId string, userID string, startDate string, endDate string) ([]db.Transaction, error) {
+	ret := _m.Called(ctx, accId, userID, startDate, endDate)
+
+	var r0 []db.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) []db.Transaction); ok {
+		r0 = rf(ctx, accId, userID, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, accId, userID, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetTransactionDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionDetails'
+type Service_GetTransactionDetails_Call struct {
+	*mock.Call
+}
+
+// GetTransactionDetails is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+//  - startDate string
+//  - endDate string
+func (_e *Service_Expecter) GetTransactionDetails(ctx interface{}, accId interface{}, userID interface{}, startDate interface{}, endDate interface{}) *Service_GetTransactionDetails_Call {
+	return &Service_GetTransactionDetails_Call{Call: _e.mock.On("GetTransactionDetails", ctx, accId, userID, startDate, endDate)}
+}
+
+func (_c *Service_GetTransactionDetails_Call) Run(run func(ctx context.Context, accId string, userID string, startDate string, endDate string)) *Service_GetTransactionDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Service_GetTransactionDetails_Call) Return(transactions []db.Transaction, err error) *Service_GetTransactionDetails_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+// GetTransactionHistory provides a mock function with given fields: ctx, accId, userID, req
+func (_m *Service) GetTransactionHistory(ctx context.Context, accId string, userID string, req bank.TransactionHistoryRequest) (db.TransactionPage, error) {
+	ret := _m.Called(ctx, accId, userID, req)
+
+	var r0 db.TransactionPage
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bank.TransactionHistoryRequest) db.TransactionPage); ok {
+		r0 = rf(ctx, accId, userID, req)
+	} else {
+		r0 = ret.Get(0).(db.TransactionPage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bank.TransactionHistoryRequest) error); ok {
+		r1 = rf(ctx, accId, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetTransactionHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionHistory'
+type Service_GetTransactionHistory_Call struct {
+	*mock.Call
+}
+
+// GetTransactionHistory is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+//  - req bank.TransactionHistoryRequest
+func (_e *Service_Expecter) GetTransactionHistory(ctx interface{}, accId interface{}, userID interface{}, req interface{}) *Service_GetTransactionHistory_Call {
+	return &Service_GetTransactionHistory_Call{Call: _e.mock.On("GetTransactionHistory", ctx, accId, userID, req)}
+}
+
+func (_c *Service_GetTransactionHistory_Call) Run(run func(ctx context.Context, accId string, userID string, req bank.TransactionHistoryRequest)) *Service_GetTransactionHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bank.TransactionHistoryRequest))
+	})
+	return _c
+}
+
+func (_c *Service_GetTransactionHistory_Call) Return(page db.TransactionPage, err error) *Service_GetTransactionHistory_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+// GetUserAccounts provides a mock function with given fields: ctx, userID
+func (_m *Service) GetUserAccounts(ctx context.Context, userID string) ([]db.Account, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []db.Account
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.Account); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_GetUserAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAccounts'
+type Service_GetUserAccounts_Call struct {
+	*mock.Call
+}
+
+// GetUserAccounts is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+func (_e *Service_Expecter) GetUserAccounts(ctx interface{}, userID interface{}) *Service_GetUserAccounts_Call {
+	return &Service_GetUserAccounts_Call{Call: _e.mock.On("GetUserAccounts", ctx, userID)}
+}
+
+func (_c *Service_GetUserAccounts_Call) Run(run func(ctx context.Context, userID string)) *Service_GetUserAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Service_GetUserAccounts_Call) Return(accounts []db.Account, err error) *Service_GetUserAccounts_Call {
+	_c.Call.Return(accounts, err)
+	return _c
+}
+
+// HasPermission provides a mock function with given fields: ctx, role, permission
+func (_m *Service) HasPermission(ctx context.Context, role string, permission string) (bool, error) {
+	ret := _m.Called(ctx, role, permission)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, role, permission)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, role, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_HasPermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasPermission'
+type Service_HasPermission_Call struct {
+	*mock.Call
+}
+
+// HasPermission is a helper method to define mock.On call
+//  - ctx context.Context
+//  - role string
+//  - permission string
+func (_e *Service_Expecter) HasPermission(ctx interface{}, role interface{}, permission interface{}) *Service_HasPermission_Call {
+	return &Service_HasPermission_Call{Call: _e.mock.On("HasPermission", ctx, role, permission)}
+}
+
+func (_c *Service_HasPermission_Call) Run(run func(ctx context.Context, role string, permission string)) *Service_HasPermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Service_HasPermission_Call) Return(allowed bool, err error) *Service_HasPermission_Call {
+	_c.Call.Return(allowed, err)
+	return _c
+}
+
+// Login provides a mock function with given fields: ctx, lReq
+func (_m *Service) Login(ctx context.Context, lReq bank.LoginRequest) (bank.LoginResponse, error) {
+	ret := _m.Called(ctx, lReq)
+
+	var r0 bank.LoginResponse
+	if rf, ok := ret.Get(0).(func(context.Context, bank.LoginRequest) bank.LoginResponse); ok {
+		r0 = rf(ctx, lReq)
+	} else {
+		r0 = ret.Get(0).(bank.LoginResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bank.LoginRequest) error); ok {
+		r1 = rf(ctx, lReq)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type Service_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//  - ctx context.Context
+//  - lReq bank.LoginRequest
+func (_e *Service_Expecter) Login(ctx interface{}, lReq interface{}) *Service_Login_Call {
+	return &Service_Login_Call{Call: _e.mock.On("Login", ctx, lReq)}
+}
+
+func (_c *Service_Login_Call) Run(run func(ctx context.Context, lReq bank.LoginRequest)) *Service_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bank.LoginRequest))
+	})
+	return _c
+}
+
+func (_c *Service_Login_Call) Return(tokens bank.LoginResponse, err error) *Service_Login_Call {
+	_c.Call.Return(tokens, err)
+	return _c
+}
+
+// LoginMFA provides a mock function with given fields: ctx, req
+func (_m *Service) LoginMFA(ctx context.Context, req bank.MFALoginRequest) (bank.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 bank.LoginResponse
+	if rf, ok := ret.Get(0).(func(context.Context, bank.MFALoginRequest) bank.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(bank.LoginResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bank.MFALoginRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_LoginMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginMFA'
+type Service_LoginMFA_Call struct {
+	*mock.Call
+}
+
+// LoginMFA is a helper method to define mock.On call
+//  - ctx context.Context
+//  - req bank.MFALoginRequest
+func (_e *Service_Expecter) LoginMFA(ctx interface{}, req interface{}) *Service_LoginMFA_Call {
+	return &Service_LoginMFA_Call{Call: _e.mock.On("LoginMFA", ctx, req)}
+}
+
+func (_c *Service_LoginMFA_Call) Run(run func(ctx context.Context, req bank.MFALoginRequest)) *Service_LoginMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bank.MFALoginRequest))
+	})
+	return _c
+}
+
+func (_c *Service_LoginMFA_Call) Return(tokens bank.LoginResponse, err error) *Service_LoginMFA_Call {
+	_c.Call.Return(tokens, err)
+	return _c
+}
+
+// Logout provides a mock function with given fields: ctx, claims
+func (_m *Service) Logout(ctx context.Context, claims *bank.Claims) error {
+	ret := _m.Called(ctx, claims)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims) error); ok {
+		r0 = rf(ctx, claims)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type Service_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+func (_e *Service_Expecter) Logout(ctx interface{}, claims interface{}) *Service_Logout_Call {
+	return &Service_Logout_Call{Call: _e.mock.On("Logout", ctx, claims)}
+}
+
+func (_c *Service_Logout_Call) Run(run func(ctx context.Context, claims *bank.Claims)) *Service_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims))
+	})
+	return _c
+}
+
+func (_c *Service_Logout_Call) Return(err error) *Service_Logout_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// OpenAccount provides a mock function with given fields: ctx, customerID, req
+func (_m *Service) OpenAccount(ctx context.Context, customerID string, req bank.OpenAccountRequest) (db.Account, error) {
+	ret := _m.Called(ctx, customerID, req)
+
+	var r0 db.Account
+	if rf, ok := ret.Get(0).(func(context.Context, string, bank.OpenAccountRequest) db.Account); ok {
+		r0 = rf(ctx, customerID, req)
+	} else {
+		r0 = ret.Get(0).(db.Account)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bank.OpenAccountRequest) error); ok {
+		r1 = rf(ctx, customerID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_OpenAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenAccount'
+type Service_OpenAccount_Call struct {
+	*mock.Call
+}
+
+// OpenAccount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - customerID string
+//  - req bank.OpenAccountRequest
+func (_e *Service_Expecter) OpenAccount(ctx interface{}, customerID interface{}, req interface{}) *Service_OpenAccount_Call {
+	return &Service_OpenAccount_Call{Call: _e.mock.On("OpenAccount", ctx, customerID, req)}
+}
+
+func (_c *Service_OpenAccount_Call) Run(run func(ctx context.Context, customerID string, req bank.OpenAccountRequest)) *Service_OpenAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bank.OpenAccountRequest))
+	})
+	return _c
+}
+
+func (_c *Service_OpenAccount_Call) Return(acc db.Account, err error) *Service_OpenAccount_Call {
+	_c.Call.Return(acc, err)
+	return _c
+}
+
+// ProvisionMFA provides a mock function with given fields: ctx, claims, userID
+func (_m *Service) ProvisionMFA(ctx context.Context, claims *bank.Claims, userID string) (bank.MFAEnrolment, error) {
+	ret := _m.Called(ctx, claims, userID)
+
+	var r0 bank.MFAEnrolment
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string) bank.MFAEnrolment); ok {
+		r0 = rf(ctx, claims, userID)
+	} else {
+		r0 = ret.Get(0).(bank.MFAEnrolment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, string) error); ok {
+		r1 = rf(ctx, claims, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ProvisionMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProvisionMFA'
+type Service_ProvisionMFA_Call struct {
+	*mock.Call
+}
+
+// ProvisionMFA is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - userID string
+func (_e *Service_Expecter) ProvisionMFA(ctx interface{}, claims interface{}, userID interface{}) *Service_ProvisionMFA_Call {
+	return &Service_ProvisionMFA_Call{Call: _e.mock.On("ProvisionMFA", ctx, claims, userID)}
+}
+
+func (_c *Service_ProvisionMFA_Call) Run(run func(ctx context.Context, claims *bank.Claims, userID string)) *Service_ProvisionMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Service_ProvisionMFA_Call) Return(enrolment bank.MFAEnrolment, err error) *Service_ProvisionMFA_Call {
+	_c.Call.Return(enrolment, err)
+	return _c
+}
+
+// RefreshToken provides a mock function with given fields: ctx, refreshToken
+func (_m *Service) RefreshToken(ctx context.Context, refreshToken string) (bank.LoginResponse, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	var r0 bank.LoginResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) bank.LoginResponse); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Get(0).(bank.LoginResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
+type Service_RefreshToken_Call struct {
+	*mock.Call
+}
+
+// RefreshToken is a helper method to define mock.On call
+//  - ctx context.Context
+//  - refreshToken string
+func (_e *Service_Expecter) RefreshToken(ctx interface{}, refreshToken interface{}) *Service_RefreshToken_Call {
+	return &Service_RefreshToken_Call{Call: _e.mock.On("RefreshToken", ctx, refreshToken)}
+}
+
+func (_c *Service_RefreshToken_Call) Run(run func(ctx context.Context, refreshToken string)) *Service_RefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Service_RefreshToken_Call) Return(tokens bank.LoginResponse, err error) *Service_RefreshToken_Call {
+	_c.Call.Return(tokens, err)
+	return _c
+}
+
+// ReleaseHold provides a mock function with given fields: ctx, claims, accId, holdId
+func (_m *Service) ReleaseHold(ctx context.Context, claims *bank.Claims, accId string, holdId string) (db.Hold, error) {
+	ret := _m.Called(ctx, claims, accId, holdId)
+
+	var r0 db.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string, string) db.Hold); ok {
+		r0 = rf(ctx, claims, accId, holdId)
+	} else {
+		r0 = ret.Get(0).(db.Hold)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, string, string) error); ok {
+		r1 = rf(ctx, claims, accId, holdId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ReleaseHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseHold'
+type Service_ReleaseHold_Call struct {
+	*mock.Call
+}
+
+// ReleaseHold is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - accId string
+//  - holdId string
+func (_e *Service_Expecter) ReleaseHold(ctx interface{}, claims interface{}, accId interface{}, holdId interface{}) *Service_ReleaseHold_Call {
+	return &Service_ReleaseHold_Call{Call: _e.mock.On("ReleaseHold", ctx, claims, accId, holdId)}
+}
+
+func (_c *Service_ReleaseHold_Call) Run(run func(ctx context.Context, claims *bank.Claims, accId string, holdId string)) *Service_ReleaseHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Service_ReleaseHold_Call) Return(hold db.Hold, err error) *Service_ReleaseHold_Call {
+	_c.Call.Return(hold, err)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: ctx, req
+func (_m *Service) ResetPassword(ctx context.Context, req bank.ResetPasswordRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bank.ResetPasswordRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type Service_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//  - ctx context.Context
+//  - req bank.ResetPasswordRequest
+func (_e *Service_Expecter) ResetPassword(ctx interface{}, req interface{}) *Service_ResetPassword_Call {
+	return &Service_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, req)}
+}
+
+func (_c *Service_ResetPassword_Call) Run(run func(ctx context.Context, req bank.ResetPasswordRequest)) *Service_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bank.ResetPasswordRequest))
+	})
+	return _c
+}
+
+func (_c *Service_ResetPassword_Call) Return(err error) *Service_ResetPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// ReverseTransaction provides a mock function with given fields: ctx, claims, accId, transactionId, req
+func (_m *Service) ReverseTransaction(ctx context.Context, claims *bank.Claims, accId string, transactionId string, req bank.ReverseTransactionRequest) (db.Transaction, error) {
+	ret := _m.Called(ctx, claims, accId, transactionId, req)
+
+	var r0 db.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string, string, bank.ReverseTransactionRequest) db.Transaction); ok {
+		r0 = rf(ctx, claims, accId, transactionId, req)
+	} else {
+		r0 = ret.Get(0).(db.Transaction)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, string, string, bank.ReverseTransactionRequest) error); ok {
+		r1 = rf(ctx, claims, accId, transactionId, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ReverseTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReverseTransaction'
+type Service_ReverseTransaction_Call struct {
+	*mock.Call
+}
+
+// ReverseTransaction is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - accId string
+//  - transactionId string
+//  - req bank.ReverseTransactionRequest
+func (_e *Service_Expecter) ReverseTransaction(ctx interface{}, claims interface{}, accId interface{}, transactionId interface{}, req interface{}) *Service_ReverseTransaction_Call {
+	return &Service_ReverseTransaction_Call{Call: _e.mock.On("ReverseTransaction", ctx, claims, accId, transactionId, req)}
+}
+
+func (_c *Service_ReverseTransaction_Call) Run(run func(ctx context.Context, claims *bank.Claims, accId string, transactionId string, req bank.ReverseTransactionRequest)) *Service_ReverseTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string), args[3].(string), args[4].(bank.ReverseTransactionRequest))
+	})
+	return _c
+}
+
+func (_c *Service_ReverseTransaction_Call) Return(reversal db.Transaction, err error) *Service_ReverseTransaction_Call {
+	_c.Call.Return(reversal, err)
+	return _c
+}
+
+// SetAccountLimits provides a mock function with given fields: ctx, claims, accId, req
+func (_m *Service) SetAccountLimits(ctx context.Context, claims *bank.Claims, accId string, req bank.SetDebitLimitsRequest) error {
+	ret := _m.Called(ctx, claims, accId, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string, bank.SetDebitLimitsRequest) error); ok {
+		r0 = rf(ctx, claims, accId, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_SetAccountLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountLimits'
+type Service_SetAccountLimits_Call struct {
+	*mock.Call
+}
+
+// SetAccountLimits is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - accId string
+//  - req bank.SetDebitLimitsRequest
+func (_e *Service_Expecter) SetAccountLimits(ctx interface{}, claims interface{}, accId interface{}, req interface{}) *Service_SetAccountLimits_Call {
+	return &Service_SetAccountLimits_Call{Call: _e.mock.On("SetAccountLimits", ctx, claims, accId, req)}
+}
+
+func (_c *Service_SetAccountLimits_Call) Run(run func(ctx context.Context, claims *bank.Claims, accId string, req bank.SetDebitLimitsRequest)) *Service_SetAccountLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string), args[3].(bank.SetDebitLimitsRequest))
+	})
+	return _c
+}
+
+func (_c *Service_SetAccountLimits_Call) Return(err error) *Service_SetAccountLimits_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// SetOverdraftLimit provides a mock function with given fields: ctx, claims, accId, req
+func (_m *Service) SetOverdraftLimit(ctx context.Context, claims *bank.Claims, accId string, req bank.SetOverdraftLimitRequest) error {
+	ret := _m.Called(ctx, claims, accId, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string, bank.SetOverdraftLimitRequest) error); ok {
+		r0 = rf(ctx, claims, accId, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_SetOverdraftLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOverdraftLimit'
+type Service_SetOverdraftLimit_Call struct {
+	*mock.Call
+}
+
+// SetOverdraftLimit is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - accId string
+//  - req bank.SetOverdraftLimitRequest
+func (_e *Service_Expecter) SetOverdraftLimit(ctx interface{}, claims interface{}, accId interface{}, req interface{}) *Service_SetOverdraftLimit_Call {
+	return &Service_SetOverdraftLimit_Call{Call: _e.mock.On("SetOverdraftLimit", ctx, claims, accId, req)}
+}
+
+func (_c *Service_SetOverdraftLimit_Call) Run(run func(ctx context.Context, claims *bank.Claims, accId string, req bank.SetOverdraftLimitRequest)) *Service_SetOverdraftLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string), args[3].(bank.SetOverdraftLimitRequest))
+	})
+	return _c
+}
+
+func (_c *Service_SetOverdraftLimit_Call) Return(err error) *Service_SetOverdraftLimit_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// SetUserRole provides a mock function with given fields: ctx, claims, userID, req
+func (_m *Service) SetUserRole(ctx context.Context, claims *bank.Claims, userID string, req bank.SetUserRoleRequest) error {
+	ret := _m.Called(ctx, claims, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string, bank.SetUserRoleRequest) error); ok {
+		r0 = rf(ctx, claims, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_SetUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserRole'
+type Service_SetUserRole_Call struct {
+	*mock.Call
+}
+
+// SetUserRole is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - userID string
+//  - req bank.SetUserRoleRequest
+func (_e *Service_Expecter) SetUserRole(ctx interface{}, claims interface{}, userID interface{}, req interface{}) *Service_SetUserRole_Call {
+	return &Service_SetUserRole_Call{Call: _e.mock.On("SetUserRole", ctx, claims, userID, req)}
+}
+
+func (_c *Service_SetUserRole_Call) Run(run func(ctx context.Context, claims *bank.Claims, userID string, req bank.SetUserRoleRequest)) *Service_SetUserRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string), args[3].(bank.SetUserRoleRequest))
+	})
+	return _c
+}
+
+func (_c *Service_SetUserRole_Call) Return(err error) *Service_SetUserRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// StaffDepositAmount provides a mock function with given fields: ctx, claims, access, accId, amount, currency
+func (_m *Service) StaffDepositAmount(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string, amount money.Amount, currency string) error {
+	ret := _m.Called(ctx, claims, access, accId, amount, currency)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, bank.StaffAccess, string, money.Amount, string) error); ok {
+		r0 = rf(ctx, claims, access, accId, amount, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_StaffDepositAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StaffDepositAmount'
+type Service_StaffDepositAmount_Call struct {
+	*mock.Call
+}
+
+// StaffDepositAmount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - access bank.StaffAccess
+//  - accId string
+//  - amount money.Amount
+//  - currency string
+func (_e *Service_Expecter) StaffDepositAmount(ctx interface{}, claims interface{}, access interface{}, accId interface{}, amount interface{}, currency interface{}) *Service_StaffDepositAmount_Call {
+	return &Service_StaffDepositAmount_Call{Call: _e.mock.On("StaffDepositAmount", ctx, claims, access, accId, amount, currency)}
+}
+
+func (_c *Service_StaffDepositAmount_Call) Run(run func(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string, amount money.Amount, currency string)) *Service_StaffDepositAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(bank.StaffAccess), args[3].(string), args[4].(money.Amount), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *Service_StaffDepositAmount_Call) Return(err error) *Service_StaffDepositAmount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// StaffGetAccountDetails provides a mock function with given fields: ctx, claims, access, accId
+func (_m *Service) StaffGetAccountDetails(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string) (db.UserAccountDetails, error) {
+	ret := _m.Called(ctx, claims, access, accId)
+
+	var r0 db.UserAccountDetails
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, bank.StaffAccess, string) db.UserAccountDetails); ok {
+		r0 = rf(ctx, claims, access, accId)
+	} else {
+		r0 = ret.Get(0).(db.UserAccountDetails)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, bank.StaffAccess, string) error); ok {
+		r1 = rf(ctx, claims, access, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_StaffGetAccountDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StaffGetAccountDetails'
+type Service_StaffGetAccountDetails_Call struct {
+	*mock.Call
+}
+
+// StaffGetAccountDetails is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - access bank.StaffAccess
+//  - accId string
+func (_e *Service_Expecter) StaffGetAccountDetails(ctx interface{}, claims interface{}, access interface{}, accId interface{}) *Service_StaffGetAccountDetails_Call {
+	return &Service_StaffGetAccountDetails_Call{Call: _e.mock.On("StaffGetAccountDetails", ctx, claims, access, accId)}
+}
+
+func (_c *Service_StaffGetAccountDetails_Call) Run(run func(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string)) *Service_StaffGetAccountDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(bank.StaffAccess), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Service_StaffGetAccountDetails_Call) Return(acc db.UserAccountDetails, err error) *Service_StaffGetAccountDetails_Call {
+	_c.Call.Return(acc, err)
+	return _c
+}
+
+// StaffGetTransactionDetails provides a mock function with given fields: ctx, claims, access, accId, startDate, endDate
+func (_m *Service) StaffGetTransactionDetails(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string, startDate string, endDate string) ([]db.Transaction, error) {
+	ret := _m.Called(ctx, claims, access, accId, startDate, endDate)
+
+	var r0 []db.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, bank.StaffAccess, string, string, string) []db.Transaction); ok {
+		r0 = rf(ctx, claims, access, accId, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, bank.StaffAccess, string, string, string) error); ok {
+		r1 = rf(ctx, claims, access, accId, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_StaffGetTransactionDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StaffGetTransactionDetails'
+type Service_StaffGetTransactionDetails_Call struct {
+	*mock.Call
+}
+
+// StaffGetTransactionDetails is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - access bank.StaffAccess
+//  - accId string
+//  - startDate string
+//  - endDate string
+func (_e *Service_Expecter) StaffGetTransactionDetails(ctx interface{}, claims interface{}, access interface{}, accId interface{}, startDate interface{}, endDate interface{}) *Service_StaffGetTransactionDetails_Call {
+	return &Service_StaffGetTransactionDetails_Call{Call: _e.mock.On("StaffGetTransactionDetails", ctx, claims, access, accId, startDate, endDate)}
+}
+
+func (_c *Service_StaffGetTransactionDetails_Call) Run(run func(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string, startDate string, endDate string)) *Service_StaffGetTransactionDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(bank.StaffAccess), args[3].(string), args[4].(string), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *Service_StaffGetTransactionDetails_Call) Return(transactions []db.Transaction, err error) *Service_StaffGetTransactionDetails_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+// StaffTransferAmount provides a mock function with given fields: ctx, claims, access, accId, toAccId, amount, currency
+func (_m *Service) StaffTransferAmount(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string, toAccId string, amount money.Amount, currency string) (db.Transfer, error) {
+	ret := _m.Called(ctx, claims, access, accId, toAccId, amount, currency)
+
+	var r0 db.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, bank.StaffAccess, string, string, money.Amount, string) db.Transfer); ok {
+		r0 = rf(ctx, claims, access, accId, toAccId, amount, currency)
+	} else {
+		r0 = ret.Get(0).(db.Transfer)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, bank.StaffAccess, string, string, money.Amount, string) error); ok {
+		r1 = rf(ctx, claims, access, accId, toAccId, amount, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_StaffTransferAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StaffTransferAmount'
+type Service_StaffTransferAmount_Call struct {
+	*mock.Call
+}
+
+// StaffTransferAmount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - access bank.StaffAccess
+//  - accId string
+//  - toAccId string
+//  - amount money.Amount
+//  - currency string
+func (_e *Service_Expecter) StaffTransferAmount(ctx interface{}, claims interface{}, access interface{}, accId interface{}, toAccId interface{}, amount interface{}, currency interface{}) *Service_StaffTransferAmount_Call {
+	return &Service_StaffTransferAmount_Call{Call: _e.mock.On("StaffTransferAmount", ctx, claims, access, accId, toAccId, amount, currency)}
+}
+
+func (_c *Service_StaffTransferAmount_Call) Run(run func(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string, toAccId string, amount money.Amount, currency string)) *Service_StaffTransferAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(bank.StaffAccess), args[3].(string), args[4].(string), args[5].(money.Amount), args[6].(string))
+	})
+	return _c
+}
+
+func (_c *Service_StaffTransferAmount_Call) Return(transfer db.Transfer, err error) *Service_StaffTransferAmount_Call {
+	_c.Call.Return(transfer, err)
+	return _c
+}
+
+// StaffWithdrawAmount provides a mock function with given fields: ctx, claims, access, accId, amount, currency
+func (_m *Service) StaffWithdrawAmount(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string, amount money.Amount, currency string) error {
+	ret := _m.Called(ctx, claims, access, accId, amount, currency)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, bank.StaffAccess, string, money.Amount, string) error); ok {
+		r0 = rf(ctx, claims, access, accId, amount, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_StaffWithdrawAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StaffWithdrawAmount'
+type Service_StaffWithdrawAmount_Call struct {
+	*mock.Call
+}
+
+// StaffWithdrawAmount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - access bank.StaffAccess
+//  - accId string
+//  - amount money.Amount
+//  - currency string
+func (_e *Service_Expecter) StaffWithdrawAmount(ctx interface{}, claims interface{}, access interface{}, accId interface{}, amount interface{}, currency interface{}) *Service_StaffWithdrawAmount_Call {
+	return &Service_StaffWithdrawAmount_Call{Call: _e.mock.On("StaffWithdrawAmount", ctx, claims, access, accId, amount, currency)}
+}
+
+func (_c *Service_StaffWithdrawAmount_Call) Run(run func(ctx context.Context, claims *bank.Claims, access bank.StaffAccess, accId string, amount money.Amount, currency string)) *Service_StaffWithdrawAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(bank.StaffAccess), args[3].(string), args[4].(money.Amount), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *Service_StaffWithdrawAmount_Call) Return(err error) *Service_StaffWithdrawAmount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// StartIdempotentRequest provides a mock function with given fields: ctx, userID, key, fingerprint
+func (_m *Service) StartIdempotentRequest(ctx context.Context, userID string, key string, fingerprint string) (db.IdempotencyKey, bool, error) {
+	ret := _m.Called(ctx, userID, key, fingerprint)
+
+	var r0 db.IdempotencyKey
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) db.IdempotencyKey); ok {
+		r0 = rf(ctx, userID, key, fingerprint)
+	} else {
+		r0 = ret.Get(0).(db.IdempotencyKey)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) bool); ok {
+		r1 = rf(ctx, userID, key, fingerprint)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = rf(ctx, userID, key, fingerprint)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Service_StartIdempotentRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartIdempotentRequest'
+type Service_StartIdempotentRequest_Call struct {
+	*mock.Call
+}
+
+// StartIdempotentRequest is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+//  - key string
+//  - fingerprint string
+func (_e *Service_Expecter) StartIdempotentRequest(ctx interface{}, userID interface{}, key interface{}, fingerprint interface{}) *Service_StartIdempotentRequest_Call {
+	return &Service_StartIdempotentRequest_Call{Call: _e.mock.On("StartIdempotentRequest", ctx, userID, key, fingerprint)}
+}
+
+func (_c *Service_StartIdempotentRequest_Call) Run(run func(ctx context.Context, userID string, key string, fingerprint string)) *Service_StartIdempotentRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Service_StartIdempotentRequest_Call) Return(saved db.IdempotencyKey, replay bool, err error) *Service_StartIdempotentRequest_Call {
+	_c.Call.Return(saved, replay, err)
+	return _c
+}
+
+// TransferAmount provides a mock function with given fields: ctx, accId, userID, toAccId, amount, currency
+func (_m *Service) TransferAmount(ctx context.Context, accId string, userID string, toAccId string, amount money.Amount, currency string) (db.Transfer, error) {
+	ret := _m.Called(ctx, accId, userID, toAccId, amount, currency)
+
+	var r0 db.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, money.Amount, string) db.Transfer); ok {
+		r0 = rf(ctx, accId, userID, toAccId, amount, currency)
+	} else {
+		r0 = ret.Get(0).(db.Transfer)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, money.Amount, string) error); ok {
+		r1 = rf(ctx, accId, userID, toAccId, amount, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_TransferAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferAmount'
+type Service_TransferAmount_Call struct {
+	*mock.Call
+}
+
+// TransferAmount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+//  - toAccId string
+//  - amount money.Amount
+//  - currency string
+func (_e *Service_Expecter) TransferAmount(ctx interface{}, accId interface{}, userID interface{}, toAccId interface{}, amount interface{}, currency interface{}) *Service_TransferAmount_Call {
+	return &Service_TransferAmount_Call{Call: _e.mock.On("TransferAmount", ctx, accId, userID, toAccId, amount, currency)}
+}
+
+func (_c *Service_TransferAmount_Call) Run(run func(ctx context.Context, accId string, userID string, toAccId string, amount money.Amount, currency string)) *Service_TransferAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(money.Amount), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *Service_TransferAmount_Call) Return(transfer db.Transfer, err error) *Service_TransferAmount_Call {
+	_c.Call.Return(transfer, err)
+	return _c
+}
+
+// UnlockUser provides a mock function with given fields: ctx, claims, userID
+func (_m *Service) UnlockUser(ctx context.Context, claims *bank.Claims, userID string) error {
+	ret := _m.Called(ctx, claims, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, string) error); ok {
+		r0 = rf(ctx, claims, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_UnlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockUser'
+type Service_UnlockUser_Call struct {
+	*mock.Call
+}
+
+// UnlockUser is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - userID string
+func (_e *Service_Expecter) UnlockUser(ctx interface{}, claims interface{}, userID interface{}) *Service_UnlockUser_Call {
+	return &Service_UnlockUser_Call{Call: _e.mock.On("UnlockUser", ctx, claims, userID)}
+}
+
+func (_c *Service_UnlockUser_Call) Run(run func(ctx context.Context, claims *bank.Claims, userID string)) *Service_UnlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Service_UnlockUser_Call) Return(err error) *Service_UnlockUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// UpdateFeeRule provides a mock function with given fields: ctx, claims, ruleID, req
+func (_m *Service) UpdateFeeRule(ctx context.Context, claims *bank.Claims, ruleID int64, req bank.FeeRuleRequest) (db.FeeRule, error) {
+	ret := _m.Called(ctx, claims, ruleID, req)
+
+	var r0 db.FeeRule
+	if rf, ok := ret.Get(0).(func(context.Context, *bank.Claims, int64, bank.FeeRuleRequest) db.FeeRule); ok {
+		r0 = rf(ctx, claims, ruleID, req)
+	} else {
+		r0 = ret.Get(0).(db.FeeRule)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *bank.Claims, int64, bank.FeeRuleRequest) error); ok {
+		r1 = rf(ctx, claims, ruleID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_UpdateFeeRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFeeRule'
+type Service_UpdateFeeRule_Call struct {
+	*mock.Call
+}
+
+// UpdateFeeRule is a helper method to define mock.On call
+//  - ctx context.Context
+//  - claims *bank.Claims
+//  - ruleID int64
+//  - req bank.FeeRuleRequest
+func (_e *Service_Expecter) UpdateFeeRule(ctx interface{}, claims interface{}, ruleID interface{}, req interface{}) *Service_UpdateFeeRule_Call {
+	return &Service_UpdateFeeRule_Call{Call: _e.mock.On("UpdateFeeRule", ctx, claims, ruleID, req)}
+}
+
+func (_c *Service_UpdateFeeRule_Call) Run(run func(ctx context.Context, claims *bank.Claims, ruleID int64, req bank.FeeRuleRequest)) *Service_UpdateFeeRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bank.Claims), args[2].(int64), args[3].(bank.FeeRuleRequest))
+	})
+	return _c
+}
+
+func (_c *Service_UpdateFeeRule_Call) Return(rule db.FeeRule, err error) *Service_UpdateFeeRule_Call {
+	_c.Call.Return(rule, err)
+	return _c
+}
+
+// ValidateJWT provides a mock function with given fields: ctx, tokenString
+func (_m *Service) ValidateJWT(ctx context.Context, tokenString string) (*bank.Claims, error) {
+	ret := _m.Called(ctx, tokenString)
+
+	var r0 *bank.Claims
+	if rf, ok := ret.Get(0).(func(context.Context, string) *bank.Claims); ok {
+		r0 = rf(ctx, tokenString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bank.Claims)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ValidateJWT_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateJWT'
+type Service_ValidateJWT_Call struct {
+	*mock.Call
+}
+
+// ValidateJWT is a helper method to define mock.On call
+//  - ctx context.Context
+//  - tokenString string
+func (_e *Service_Expecter) ValidateJWT(ctx interface{}, tokenString interface{}) *Service_ValidateJWT_Call {
+	return &Service_ValidateJWT_Call{Call: _e.mock.On("ValidateJWT", ctx, tokenString)}
+}
+
+func (_c *Service_ValidateJWT_Call) Run(run func(ctx context.Context, tokenString string)) *Service_ValidateJWT_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Service_ValidateJWT_Call) Return(claims *bank.Claims, err error) *Service_ValidateJWT_Call {
+	_c.Call.Return(claims, err)
+	return _c
+}
+
+// WithdrawAmount provides a mock function with given fields: ctx, accId, userID, amount, currency
+func (_m *Service) WithdrawAmount(ctx context.Context, accId string, userID string, amount money.Amount, currency string) error {
+	ret := _m.Called(ctx, accId, userID, amount, currency)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Amount, string) error); ok {
+		r0 = rf(ctx, accId, userID, amount, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Service_WithdrawAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithdrawAmount'
+type Service_WithdrawAmount_Call struct {
+	*mock.Call
+}
+
+// WithdrawAmount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accId string
+//  - userID string
+//  - amount money.Amount
+//  - currency string
+func (_e *Service_Expecter) WithdrawAmount(ctx interface{}, accId interface{}, userID interface{}, amount interface{}, currency interface{}) *Service_WithdrawAmount_Call {
+	return &Service_WithdrawAmount_Call{Call: _e.mock.On("WithdrawAmount", ctx, accId, userID, amount, currency)}
+}
+
+func (_c *Service_WithdrawAmount_Call) Run(run func(ctx context.Context, accId string, userID string, amount money.Amount, currency string)) *Service_WithdrawAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(money.Amount), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Service_WithdrawAmount_Call) Return(err error) *Service_WithdrawAmount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// WriteStatements provides a mock function with given fields: ctx, month, dir
+func (_m *Service) WriteStatements(ctx context.Context, month time.Time, dir string) (bank.StatementRun, error) {
+	ret := _m.Called(ctx, month, dir)
+
+	var r0 bank.StatementRun
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) bank.StatementRun); ok {
+		r0 = rf(ctx, month, dir)
+	} else {
+		r0 = ret.Get(0).(bank.StatementRun)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, string) error); ok {
+		r1 = rf(ctx, month, dir)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_WriteStatements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteStatements'
+type Service_WriteStatements_Call struct {
+	*mock.Call
+}
+
+// WriteStatements is a helper method to define mock.On call
+//  - ctx context.Context
+//  - month time.Time
+//  - dir string
+func (_e *Service_Expecter) WriteStatements(ctx interface{}, month interface{}, dir interface{}) *Service_WriteStatements_Call {
+	return &Service_WriteStatements_Call{Call: _e.mock.On("WriteStatements", ctx, month, dir)}
+}
+
+func (_c *Service_WriteStatements_Call) Run(run func(ctx context.Context, month time.Time, dir string)) *Service_WriteStatements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Service_WriteStatements_Call) Return(run bank.StatementRun, err error) *Service_WriteStatements_Call {
+	_c.Call.Return(run, err)
 	return _c
 }
 
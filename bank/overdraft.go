@@ -0,0 +1,45 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+
+	"example.com/banking/db"
+	"example.com/banking/money"
+)
+
+// SetOverdraftLimit lets the balance of an account go below zero, down to the
+// negative limit. A limit of zero removes the overdraft.
+func (b *bankService) SetOverdraftLimit(ctx context.Context, claims *Claims, accId string, req SetOverdraftLimitRequest) (err error) {
+	reason, err := validateReason(req.Reason)
+	if err != nil {
+		return
+	}
+
+	cur, err := b.accountCurrency(ctx, accId, db.AnyOwner, "")
+	if err != nil {
+		return
+	}
+
+	limit := money.New(0, cur.Digits)
+	if !req.Limit.IsZero() {
+		if limit, err = validateAmount(req.Limit, cur); err != nil {
+			return
+		}
+	}
+
+	b.logger.Infof("User: %v setting overdraft limit of account: %v to %v\n", claims.UserID, accId, limit)
+	err = b.store.SetOverdraftLimit(ctx, accId, limit)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditOverdraftLimitChanged,
+		ActorID: &claims.UserID,
+		Subject: accId,
+		IP:      req.IP,
+		Details: fmt.Sprintf("limit: %v, reason: %v", limit, reason),
+	})
+	return
+}
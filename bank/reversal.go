@@ -0,0 +1,53 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"example.com/banking/db"
+)
+
+var reversalReasonCodes = []string{ReversalDuplicate, ReversalIncorrectAmount, ReversalWrongAccount, ReversalFraud, ReversalCustomerDispute, ReversalRefund}
+
+// ReverseTransaction corrects a credit or debit of an account by posting its
+// opposite, in whole or, as for a partial refund, in part.
+func (b *bankService) ReverseTransaction(ctx context.Context, claims *Claims, accId, transactionId string, req ReverseTransactionRequest) (reversal db.Transaction, err error) {
+	reasonCode := strings.TrimSpace(req.ReasonCode)
+	if !containsString(reversalReasonCodes, reasonCode) {
+		err = fmt.Errorf("%w: reason_code must be one of %v", ErrInvalidReversal, strings.Join(reversalReasonCodes, ", "))
+		return
+	}
+
+	amount := req.Amount
+	if amount != nil {
+		cur, err := b.accountCurrency(ctx, accId, db.AnyOwner, "")
+		if err != nil {
+			return reversal, err
+		}
+		a, err := validateAmount(*amount, cur)
+		if err != nil {
+			return reversal, err
+		}
+		amount = &a
+	}
+
+	b.logger.Infof("User: %v reversing transaction: %v of account: %v\n", claims.UserID, transactionId, accId)
+	reversal, err = b.store.ReverseTransaction(ctx, db.Reversal{
+		TransactionID: transactionId,
+		AccountID:     accId,
+		Amount:        amount,
+		ReasonCode:    reasonCode,
+	})
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditTransactionReversed,
+		ActorID: &claims.UserID,
+		Subject: accId,
+		Details: fmt.Sprintf("transaction: %v, reversal: %v, amount: %v, reason code: %v", transactionId, reversal.ID, reversal.Amount, reasonCode),
+	})
+	return
+}
@@ -0,0 +1,51 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"example.com/banking/db"
+)
+
+var roles = []string{RoleCustomer, RoleTeller, RoleAccountant, RoleBranchManager, RoleAuditor}
+
+// actorID returns the user acting with the claims for the audit log, nil when
+// the operation is run from the command line without any.
+func actorID(claims *Claims) *string {
+	if claims == nil {
+		return nil
+	}
+	return &claims.UserID
+}
+
+// SetUserRole gives a user one of the roles. Staff users are onboarded like
+// customers and then assigned their role. The user's sessions are revoked, so
+// the new role applies from the next login.
+// Claims are nil when run from the command line.
+func (b *bankService) SetUserRole(ctx context.Context, claims *Claims, userID string, req SetUserRoleRequest) (err error) {
+	role := strings.TrimSpace(req.Role)
+	if !containsString(roles, role) {
+		err = fmt.Errorf("%w: role must be one of %v", ErrInvalidRole, strings.Join(roles, ", "))
+		return
+	}
+
+	if claims == nil {
+		b.logger.Infof("Setting role of user: %v to %v from the command line\n", userID, role)
+	} else {
+		b.logger.Infof("User: %v setting role of user: %v to %v\n", claims.UserID, userID, role)
+	}
+	err = b.store.SetUserRole(ctx, userID, role)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditUserRoleChanged,
+		ActorID: actorID(claims),
+		Subject: userID,
+		IP:      req.IP,
+		Details: fmt.Sprintf("role: %v", role),
+	})
+	return
+}
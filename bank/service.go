@@ -2,52 +2,141 @@ package bank
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
+	"unicode"
 
 	"github.com/dgrijalva/jwt-go"
 	uuidgen "github.com/pborman/uuid"
 	"go.uber.org/zap"
 
+	"example.com/banking/config"
 	"example.com/banking/db"
+	"example.com/banking/money"
+	"example.com/banking/notify"
 )
 
-var secretKey = []byte("I'mGoingToBeAGolangDeveloper")
-
 type Service interface {
-	Login(ctx context.Context, lReq LoginRequest) (tokenString string, tokenExpirationTime time.Time, err error)
+	Login(ctx context.Context, lReq LoginRequest) (tokens LoginResponse, err error)
+	RefreshToken(ctx context.Context, refreshToken string) (tokens LoginResponse, err error)
+	Logout(ctx context.Context, claims *Claims) (err error)
+	ValidateJWT(ctx context.Context, tokenString string) (claims *Claims, err error)
+	HasPermission(ctx context.Context, role, permission string) (allowed bool, err error)
+	ChangePassword(ctx context.Context, claims *Claims, req ChangePasswordRequest) (err error)
+	ForgotPassword(ctx context.Context, email string) (err error)
+	ResetPassword(ctx context.Context, req ResetPasswordRequest) (err error)
+	UnlockUser(ctx context.Context, claims *Claims, userID string) (err error)
+	SetUserRole(ctx context.Context, claims *Claims, userID string, req SetUserRoleRequest) (err error)
+	LoginMFA(ctx context.Context, req MFALoginRequest) (tokens LoginResponse, err error)
+	EnrolMFA(ctx context.Context, claims *Claims) (enrolment MFAEnrolment, err error)
+	ProvisionMFA(ctx context.Context, claims *Claims, userID string) (enrolment MFAEnrolment, err error)
+	ConfirmMFA(ctx context.Context, claims *Claims, code string) (recoveryCodes []string, err error)
 	CreateAccount(ctx context.Context, accReq CreateAccountRequest) (accRes CreateAccountResponse, err error)
+	OpenAccount(ctx context.Context, customerID string, req OpenAccountRequest) (acc db.Account, err error)
+	GetUserAccounts(ctx context.Context, userID string) (accounts []db.Account, err error)
 	GetAccountList(ctx context.Context) (accounts []db.UserAccountDetails, err error)
+	ChangeAccountStatus(ctx context.Context, claims *Claims, accId string, req ChangeAccountStatusRequest) (err error)
+	CloseAccount(ctx context.Context, claims *Claims, accId string, req CloseAccountRequest) (closure db.AccountClosure, err error)
+	SetOverdraftLimit(ctx context.Context, claims *Claims, accId string, req SetOverdraftLimitRequest) (err error)
+	GetFXRates(ctx context.Context) (rates []db.FXRate, err error)
+	CreateFXRate(ctx context.Context, claims *Claims, req FXRateRequest) (rate db.FXRate, err error)
+	CreateStandingOrder(ctx context.Context, accId, userID string, req CreateStandingOrderRequest) (order db.StandingOrder, err error)
+	GetStandingOrders(ctx context.Context, accId, userID string) (orders []db.StandingOrder, err error)
+	GetStandingOrderExecutions(ctx context.Context, accId, orderId, userID string) (executions []db.StandingOrderExecution, err error)
+	CancelStandingOrder(ctx context.Context, accId, orderId, userID string) (err error)
+	ExecuteStandingOrders(ctx context.Context, now time.Time) (run StandingOrderRun, err error)
+	CreateHold(ctx context.Context, claims *Claims, accId string, req CreateHoldRequest) (hold db.Hold, err error)
+	GetHolds(ctx context.Context, accId string) (holds []db.Hold, err error)
+	CaptureHold(ctx context.Context, claims *Claims, accId, holdId string, req CaptureHoldRequest) (hold db.Hold, err error)
+	ReleaseHold(ctx context.Context, claims *Claims, accId, holdId string) (hold db.Hold, err error)
+	ExpireHolds(ctx context.Context, now time.Time) (expired int, err error)
+	ReverseTransaction(ctx context.Context, claims *Claims, accId, transactionId string, req ReverseTransactionRequest) (reversal db.Transaction, err error)
+	GetAccountLimits(ctx context.Context, accId, userID string) (limits db.AccountLimits, err error)
+	SetAccountLimits(ctx context.Context, claims *Claims, accId string, req SetDebitLimitsRequest) (err error)
 	GetAccountDetails(ctx context.Context, accId, userID string) (acc db.UserAccountDetails, err error)
-	DepositAmount(ctx context.Context, accId, userID string, amount float32) (err error)
-	WithdrawAmount(ctx context.Context, accId, userID string, amount float32) (err error)
+	DepositAmount(ctx context.Context, accId, userID string, amount money.Amount, currency string) (err error)
+	WithdrawAmount(ctx context.Context, accId, userID string, amount money.Amount, currency string) (err error)
 	GetTransactionDetails(ctx context.Context, accId, userID string, startDate, endDate string) (transactions []db.Transaction, err error)
+	GetTransactionHistory(ctx context.Context, accId, userID string, req TransactionHistoryRequest) (page db.TransactionPage, err error)
+	GetStatement(ctx context.Context, accId, userID string, month time.Time) (s db.Statement, err error)
+	WriteStatements(ctx context.Context, month time.Time, dir string) (run StatementRun, err error)
+	TransferAmount(ctx context.Context, accId, userID, toAccId string, amount money.Amount, currency string) (transfer db.Transfer, err error)
+	StaffGetAccountDetails(ctx context.Context, claims *Claims, access StaffAccess, accId string) (acc db.UserAccountDetails, err error)
+	StaffDepositAmount(ctx context.Context, claims *Claims, access StaffAccess, accId string, amount money.Amount, currency string) (err error)
+	StaffWithdrawAmount(ctx context.Context, claims *Claims, access StaffAccess, accId string, amount money.Amount, currency string) (err error)
+	StaffTransferAmount(ctx context.Context, claims *Claims, access StaffAccess, accId, toAccId string, amount money.Amount, currency string) (transfer db.Transfer, err error)
+	StaffGetTransactionDetails(ctx context.Context, claims *Claims, access StaffAccess, accId, startDate, endDate string) (transactions []db.Transaction, err error)
+	GetAuditLog(ctx context.Context, f db.AuditFilter) (entries []db.AuditEntry, err error)
+	AccrueInterest(ctx context.Context, businessDate time.Time) (run InterestRun, err error)
+	GetFeeRules(ctx context.Context) (rules []db.FeeRule, err error)
+	CreateFeeRule(ctx context.Context, claims *Claims, req FeeRuleRequest) (rule db.FeeRule, err error)
+	UpdateFeeRule(ctx context.Context, claims *Claims, ruleID int64, req FeeRuleRequest) (rule db.FeeRule, err error)
+	ChargeMonthlyFees(ctx context.Context, month time.Time) (run FeeRun, err error)
+	StartIdempotentRequest(ctx context.Context, userID, key, fingerprint string) (saved db.IdempotencyKey, replay bool, err error)
+	CompleteIdempotentRequest(ctx context.Context, userID, key string, statusCode int, body []byte) (err error)
 }
 
 type bankService struct {
-	store  db.Storer
-	logger *zap.SugaredLogger
+	store            db.Storer
+	logger           *zap.SugaredLogger
+	notifier         notify.Notifier
+	idempotencyTTL   time.Duration
+	refreshTokenTTL  time.Duration
+	passwordResetTTL time.Duration
+	loginPolicy      loginPolicy
+	mfaRoles         []string
+	mfaChallengeTTL  time.Duration
+	orderPolicy      standingOrderPolicy
+	holdPolicy       holdPolicy
 }
 
-func NewBankService(s db.Storer, l *zap.SugaredLogger) Service {
+func NewBankService(s db.Storer, l *zap.SugaredLogger, n notify.Notifier) Service {
 	return &bankService{
-		store:  s,
-		logger: l,
+		store:            s,
+		logger:           l,
+		notifier:         n,
+		idempotencyTTL:   config.IdempotencyKeyTTL(),
+		refreshTokenTTL:  config.JWT().RefreshTokenTTL(),
+		passwordResetTTL: config.PasswordResetTTL(),
+		loginPolicy:      newLoginPolicy(),
+		mfaRoles:         config.MFA().RequiredRoles(),
+		mfaChallengeTTL:  config.MFA().ChallengeTTL(),
+		orderPolicy:      newStandingOrderPolicy(),
+		holdPolicy:       newHoldPolicy(),
 	}
 }
 
-func generateJWT(userID string, role string) (tokenString string, tokenExpirationTime time.Time, err error) {
-	tokenExpirationTime = time.Now().Add(5 * time.Minute)
+func generateJWT(userID, role, sessionID string) (tokenString string, tokenExpirationTime time.Time, err error) {
+	tokenExpirationTime = time.Now().Add(jwtKeys.tokenTTL)
 	claims := &Claims{
-		UserID: userID,
-		Role:   role,
+		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
 		StandardClaims: jwt.StandardClaims{
+			Id:        uuidgen.New(),
 			ExpiresAt: tokenExpirationTime.Unix(),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err = token.SignedString(secretKey)
+
+	tokenString, err = signClaims(claims)
+	return
+}
+
+func signClaims(claims *Claims) (tokenString string, err error) {
+	if jwtKeys.signing == nil {
+		err = fmt.Errorf("error generating token, err: %w", ErrUnknownSigningKey)
+		return
+	}
+
+	token := jwt.NewWithClaims(jwtKeys.signing.method, claims)
+	token.Header["kid"] = jwtKeys.signing.id
+	tokenString, err = token.SignedString(jwtKeys.signing.signKey)
 	if err != nil {
 		err = fmt.Errorf("error generating token, err: %v", err)
 		return
@@ -55,35 +144,358 @@ func generateJWT(userID string, role string) (tokenString string, tokenExpiratio
 	return
 }
 
-func ValidateJWT(tokenString string) (claims *Claims, err error) {
+// parseJWT checks the signature and expiry of the token and returns its claims.
+func parseJWT(tokenString string) (claims *Claims, err error) {
 	claims = &Claims{}
 
-	_, err = jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return secretKey, nil
-	})
+	_, err = jwt.ParseWithClaims(tokenString, claims, jwtKeys.verificationKey)
+	if err != nil {
+		err = fmt.Errorf("%w, err: %v", ErrUnauthorized, err)
+		return
+	}
+	return
+}
+
+// randomToken returns a random, URL safe token. Only its hash is stored.
+func randomToken() (token string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newRefreshToken returns a random refresh token and the record to store for
+// it, which only holds the token's hash.
+func newRefreshToken(sessionID string, ttl time.Duration) (token string, rt db.RefreshToken, err error) {
+	token, err = randomToken()
 	if err != nil {
-		err = fmt.Errorf("unauthorized, err: %v", err)
 		return
 	}
+
+	now := time.Now()
+	rt = db.RefreshToken{
+		TokenHash: hashToken(token),
+		SessionID: sessionID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
 	return
 }
 
-func (b *bankService) Login(ctx context.Context, u LoginRequest) (tokenString string, tokenExpirationTime time.Time, err error) {
+// ValidateJWT checks the signature and expiry of the token, and that neither
+// the token nor the session it belongs to has been revoked.
+func (b *bankService) ValidateJWT(ctx context.Context, tokenString string) (claims *Claims, err error) {
+	claims, err = parseJWT(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Id == "" || claims.SessionID == "" || claims.Audience != "" {
+		return nil, fmt.Errorf("%w, err: not an access token", ErrUnauthorized)
+	}
+
+	revoked, err := b.store.IsTokenRevoked(ctx, claims.Id, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, fmt.Errorf("%w, err: token has been revoked", ErrUnauthorized)
+	}
+	return
+}
+
+// HasPermission reports whether the role is granted the permission.
+func (b *bankService) HasPermission(ctx context.Context, role, permission string) (allowed bool, err error) {
+	if role == "" {
+		return false, nil
+	}
+	return b.store.RoleHasPermission(ctx, role, permission)
+}
+
+// audit writes e to the audit log. Failing to do so is logged but does not
+// fail the audited action.
+func (b *bankService) audit(ctx context.Context, e db.AuditEntry) {
+	if err := b.store.AddAuditEntry(ctx, e); err != nil {
+		b.logger.Errorf("Err writing audit entry: %v, err: %v", e.Action, err)
+	}
+}
+
+func (b *bankService) Login(ctx context.Context, u LoginRequest) (tokens LoginResponse, err error) {
+	// Refuse to check the password while failed logins block the email or client
+	if err = b.checkLoginBlocked(ctx, u); err != nil {
+		return
+	}
+
 	// Verify if user is present
 	user, err := b.store.GetUserByEmailAndPassword(ctx, u.Email, u.Password)
 	if err != nil {
 		if err == db.ErrUserNotExist {
+			b.recordLoginFailure(ctx, u)
 			err = ErrUnauthorized
 			return
 		}
 		return
 	}
 
+	// Users enrolled in MFA, or whose role requires it, must also give a code.
+	// Those of a role that requires it cannot log in until an administrator
+	// provisioned their enrolment
+	started, enrolled, err := b.mfaStatus(ctx, user.ID)
+	if err != nil {
+		return
+	}
+	if enrolled || b.mfaRequired(user.Type) {
+		if !started {
+			err = ErrMFANotProvisioned
+			return
+		}
+		tokens.MFA, err = b.mfaChallenge(user, u.Email)
+		return
+	}
+
+	tokens, err = b.startSession(ctx, user, u)
+	return
+}
+
+// checkLoginBlocked returns a LoginBlockedError while failed logins block the
+// email or client.
+func (b *bankService) checkLoginBlocked(ctx context.Context, u LoginRequest) (err error) {
+	attempts, err := b.store.GetLoginAttempts(ctx, u.Email, u.IP)
+	if err != nil {
+		return
+	}
+	if until := b.loginPolicy.blockedUntil(attempts); time.Now().Before(until) {
+		b.audit(ctx, db.AuditEntry{Action: AuditLoginBlocked, Subject: u.Email, IP: u.IP})
+		err = &LoginBlockedError{RetryAt: until}
+	}
+	return
+}
+
+// startSession completes a login: it clears failed logins for the email and
+// issues the tokens of a new session.
+func (b *bankService) startSession(ctx context.Context, user db.User, u LoginRequest) (tokens LoginResponse, err error) {
+	if err = b.store.ResetLoginFailures(ctx, db.LoginAttemptEmail, u.Email); err != nil {
+		return
+	}
+	b.audit(ctx, db.AuditEntry{Action: AuditLoginSucceeded, ActorID: &user.ID, Subject: u.Email, IP: u.IP})
+
+	// Start a session with its first refresh token
+	sess := db.Session{
+		ID:        uuidgen.New(),
+		UserID:    user.ID,
+		CreatedAt: time.Now(),
+	}
+	refreshToken, rt, err := newRefreshToken(sess.ID, b.refreshTokenTTL)
+	if err != nil {
+		return
+	}
+
+	err = b.store.CreateSession(ctx, sess, rt)
+	if err != nil {
+		return
+	}
+
 	// Create the JWT token
-	tokenString, tokenExpirationTime, err = generateJWT(user.ID, user.Type)
+	tokens.AccessToken, tokens.AccessTokenExpiresAt, err = generateJWT(user.ID, user.Type, sess.ID)
+	if err != nil {
+		return
+	}
+	tokens.RefreshToken = refreshToken
+	tokens.RefreshTokenExpiresAt = rt.ExpiresAt
+	return
+}
+
+// recordLoginFailure counts a failed login against the email and the client.
+func (b *bankService) recordLoginFailure(ctx context.Context, u LoginRequest) {
+	resetBefore := time.Now().Add(-b.loginPolicy.lockout)
+
+	failures := 0
+	keys := []struct{ kind, key string }{
+		{db.LoginAttemptEmail, u.Email},
+		{db.LoginAttemptIP, u.IP},
+	}
+	for _, k := range keys {
+		if k.key == "" {
+			continue
+		}
+
+		attempt, err := b.store.RecordLoginFailure(ctx, k.kind, k.key, resetBefore)
+		if err != nil {
+			b.logger.Errorf("Err recording failed login for %v: %v, err: %v", k.kind, k.key, err)
+			continue
+		}
+		if k.kind == db.LoginAttemptEmail {
+			failures = attempt.Failures
+		}
+	}
+
+	b.audit(ctx, db.AuditEntry{
+		Action:  AuditLoginFailed,
+		Subject: u.Email,
+		IP:      u.IP,
+		Details: fmt.Sprintf("consecutive failures: %v", failures),
+	})
+}
+
+// UnlockUser lifts a lockout of the user caused by failed logins.
+func (b *bankService) UnlockUser(ctx context.Context, claims *Claims, userID string) (err error) {
+	b.logger.Infof("Unlocking user: %v\n", userID)
+
+	err = b.store.UnlockUser(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	b.audit(ctx, db.AuditEntry{Action: AuditUserUnlocked, ActorID: &claims.UserID, Subject: userID})
+	return
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new
+// refresh token. Each refresh token can be used once; if a used one is
+// presented again the session is revoked, logging out whoever holds it.
+func (b *bankService) RefreshToken(ctx context.Context, refreshToken string) (tokens LoginResponse, err error) {
+	nextToken, next, err := newRefreshToken("", b.refreshTokenTTL)
+	if err != nil {
+		return
+	}
+
+	sess, err := b.store.RotateRefreshToken(ctx, hashToken(refreshToken), next)
+	if err != nil {
+		switch err {
+		case db.ErrRefreshTokenReused:
+			b.logger.Warnf("Refresh token reused, revoked the session it belongs to")
+			err = ErrUnauthorized
+		case db.ErrRefreshTokenNotExist, db.ErrRefreshTokenExpired, db.ErrSessionRevoked:
+			err = ErrUnauthorized
+		}
+		return
+	}
+
+	tokens.AccessToken, tokens.AccessTokenExpiresAt, err = generateJWT(sess.UserID, sess.Role, sess.ID)
 	if err != nil {
 		return
 	}
+	tokens.RefreshToken = nextToken
+	tokens.RefreshTokenExpiresAt = next.ExpiresAt
+	return
+}
+
+// Logout revokes the caller's session and the access token used for the call.
+func (b *bankService) Logout(ctx context.Context, claims *Claims) (err error) {
+	b.logger.Infof("Logging out user: %v from session: %v\n", claims.UserID, claims.SessionID)
+
+	err = b.store.RevokeSession(ctx, claims.SessionID, claims.UserID)
+	if err != nil {
+		return
+	}
+
+	err = b.store.RevokeAccessToken(ctx, claims.Id, time.Unix(claims.ExpiresAt, 0))
+	return
+}
+
+const (
+	minPasswordLength = 8
+	// bcrypt only uses the first 72 bytes of a password
+	maxPasswordLength = 72
+)
+
+// validatePassword enforces the password policy: 8 to 72 characters with at
+// least one lower case letter, upper case letter, digit and symbol.
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return fmt.Errorf("%w: password must be between %v and %v characters long", ErrWeakPassword, minPasswordLength, maxPasswordLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	if !lower || !upper || !digit || !symbol {
+		return fmt.Errorf("%w: password must contain a lower case letter, an upper case letter, a digit and a symbol", ErrWeakPassword)
+	}
+	return nil
+}
+
+// ChangePassword replaces the caller's password. The caller stays logged in,
+// all of their other sessions are revoked.
+func (b *bankService) ChangePassword(ctx context.Context, claims *Claims, req ChangePasswordRequest) (err error) {
+	b.logger.Infof("Changing the password of user: %v\n", claims.UserID)
+
+	if req.OldPassword == req.NewPassword {
+		err = fmt.Errorf("%w: new password must be different from the current password", ErrWeakPassword)
+		return
+	}
+	if err = validatePassword(req.NewPassword); err != nil {
+		return
+	}
+
+	err = b.store.ChangePassword(ctx, claims.UserID, claims.SessionID, req.OldPassword, req.NewPassword)
+	if err == db.ErrUserNotExist {
+		err = ErrIncorrectPassword
+	}
+	return
+}
+
+// ForgotPassword sends a single use password reset token to the user with the
+// given email. To not reveal which emails have an account, an unknown email
+// is not an error.
+func (b *bankService) ForgotPassword(ctx context.Context, email string) (err error) {
+	token, err := randomToken()
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	t := db.PasswordResetToken{
+		TokenHash: hashToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(b.passwordResetTTL),
+	}
+
+	err = b.store.CreatePasswordResetToken(ctx, email, t)
+	if err != nil {
+		if err == db.ErrUserNotExist {
+			b.logger.Infof("Password reset requested for unknown email: %v\n", email)
+			err = nil
+		}
+		return
+	}
+
+	err = b.notifier.Notify(ctx, notify.Notification{
+		To:      email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use this token to reset your password: %v\nThe token is valid until %v.",
+			token, t.ExpiresAt.Format(time.RFC1123)),
+	})
+	return
+}
+
+// ResetPassword sets a new password using a token sent by ForgotPassword and
+// logs the user out everywhere.
+func (b *bankService) ResetPassword(ctx context.Context, req ResetPasswordRequest) (err error) {
+	if err = validatePassword(req.NewPassword); err != nil {
+		return
+	}
+
+	err = b.store.ResetPassword(ctx, hashToken(req.Token), req.NewPassword)
+	if err == db.ErrPasswordResetTokenNotExist || err == db.ErrPasswordResetTokenExpired {
+		err = ErrInvalidResetToken
+	}
 	return
 }
 
@@ -95,13 +507,23 @@ func (b *bankService) CreateAccount(ctx context.Context, accReq CreateAccountReq
 		Email:       accReq.Email,
 		PhoneNumber: accReq.PhoneNumber,
 		Password:    uuidgen.New(),
-		Type:        "customer",
+		Type:        RoleCustomer,
+	}
+
+	cur, err := validateCurrency(accReq.Currency)
+	if err != nil {
+		return
 	}
 
 	acc := db.Account{
-		ID:      uuidgen.New(),
-		Balance: 0.0,
-		UserID:  u.ID,
+		ID:       uuidgen.New(),
+		Balance:  money.New(0, cur.Digits),
+		UserID:   u.ID,
+		Type:     accReq.AccountType,
+		Currency: cur.Code,
+	}
+	if acc.Type == "" {
+		acc.Type = AccountTypeSavings
 	}
 
 	// Save the user in the bank
@@ -117,15 +539,51 @@ func (b *bankService) CreateAccount(ctx context.Context, accReq CreateAccountReq
 
 	// Create the response
 	accRes = CreateAccountResponse{
-		Email:     u.Email,
-		Password:  u.Password,
-		AccountID: acc.ID,
+		Email:       u.Email,
+		Password:    u.Password,
+		AccountID:   acc.ID,
+		AccountType: acc.Type,
+		Currency:    acc.Currency,
 	}
 
 	b.logger.Infof("Created account with details: %v. Opening balance: %v\n", accRes, acc.Balance)
 	return
 }
 
+// OpenAccount opens another account for an existing customer.
+func (b *bankService) OpenAccount(ctx context.Context, customerID string, req OpenAccountRequest) (acc db.Account, err error) {
+	b.logger.Infof("Opening a %v account for customer: %v\n", req.AccountType, customerID)
+
+	cur, err := validateCurrency(req.Currency)
+	if err != nil {
+		return
+	}
+
+	acc = db.Account{
+		ID:       uuidgen.New(),
+		Balance:  money.New(0, cur.Digits),
+		UserID:   customerID,
+		Type:     req.AccountType,
+		Currency: cur.Code,
+	}
+
+	err = b.store.OpenAccount(ctx, customerID, acc)
+	if err != nil {
+		acc = db.Account{}
+		return
+	}
+
+	b.logger.Infof("Opened account: %v for customer: %v\n", acc.ID, customerID)
+	return
+}
+
+// GetUserAccounts lists the accounts of a customer.
+func (b *bankService) GetUserAccounts(ctx context.Context, userID string) (accounts []db.Account, err error) {
+	b.logger.Infof("Getting the accounts of user: %v\n", userID)
+	accounts, err = b.store.GetUserAccounts(ctx, userID)
+	return
+}
+
 func (b *bankService) GetAccountList(ctx context.Context) (accounts []db.UserAccountDetails, err error) {
 	b.logger.Info("Getting the list of accounts in the bank")
 	accounts, err = b.store.GetAccountList(ctx)
@@ -138,34 +596,148 @@ func (b *bankService) GetAccountDetails(ctx context.Context, accId, userID strin
 	return
 }
 
-func (b *bankService) DepositAmount(ctx context.Context, accId, userID string, amount float32) (err error) {
+// maxAmount is the largest amount a single operation accepts. Amounts are
+// exact 64-bit coefficients, so larger ones could overflow once added to a
+// balance.
+var maxAmount = money.New(1000000000000, 0)
+
+// validateAmount checks that amount is a positive value in the account
+// currency, not above maxAmount, and returns it with the currency's number of
+// fraction digits.
+func validateAmount(amount money.Amount, cur money.Currency) (money.Amount, error) {
+	if amount.Sign() <= 0 {
+		return amount, fmt.Errorf("%w: amount must be greater than zero", ErrInvalidAmount)
+	}
+
+	if err := cur.Check(amount); err != nil {
+		return amount, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+
+	amount, err := cur.Normalize(amount)
+	if err != nil {
+		return amount, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+
+	if amount.Cmp(maxAmount) > 0 {
+		return amount, fmt.Errorf("%w: amount must not exceed %v", ErrInvalidAmount, maxAmount)
+	}
+	return amount, nil
+}
+
+func (b *bankService) DepositAmount(ctx context.Context, accId, userID string, amount money.Amount, currency string) (err error) {
 	fmt.Printf("Depositing amount: %v in account: %v\n", amount, accId)
 
-	err = b.store.DepositAmount(ctx, accId, userID, amount)
+	cur, err := b.accountCurrency(ctx, accId, userID, currency)
+	if err != nil {
+		return
+	}
+
+	amount, err = validateAmount(amount, cur)
+	if err != nil {
+		return
+	}
+
+	fees, err := b.store.DepositAmount(ctx, accId, userID, amount)
 	if err != nil {
 		return
 	}
 
+	b.logFees(accId, fees)
 	return
 }
 
-func (b *bankService) WithdrawAmount(ctx context.Context, accId, userID string, amount float32) (err error) {
+func (b *bankService) WithdrawAmount(ctx context.Context, accId, userID string, amount money.Amount, currency string) (err error) {
 	fmt.Printf("Withdrawing amount: %v from account: %v\n", amount, accId)
 
-	err = b.store.WithdrawAmount(ctx, accId, userID, amount)
+	cur, err := b.accountCurrency(ctx, accId, userID, currency)
 	if err != nil {
 		return
 	}
+
+	amount, err = validateAmount(amount, cur)
+	if err != nil {
+		return
+	}
+
+	fees, err := b.store.WithdrawAmount(ctx, accId, userID, amount)
+	if err != nil {
+		return
+	}
+
+	b.logFees(accId, fees)
 	return
 }
 
-func (b *bankService) GetTransactionDetails(ctx context.Context, accId, userID, startDate, endDate string) (transactions []db.Transaction, err error) {
-	fmt.Printf("Getting transactions details for account: %v, from %v to %v\n", accId, startDate, endDate)
-	allTransactions, err := b.store.GetTransactions(ctx, accId, userID)
+// TransferAmount moves amount, in the currency of the account, to another
+// account. The payee gets it exchanged into the currency of its account.
+func (b *bankService) TransferAmount(ctx context.Context, accId, userID, toAccId string, amount money.Amount, currency string) (transfer db.Transfer, err error) {
+	fmt.Printf("Transferring amount: %v from account: %v to account: %v\n", amount, accId, toAccId)
+
+	if accId == toAccId {
+		err = ErrSameAccount
+		return
+	}
+
+	cur, err := b.accountCurrency(ctx, accId, userID, currency)
+	if err != nil {
+		return
+	}
+
+	amount, err = validateAmount(amount, cur)
 	if err != nil {
 		return
 	}
 
+	transfer, err = b.store.TransferAmount(ctx, accId, toAccId, userID, amount)
+	return
+}
+
+// StartIdempotentRequest reserves the idempotency key for a new request.
+// If the key was already used for the same request, replay is true and saved
+// holds the response to send again.
+func (b *bankService) StartIdempotentRequest(ctx context.Context, userID, key, fingerprint string) (saved db.IdempotencyKey, replay bool, err error) {
+	k := db.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+	}
+
+	saved, reserved, err := b.store.ReserveIdempotencyKey(ctx, k, b.idempotencyTTL)
+	if err == db.ErrIdempotencyKeyNotExist {
+		// the expired key is being reserved again by a concurrent request
+		err = ErrIdempotencyKeyInProgress
+		return
+	}
+	if err != nil || reserved {
+		return
+	}
+
+	if saved.Fingerprint != fingerprint {
+		err = ErrIdempotencyKeyConflict
+		return
+	}
+	if saved.StatusCode == nil {
+		err = ErrIdempotencyKeyInProgress
+		return
+	}
+
+	b.logger.Infof("Replaying response for idempotency key: %v of user: %v\n", key, userID)
+	replay = true
+	return
+}
+
+// CompleteIdempotentRequest stores the response of a request started with
+// StartIdempotentRequest. Server errors are not stored, so that a retry with
+// the same key is executed again.
+func (b *bankService) CompleteIdempotentRequest(ctx context.Context, userID, key string, statusCode int, body []byte) (err error) {
+	if statusCode >= http.StatusInternalServerError {
+		return b.store.DeleteIdempotencyKey(ctx, userID, key)
+	}
+	return b.store.SaveIdempotentResponse(ctx, userID, key, statusCode, body)
+}
+
+func (b *bankService) GetTransactionDetails(ctx context.Context, accId, userID, startDate, endDate string) (transactions []db.Transaction, err error) {
+	fmt.Printf("Getting transactions details for account: %v, from %v to %v\n", accId, startDate, endDate)
 	startDateTime, err := time.Parse("2006-01-02", startDate)
 	if err != nil {
 		err = fmt.Errorf("error parsing startdate: %v", startDate)
@@ -177,30 +749,12 @@ func (b *bankService) GetTransactionDetails(ctx context.Context, accId, userID,
 		return
 	}
 
-	transactions = make([]db.Transaction, 0)
-	var tDateTimeAsNeeded time.Time
-	var errFormat1, errFormat2, errFormat3 error
-	for _, t := range allTransactions {
-		tDateTimeAsNeeded, errFormat1 = time.Parse("2006-01-02T15:04:05.000Z", t.CreatedAt)
-		if errFormat1 != nil {
-			tDateTimeAsNeeded, errFormat2 = time.Parse("2006-01-02T15:04:05.00Z", t.CreatedAt)
-
-			if errFormat2 != nil {
-				tDateTimeAsNeeded, errFormat3 = time.Parse("2006-01-02T15:04:05.0Z", t.CreatedAt)
-			}
-
-			if errFormat3 != nil {
-				err = fmt.Errorf("error parsing transaction date: %v", t.CreatedAt)
-				return nil, err
-			}
-		}
-
-		// Transaction time must be greater than or equal to startdate and
-		// less than or equal to end date
-		if (tDateTimeAsNeeded == startDateTime || tDateTimeAsNeeded.After(startDateTime)) &&
-			(tDateTimeAsNeeded == endDateTime || tDateTimeAsNeeded.Before(endDateTime)) {
-			transactions = append(transactions, t)
-		}
-	}
+	// the history runs from the start date up to the end date, both at
+	// midnight, the timestamps of transactions being in microseconds
+	page, err := b.store.GetTransactions(ctx, accId, userID, db.TransactionFilter{
+		From: startDateTime,
+		To:   endDateTime.Add(time.Microsecond),
+	})
+	transactions = page.Transactions
 	return
 }
@@ -3,7 +3,11 @@ package bank
 import (
 	"context"
 	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	uuidgen "github.com/pborman/uuid"
 	"github.com/stretchr/testify/mock"
@@ -13,16 +17,25 @@ import (
 	"example.com/banking/app"
 	"example.com/banking/db"
 	"example.com/banking/db/mocks"
+	"example.com/banking/money"
+	"example.com/banking/notify"
+	notifymocks "example.com/banking/notify/mocks"
+	"example.com/banking/totp"
 )
 
 func init() {
 	app.InitLogger()
+
+	if err := LoadSigningKeys("", "test-secret", defaultKeyID, 5*time.Minute); err != nil {
+		panic(err)
+	}
 }
 
 type BankServiceTestSuite struct {
 	suite.Suite
 	logger      *zap.SugaredLogger
 	storer      *mocks.Storer
+	notifier    *notifymocks.Notifier
 	bankService Service
 }
 
@@ -35,7 +48,8 @@ func (bsts *BankServiceTestSuite) SetupTest() {
 	bsts.T().Logf("SetupTest - Creating the mock db instance and the bank service")
 
 	bsts.storer = mocks.NewStorer(bsts.T())
-	bsts.bankService = NewBankService(bsts.storer, bsts.logger)
+	bsts.notifier = notifymocks.NewNotifier(bsts.T())
+	bsts.bankService = NewBankService(bsts.storer, bsts.logger, bsts.notifier)
 }
 
 func TestBankServiceTestSuite(t *testing.T) {
@@ -65,7 +79,9 @@ func (bsts *BankServiceTestSuite) Test_bankService_CreateAccount() {
 			},
 			wantErr: false,
 			prepare: func(a args, s *mocks.Storer) {
-				s.On("CreateAccount", context.TODO(), mock.AnythingOfType("db.User"), mock.AnythingOfType("db.Account")).Return(nil).Once()
+				s.On("CreateAccount", context.TODO(), mock.AnythingOfType("db.User"), mock.MatchedBy(func(acc db.Account) bool {
+					return acc.Type == AccountTypeSavings
+				})).Return(nil).Once()
 			},
 		},
 		// negative test
@@ -103,6 +119,22 @@ func (bsts *BankServiceTestSuite) Test_bankService_CreateAccount() {
 	}
 }
 
+func (bsts *BankServiceTestSuite) Test_bankService_OpenAccount() {
+	ctx := context.TODO()
+
+	bsts.storer.On("OpenAccount", ctx, "2", mock.MatchedBy(func(acc db.Account) bool {
+		return acc.ID != "" && acc.UserID == "2" && acc.Type == AccountTypeCurrent && acc.Balance.IsZero()
+	})).Return(nil).Once()
+	acc, err := bsts.bankService.OpenAccount(ctx, "2", OpenAccountRequest{AccountType: AccountTypeCurrent})
+	bsts.NoError(err)
+	bsts.Equal(AccountTypeCurrent, acc.Type)
+
+	bsts.storer.On("OpenAccount", ctx, "3", mock.AnythingOfType("db.Account")).Return(db.ErrUserNotExist).Once()
+	acc, err = bsts.bankService.OpenAccount(ctx, "3", OpenAccountRequest{AccountType: AccountTypeSavings})
+	bsts.Equal(db.ErrUserNotExist, err)
+	bsts.Empty(acc.ID)
+}
+
 func (bsts *BankServiceTestSuite) Test_bankService_GetAccountList() {
 	type args struct {
 		ctx context.Context
@@ -209,3 +241,1205 @@ func (bsts *BankServiceTestSuite) Test_bankService_GetAccountDetails() {
 		})
 	}
 }
+
+func (bsts *BankServiceTestSuite) Test_bankService_TransferAmount() {
+	type args struct {
+		ctx      context.Context
+		accId    string
+		userID   string
+		toAccId  string
+		amount   money.Amount
+		currency string
+	}
+	fromAccId, toAccId := uuidgen.New(), uuidgen.New()
+	inrAccount := db.UserAccountDetails{Account: db.Account{ID: fromAccId, Currency: "INR"}}
+	tests := []struct {
+		name         string
+		args         args
+		wantTransfer db.Transfer
+		wantErr      error
+		prepare      func(args, *mocks.Storer)
+	}{
+		// positive test
+		{
+			name:         "positiveTest",
+			args:         args{context.TODO(), fromAccId, "1", toAccId, money.New(105, 1), "inr"},
+			wantTransfer: db.Transfer{FromAccountID: fromAccId, ToAccountID: toAccId},
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetAccountDetails", a.ctx, a.accId, a.userID).Return(inrAccount, nil).Once()
+				s.On("TransferAmount", a.ctx, a.accId, a.toAccId, a.userID, money.New(1050, 2)).
+					Return(db.Transfer{FromAccountID: fromAccId, ToAccountID: toAccId}, nil).Once()
+			},
+		},
+		// negative tests
+		{
+			name:    "sameAccount",
+			args:    args{context.TODO(), fromAccId, "1", fromAccId, money.New(1, 0), ""},
+			wantErr: ErrSameAccount,
+			prepare: func(a args, s *mocks.Storer) {},
+		},
+		{
+			name:    "negativeAmount",
+			args:    args{context.TODO(), fromAccId, "1", toAccId, money.New(-1, 0), ""},
+			wantErr: ErrInvalidAmount,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetAccountDetails", a.ctx, a.accId, a.userID).Return(inrAccount, nil).Once()
+			},
+		},
+		{
+			name:    "tooManyFractionDigits",
+			args:    args{context.TODO(), fromAccId, "1", toAccId, money.New(1005, 3), ""},
+			wantErr: ErrInvalidAmount,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetAccountDetails", a.ctx, a.accId, a.userID).Return(inrAccount, nil).Once()
+			},
+		},
+		{
+			name:    "amountTooLarge",
+			args:    args{context.TODO(), fromAccId, "1", toAccId, money.New(9000000000000000000, 2), ""},
+			wantErr: ErrInvalidAmount,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetAccountDetails", a.ctx, a.accId, a.userID).Return(inrAccount, nil).Once()
+			},
+		},
+		{
+			name:    "noFractionDigitsForYen",
+			args:    args{context.TODO(), fromAccId, "1", toAccId, money.New(105, 1), ""},
+			wantErr: ErrInvalidAmount,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetAccountDetails", a.ctx, a.accId, a.userID).Return(db.UserAccountDetails{Account: db.Account{Currency: "JPY"}}, nil).Once()
+			},
+		},
+		{
+			name:    "currencyMismatch",
+			args:    args{context.TODO(), fromAccId, "1", toAccId, money.New(1, 0), "USD"},
+			wantErr: ErrCurrencyMismatch,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetAccountDetails", a.ctx, a.accId, a.userID).Return(inrAccount, nil).Once()
+			},
+		},
+		{
+			name:    "insufficientFunds",
+			args:    args{context.TODO(), fromAccId, "1", toAccId, money.New(1, 0), ""},
+			wantErr: db.ErrInsufficientFunds,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetAccountDetails", a.ctx, a.accId, a.userID).Return(inrAccount, nil).Once()
+				s.On("TransferAmount", a.ctx, a.accId, a.toAccId, a.userID, money.New(100, 2)).
+					Return(db.Transfer{}, db.ErrInsufficientFunds).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		bsts.T().Run(tt.name, func(t *testing.T) {
+			tt.prepare(tt.args, bsts.storer)
+
+			gotTransfer, err := bsts.bankService.TransferAmount(tt.args.ctx, tt.args.accId, tt.args.userID, tt.args.toAccId, tt.args.amount, tt.args.currency)
+
+			if tt.wantErr != nil {
+				bsts.ErrorIs(err, tt.wantErr)
+			} else {
+				bsts.ErrorIs(err, nil)
+				bsts.Equal(tt.wantTransfer, gotTransfer)
+			}
+		})
+	}
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_StartIdempotentRequest() {
+	type args struct {
+		ctx         context.Context
+		userID      string
+		key         string
+		fingerprint string
+	}
+	statusOK := http.StatusOK
+	tests := []struct {
+		name       string
+		args       args
+		wantReplay bool
+		wantErr    error
+		prepare    func(args, *mocks.Storer)
+	}{
+		// positive tests
+		{
+			name: "newKey",
+			args: args{context.TODO(), "1", uuidgen.New(), "abc"},
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("ReserveIdempotencyKey", a.ctx, mock.AnythingOfType("db.IdempotencyKey"), mock.AnythingOfType("time.Duration")).
+					Return(db.IdempotencyKey{}, true, nil).Once()
+			},
+		},
+		{
+			name:       "replay",
+			args:       args{context.TODO(), "1", uuidgen.New(), "abc"},
+			wantReplay: true,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("ReserveIdempotencyKey", a.ctx, mock.AnythingOfType("db.IdempotencyKey"), mock.AnythingOfType("time.Duration")).
+					Return(db.IdempotencyKey{Fingerprint: "abc", StatusCode: &statusOK}, false, nil).Once()
+			},
+		},
+		// negative tests
+		{
+			name:    "differentPayload",
+			args:    args{context.TODO(), "1", uuidgen.New(), "abc"},
+			wantErr: ErrIdempotencyKeyConflict,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("ReserveIdempotencyKey", a.ctx, mock.AnythingOfType("db.IdempotencyKey"), mock.AnythingOfType("time.Duration")).
+					Return(db.IdempotencyKey{Fingerprint: "def", StatusCode: &statusOK}, false, nil).Once()
+			},
+		},
+		{
+			name:    "inProgress",
+			args:    args{context.TODO(), "1", uuidgen.New(), "abc"},
+			wantErr: ErrIdempotencyKeyInProgress,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("ReserveIdempotencyKey", a.ctx, mock.AnythingOfType("db.IdempotencyKey"), mock.AnythingOfType("time.Duration")).
+					Return(db.IdempotencyKey{Fingerprint: "abc"}, false, nil).Once()
+			},
+		},
+		{
+			name:    "expiredKeyReservedConcurrently",
+			args:    args{context.TODO(), "1", uuidgen.New(), "abc"},
+			wantErr: ErrIdempotencyKeyInProgress,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("ReserveIdempotencyKey", a.ctx, mock.AnythingOfType("db.IdempotencyKey"), mock.AnythingOfType("time.Duration")).
+					Return(db.IdempotencyKey{}, false, db.ErrIdempotencyKeyNotExist).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		bsts.T().Run(tt.name, func(t *testing.T) {
+			tt.prepare(tt.args, bsts.storer)
+
+			_, replay, err := bsts.bankService.StartIdempotentRequest(tt.args.ctx, tt.args.userID, tt.args.key, tt.args.fingerprint)
+
+			if tt.wantErr != nil {
+				bsts.ErrorIs(err, tt.wantErr)
+			} else {
+				bsts.ErrorIs(err, nil)
+			}
+			bsts.Equal(tt.wantReplay, replay)
+		})
+	}
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_RefreshToken() {
+	type args struct {
+		ctx          context.Context
+		refreshToken string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+		prepare func(args, *mocks.Storer)
+	}{
+		// positive test
+		{
+			name: "rotated",
+			args: args{context.TODO(), "abc"},
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("RotateRefreshToken", a.ctx, hashToken(a.refreshToken), mock.AnythingOfType("db.RefreshToken")).
+					Return(db.Session{ID: uuidgen.New(), UserID: "1", Role: RoleCustomer}, nil).Once()
+			},
+		},
+		// negative tests
+		{
+			name:    "reused",
+			args:    args{context.TODO(), "abc"},
+			wantErr: ErrUnauthorized,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("RotateRefreshToken", a.ctx, hashToken(a.refreshToken), mock.AnythingOfType("db.RefreshToken")).
+					Return(db.Session{}, db.ErrRefreshTokenReused).Once()
+			},
+		},
+		{
+			name:    "expired",
+			args:    args{context.TODO(), "abc"},
+			wantErr: ErrUnauthorized,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("RotateRefreshToken", a.ctx, hashToken(a.refreshToken), mock.AnythingOfType("db.RefreshToken")).
+					Return(db.Session{}, db.ErrRefreshTokenExpired).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		bsts.T().Run(tt.name, func(t *testing.T) {
+			tt.prepare(tt.args, bsts.storer)
+
+			tokens, err := bsts.bankService.RefreshToken(tt.args.ctx, tt.args.refreshToken)
+
+			if tt.wantErr != nil {
+				bsts.ErrorIs(err, tt.wantErr)
+				return
+			}
+			bsts.NoError(err)
+			bsts.NotEqual(tt.args.refreshToken, tokens.RefreshToken)
+
+			claims, err := parseJWT(tokens.AccessToken)
+			bsts.NoError(err)
+			bsts.Equal("1", claims.UserID)
+			bsts.Equal(RoleCustomer, claims.Role)
+			bsts.NotEmpty(claims.Id)
+		})
+	}
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_ValidateJWT() {
+	token, _, err := generateJWT("1", RoleCustomer, uuidgen.New())
+	bsts.Require().NoError(err)
+	claims, err := parseJWT(token)
+	bsts.Require().NoError(err)
+
+	bsts.storer.On("IsTokenRevoked", context.TODO(), claims.Id, claims.SessionID).Return(false, nil).Once()
+	_, err = bsts.bankService.ValidateJWT(context.TODO(), token)
+	bsts.NoError(err)
+
+	bsts.storer.On("IsTokenRevoked", context.TODO(), claims.Id, claims.SessionID).Return(true, nil).Once()
+	_, err = bsts.bankService.ValidateJWT(context.TODO(), token)
+	bsts.ErrorIs(err, ErrUnauthorized)
+
+	// the revocation check is skipped for tokens that are not valid anyway
+	_, err = bsts.bankService.ValidateJWT(context.TODO(), token+"x")
+	bsts.ErrorIs(err, ErrUnauthorized)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_Logout() {
+	claims := &Claims{UserID: "1", SessionID: uuidgen.New()}
+	claims.Id = uuidgen.New()
+	claims.ExpiresAt = time.Now().Add(time.Minute).Unix()
+
+	bsts.storer.On("RevokeSession", context.TODO(), claims.SessionID, claims.UserID).Return(nil).Once()
+	bsts.storer.On("RevokeAccessToken", context.TODO(), claims.Id, time.Unix(claims.ExpiresAt, 0)).Return(nil).Once()
+
+	bsts.NoError(bsts.bankService.Logout(context.TODO(), claims))
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_ChangePassword() {
+	claims := &Claims{UserID: "1", SessionID: uuidgen.New()}
+	type args struct {
+		ctx context.Context
+		req ChangePasswordRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+		prepare func(args, *mocks.Storer)
+	}{
+		// positive test
+		{
+			name: "changed",
+			args: args{context.TODO(), ChangePasswordRequest{OldPassword: "f3b2c1d0-old", NewPassword: "N3w-Passw0rd"}},
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("ChangePassword", a.ctx, claims.UserID, claims.SessionID, a.req.OldPassword, a.req.NewPassword).Return(nil).Once()
+			},
+		},
+		// negative tests
+		{
+			name:    "incorrectOldPassword",
+			args:    args{context.TODO(), ChangePasswordRequest{OldPassword: "wrong", NewPassword: "N3w-Passw0rd"}},
+			wantErr: ErrIncorrectPassword,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("ChangePassword", a.ctx, claims.UserID, claims.SessionID, a.req.OldPassword, a.req.NewPassword).Return(db.ErrUserNotExist).Once()
+			},
+		},
+		{
+			name:    "tooShort",
+			args:    args{context.TODO(), ChangePasswordRequest{OldPassword: "f3b2c1d0-old", NewPassword: "Sh0rt!"}},
+			wantErr: ErrWeakPassword,
+			prepare: func(a args, s *mocks.Storer) {},
+		},
+		{
+			name:    "noSymbol",
+			args:    args{context.TODO(), ChangePasswordRequest{OldPassword: "f3b2c1d0-old", NewPassword: "NewPassw0rd"}},
+			wantErr: ErrWeakPassword,
+			prepare: func(a args, s *mocks.Storer) {},
+		},
+		{
+			name:    "sameAsOld",
+			args:    args{context.TODO(), ChangePasswordRequest{OldPassword: "N3w-Passw0rd", NewPassword: "N3w-Passw0rd"}},
+			wantErr: ErrWeakPassword,
+			prepare: func(a args, s *mocks.Storer) {},
+		},
+	}
+	for _, tt := range tests {
+		bsts.T().Run(tt.name, func(t *testing.T) {
+			tt.prepare(tt.args, bsts.storer)
+
+			err := bsts.bankService.ChangePassword(tt.args.ctx, claims, tt.args.req)
+
+			if tt.wantErr != nil {
+				bsts.ErrorIs(err, tt.wantErr)
+			} else {
+				bsts.NoError(err)
+			}
+		})
+	}
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_ForgotPassword() {
+	var token db.PasswordResetToken
+	bsts.storer.On("CreatePasswordResetToken", context.TODO(), "abc@gmail.com", mock.AnythingOfType("db.PasswordResetToken")).
+		Run(func(args mock.Arguments) { token = args.Get(2).(db.PasswordResetToken) }).
+		Return(nil).Once()
+
+	var sent notify.Notification
+	bsts.notifier.On("Notify", context.TODO(), mock.AnythingOfType("notify.Notification")).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(notify.Notification) }).
+		Return(nil).Once()
+
+	bsts.NoError(bsts.bankService.ForgotPassword(context.TODO(), "abc@gmail.com"))
+	bsts.Equal("abc@gmail.com", sent.To)
+	bsts.NotContains(sent.Body, token.TokenHash, "only the hash of the token is stored")
+
+	// the reset token in the notification matches the stored hash
+	bsts.storer.On("ResetPassword", context.TODO(), token.TokenHash, "N3w-Passw0rd").Return(nil).Once()
+	var sentToken string
+	for _, field := range strings.Fields(sent.Body) {
+		if hashToken(field) == token.TokenHash {
+			sentToken = field
+		}
+	}
+	bsts.NoError(bsts.bankService.ResetPassword(context.TODO(), ResetPasswordRequest{Token: sentToken, NewPassword: "N3w-Passw0rd"}))
+
+	// unknown emails are not revealed
+	bsts.storer.On("CreatePasswordResetToken", context.TODO(), "unknown@gmail.com", mock.AnythingOfType("db.PasswordResetToken")).
+		Return(db.ErrUserNotExist).Once()
+	bsts.NoError(bsts.bankService.ForgotPassword(context.TODO(), "unknown@gmail.com"))
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_ResetPassword_InvalidToken() {
+	bsts.storer.On("ResetPassword", context.TODO(), hashToken("used"), "N3w-Passw0rd").Return(db.ErrPasswordResetTokenNotExist).Once()
+	bsts.storer.On("ResetPassword", context.TODO(), hashToken("expired"), "N3w-Passw0rd").Return(db.ErrPasswordResetTokenExpired).Once()
+
+	err := bsts.bankService.ResetPassword(context.TODO(), ResetPasswordRequest{Token: "used", NewPassword: "N3w-Passw0rd"})
+	bsts.ErrorIs(err, ErrInvalidResetToken)
+	err = bsts.bankService.ResetPassword(context.TODO(), ResetPasswordRequest{Token: "expired", NewPassword: "N3w-Passw0rd"})
+	bsts.ErrorIs(err, ErrInvalidResetToken)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_Login() {
+	bsts.bankService.(*bankService).loginPolicy = loginPolicy{maxFailures: 3, maxFailuresPerIP: 10, lockout: time.Minute, backoffBase: time.Second}
+
+	type args struct {
+		ctx context.Context
+		req LoginRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+		prepare func(args, *mocks.Storer)
+	}{
+		// positive test
+		{
+			name: "loggedIn",
+			args: args{context.TODO(), LoginRequest{Email: "abc@gmail.com", Password: "pass", IP: "10.0.0.1"}},
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetLoginAttempts", a.ctx, a.req.Email, a.req.IP).Return(nil, nil).Once()
+				s.On("GetUserByEmailAndPassword", a.ctx, a.req.Email, a.req.Password).Return(db.User{ID: "1", Type: RoleCustomer}, nil).Once()
+				s.On("GetMFA", a.ctx, "1").Return(db.MFA{}, db.ErrMFANotEnrolled).Once()
+				s.On("ResetLoginFailures", a.ctx, db.LoginAttemptEmail, a.req.Email).Return(nil).Once()
+				s.On("AddAuditEntry", a.ctx, mock.MatchedBy(func(e db.AuditEntry) bool { return e.Action == AuditLoginSucceeded })).Return(nil).Once()
+				s.On("CreateSession", a.ctx, mock.AnythingOfType("db.Session"), mock.AnythingOfType("db.RefreshToken")).Return(nil).Once()
+			},
+		},
+		{
+			name: "backoffExpired",
+			args: args{context.TODO(), LoginRequest{Email: "abc@gmail.com", Password: "pass", IP: "10.0.0.1"}},
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetLoginAttempts", a.ctx, a.req.Email, a.req.IP).
+					Return([]db.LoginAttempt{{Kind: db.LoginAttemptEmail, Failures: 2, LastFailureAt: time.Now().Add(-3 * time.Second)}}, nil).Once()
+				s.On("GetUserByEmailAndPassword", a.ctx, a.req.Email, a.req.Password).Return(db.User{ID: "1", Type: RoleCustomer}, nil).Once()
+				s.On("GetMFA", a.ctx, "1").Return(db.MFA{}, db.ErrMFANotEnrolled).Once()
+				s.On("ResetLoginFailures", a.ctx, db.LoginAttemptEmail, a.req.Email).Return(nil).Once()
+				s.On("AddAuditEntry", a.ctx, mock.AnythingOfType("db.AuditEntry")).Return(nil).Once()
+				s.On("CreateSession", a.ctx, mock.AnythingOfType("db.Session"), mock.AnythingOfType("db.RefreshToken")).Return(nil).Once()
+			},
+		},
+		// negative tests
+		{
+			name:    "wrongPassword",
+			args:    args{context.TODO(), LoginRequest{Email: "abc@gmail.com", Password: "wrong", IP: "10.0.0.1"}},
+			wantErr: ErrUnauthorized,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetLoginAttempts", a.ctx, a.req.Email, a.req.IP).Return(nil, nil).Once()
+				s.On("GetUserByEmailAndPassword", a.ctx, a.req.Email, a.req.Password).Return(db.User{}, db.ErrUserNotExist).Once()
+				s.On("RecordLoginFailure", a.ctx, db.LoginAttemptEmail, a.req.Email, mock.AnythingOfType("time.Time")).
+					Return(db.LoginAttempt{Failures: 1}, nil).Once()
+				s.On("RecordLoginFailure", a.ctx, db.LoginAttemptIP, a.req.IP, mock.AnythingOfType("time.Time")).
+					Return(db.LoginAttempt{Failures: 1}, nil).Once()
+				s.On("AddAuditEntry", a.ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+					return e.Action == AuditLoginFailed && e.IP == a.req.IP && e.Subject == a.req.Email
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:    "lockedOut",
+			args:    args{context.TODO(), LoginRequest{Email: "abc@gmail.com", Password: "pass", IP: "10.0.0.1"}},
+			wantErr: ErrTooManyLoginAttempts,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetLoginAttempts", a.ctx, a.req.Email, a.req.IP).
+					Return([]db.LoginAttempt{{Kind: db.LoginAttemptEmail, Failures: 3, LastFailureAt: time.Now().Add(-30 * time.Second)}}, nil).Once()
+				s.On("AddAuditEntry", a.ctx, mock.MatchedBy(func(e db.AuditEntry) bool { return e.Action == AuditLoginBlocked })).Return(nil).Once()
+			},
+		},
+		{
+			name:    "ipBackoff",
+			args:    args{context.TODO(), LoginRequest{Email: "other@gmail.com", Password: "pass", IP: "10.0.0.1"}},
+			wantErr: ErrTooManyLoginAttempts,
+			prepare: func(a args, s *mocks.Storer) {
+				s.On("GetLoginAttempts", a.ctx, a.req.Email, a.req.IP).
+					Return([]db.LoginAttempt{{Kind: db.LoginAttemptIP, Failures: 4, LastFailureAt: time.Now()}}, nil).Once()
+				s.On("AddAuditEntry", a.ctx, mock.AnythingOfType("db.AuditEntry")).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		bsts.T().Run(tt.name, func(t *testing.T) {
+			tt.prepare(tt.args, bsts.storer)
+
+			tokens, err := bsts.bankService.Login(tt.args.ctx, tt.args.req)
+
+			if tt.wantErr != nil {
+				bsts.ErrorIs(err, tt.wantErr)
+				return
+			}
+			bsts.NoError(err)
+			bsts.NotEmpty(tokens.AccessToken)
+			bsts.NotEmpty(tokens.RefreshToken)
+		})
+	}
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_UnlockUser() {
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+
+	bsts.storer.On("UnlockUser", context.TODO(), "2").Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", context.TODO(), mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditUserUnlocked && *e.ActorID == "1" && e.Subject == "2"
+	})).Return(nil).Once()
+	bsts.NoError(bsts.bankService.UnlockUser(context.TODO(), claims, "2"))
+
+	bsts.storer.On("UnlockUser", context.TODO(), "3").Return(db.ErrUserNotExist).Once()
+	bsts.ErrorIs(bsts.bankService.UnlockUser(context.TODO(), claims, "3"), db.ErrUserNotExist)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_SetUserRole() {
+	claims := &Claims{UserID: "1", Role: RoleBranchManager}
+
+	err := bsts.bankService.SetUserRole(context.TODO(), claims, "2", SetUserRoleRequest{Role: "superuser"})
+	bsts.ErrorIs(err, ErrInvalidRole)
+
+	bsts.storer.On("SetUserRole", context.TODO(), "2", RoleTeller).Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", context.TODO(), mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditUserRoleChanged && *e.ActorID == "1" && e.Subject == "2" && e.Details == "role: teller"
+	})).Return(nil).Once()
+	bsts.NoError(bsts.bankService.SetUserRole(context.TODO(), claims, "2", SetUserRoleRequest{Role: " teller "}))
+
+	// from the command line there is no actor
+	bsts.storer.On("SetUserRole", context.TODO(), "3", RoleBranchManager).Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", context.TODO(), mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditUserRoleChanged && e.ActorID == nil && e.Subject == "3"
+	})).Return(nil).Once()
+	bsts.NoError(bsts.bankService.SetUserRole(context.TODO(), nil, "3", SetUserRoleRequest{Role: RoleBranchManager}))
+
+	bsts.storer.On("SetUserRole", context.TODO(), "4", RoleTeller).Return(db.ErrUserNotExist).Once()
+	bsts.ErrorIs(bsts.bankService.SetUserRole(context.TODO(), claims, "4", SetUserRoleRequest{Role: RoleTeller}), db.ErrUserNotExist)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_HasPermission() {
+	bsts.storer.On("RoleHasPermission", context.TODO(), RoleTeller, PermAccountsCreate).Return(true, nil).Once()
+	allowed, err := bsts.bankService.HasPermission(context.TODO(), RoleTeller, PermAccountsCreate)
+	bsts.NoError(err)
+	bsts.True(allowed)
+
+	// tokens without a role are never granted anything
+	allowed, err = bsts.bankService.HasPermission(context.TODO(), "", PermAccountsCreate)
+	bsts.NoError(err)
+	bsts.False(allowed)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_Login_MFA() {
+	bsts.bankService.(*bankService).mfaRoles = []string{RoleAccountant}
+	bsts.bankService.(*bankService).mfaChallengeTTL = time.Minute
+	ctx := context.TODO()
+	login := LoginRequest{Email: "account@bank.com", Password: "josh@123", IP: "10.0.0.1"}
+	accountant := db.User{ID: "1", Type: RoleAccountant}
+
+	// an accountant cannot log in before an administrator provisioned the
+	// enrolment, and is never handed a secret
+	bsts.storer.On("GetLoginAttempts", ctx, login.Email, login.IP).Return(nil, nil)
+	bsts.storer.On("GetUserByEmailAndPassword", ctx, login.Email, login.Password).Return(accountant, nil)
+	bsts.storer.On("GetMFA", ctx, "1").Return(db.MFA{}, db.ErrMFANotEnrolled).Once()
+
+	_, err := bsts.bankService.Login(ctx, login)
+	bsts.ErrorIs(err, ErrMFANotProvisioned)
+
+	// a branch manager provisions it
+	var secret string
+	manager := &Claims{UserID: "9", Role: RoleBranchManager}
+	bsts.storer.On("GetUserByID", ctx, "1").Return(accountant, nil).Once()
+	bsts.storer.On("SaveMFASecret", ctx, "1", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { secret = args.String(2) }).
+		Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditMFAProvisioned && e.Subject == "1" && *e.ActorID == "9"
+	})).Return(nil).Once()
+
+	enrolment, err := bsts.bankService.ProvisionMFA(ctx, manager, "1")
+	bsts.Require().NoError(err)
+	bsts.Equal(secret, enrolment.Secret)
+
+	// or it is provisioned from the command line, without an actor
+	bsts.storer.On("GetUserByID", ctx, "1").Return(accountant, nil).Once()
+	bsts.storer.On("SaveMFASecret", ctx, "1", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { secret = args.String(2) }).
+		Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditMFAProvisioned && e.Subject == "1" && e.ActorID == nil
+	})).Return(nil).Once()
+
+	enrolment, err = bsts.bankService.ProvisionMFA(ctx, nil, "1")
+	bsts.Require().NoError(err)
+	bsts.Equal(secret, enrolment.Secret)
+
+	// the login then asks for a code of the pending enrolment
+	pending := db.MFA{UserID: "1", Secret: secret}
+	bsts.storer.On("GetMFA", ctx, "1").Return(pending, nil).Once()
+
+	tokens, err := bsts.bankService.Login(ctx, login)
+	bsts.Require().NoError(err)
+	bsts.Empty(tokens.AccessToken)
+	bsts.Require().NotNil(tokens.MFA)
+	bsts.NotEmpty(tokens.MFA.MFAToken)
+
+	// the challenge token is not an access token
+	_, err = bsts.bankService.ValidateJWT(ctx, tokens.MFA.MFAToken)
+	bsts.ErrorIs(err, ErrUnauthorized)
+
+	// a wrong code counts as a failed login
+	bsts.storer.On("GetMFA", ctx, "1").Return(pending, nil).Once()
+	bsts.storer.On("RecordLoginFailure", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return(db.LoginAttempt{Failures: 1}, nil).Twice()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool { return e.Action == AuditLoginFailed })).Return(nil).Once()
+
+	_, err = bsts.bankService.LoginMFA(ctx, MFALoginRequest{MFAToken: tokens.MFA.MFAToken, Code: "000000", IP: login.IP})
+	bsts.ErrorIs(err, ErrUnauthorized)
+
+	// the first valid code confirms the enrolment and completes the login
+	counter := totp.Counter(time.Now())
+	code, err := totp.Code(secret, counter)
+	bsts.Require().NoError(err)
+	var hashes []string
+	bsts.storer.On("GetMFA", ctx, "1").Return(pending, nil).Once()
+	bsts.storer.On("ConfirmMFA", ctx, "1", counter, mock.AnythingOfType("[]string")).
+		Run(func(args mock.Arguments) { hashes = args.Get(3).([]string) }).
+		Return(nil).Once()
+	bsts.storer.On("ResetLoginFailures", ctx, db.LoginAttemptEmail, login.Email).Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool { return e.Action == AuditLoginSucceeded })).Return(nil).Once()
+	bsts.storer.On("CreateSession", ctx, mock.AnythingOfType("db.Session"), mock.AnythingOfType("db.RefreshToken")).Return(nil).Once()
+
+	full, err := bsts.bankService.LoginMFA(ctx, MFALoginRequest{MFAToken: tokens.MFA.MFAToken, Code: code, IP: login.IP})
+	bsts.Require().NoError(err)
+	bsts.NotEmpty(full.AccessToken)
+	bsts.Len(full.RecoveryCodes, recoveryCodeCount)
+	bsts.Require().Len(hashes, recoveryCodeCount)
+	bsts.Equal(hashes[0], hashRecoveryCode(strings.ToLower(full.RecoveryCodes[0])))
+
+	// the access token cannot be used as a challenge token
+	_, err = bsts.bankService.LoginMFA(ctx, MFALoginRequest{MFAToken: full.AccessToken, Code: code})
+	bsts.ErrorIs(err, ErrUnauthorized)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_LoginMFA_RecoveryCode() {
+	ctx := context.TODO()
+	confirmed := time.Now()
+	m := db.MFA{UserID: "2", Secret: "JBSWY3DPEHPK3PXP", ConfirmedAt: &confirmed}
+	token, _, err := generateMFAToken(db.User{ID: "2", Type: RoleCustomer}, "abc@gmail.com", time.Minute)
+	bsts.Require().NoError(err)
+
+	bsts.storer.On("GetLoginAttempts", ctx, "abc@gmail.com", "").Return(nil, nil)
+	bsts.storer.On("GetMFA", ctx, "2").Return(m, nil)
+	bsts.storer.On("UseRecoveryCode", ctx, "2", hashRecoveryCode("ABCD-EFGH")).Return(nil).Once()
+	bsts.storer.On("ResetLoginFailures", ctx, db.LoginAttemptEmail, "abc@gmail.com").Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.AnythingOfType("db.AuditEntry")).Return(nil)
+	bsts.storer.On("CreateSession", ctx, mock.AnythingOfType("db.Session"), mock.AnythingOfType("db.RefreshToken")).Return(nil).Once()
+
+	tokens, err := bsts.bankService.LoginMFA(ctx, MFALoginRequest{MFAToken: token, RecoveryCode: "abcd efgh"})
+	bsts.Require().NoError(err)
+	bsts.NotEmpty(tokens.AccessToken)
+	bsts.Empty(tokens.RecoveryCodes)
+
+	// a code that was already used is refused
+	counter := totp.Counter(time.Now())
+	code, err := totp.Code(m.Secret, counter)
+	bsts.Require().NoError(err)
+	bsts.storer.On("UseMFACode", ctx, "2", counter).Return(db.ErrMFACodeUsed).Once()
+	bsts.storer.On("RecordLoginFailure", ctx, db.LoginAttemptEmail, "abc@gmail.com", mock.AnythingOfType("time.Time")).
+		Return(db.LoginAttempt{Failures: 1}, nil).Once()
+
+	_, err = bsts.bankService.LoginMFA(ctx, MFALoginRequest{MFAToken: token, Code: code})
+	bsts.ErrorIs(err, ErrUnauthorized)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_StaffDepositAmount() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "7", Role: RoleTeller}
+	amount := money.New(1000, 2)
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", db.AnyOwner).Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Times(3)
+
+	// a reason is required
+	err := bsts.bankService.StaffDepositAmount(ctx, claims, StaffAccess{Reason: "  "}, "acc-1", amount, "")
+	bsts.Equal(ErrReasonRequired, err)
+
+	// the access is refused when it cannot be audited
+	bsts.storer.On("AddAuditEntry", ctx, mock.AnythingOfType("db.AuditEntry")).Return(errors.New("connection refused")).Once()
+	err = bsts.bankService.StaffDepositAmount(ctx, claims, StaffAccess{Reason: "cash deposit at branch"}, "acc-1", amount, "")
+	bsts.Error(err)
+
+	// the deposit is not limited to accounts of the staff member
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditStaffDeposit && *e.ActorID == "7" && e.Subject == "acc-1" &&
+			e.IP == "10.0.0.1" && strings.Contains(e.Details, "cash deposit at branch")
+	})).Return(nil).Once()
+	bsts.storer.On("DepositAmount", ctx, "acc-1", db.AnyOwner, amount).Return(nil, nil).Once()
+	err = bsts.bankService.StaffDepositAmount(ctx, claims, StaffAccess{Reason: "cash deposit at branch", IP: "10.0.0.1"}, "acc-1", amount, "")
+	bsts.NoError(err)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_StaffGetAccountDetails() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "7", Role: RoleAccountant}
+	want := db.UserAccountDetails{Account: db.Account{ID: "acc-1"}, Email: "abc@gmail.com"}
+
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditStaffAccountViewed && e.Subject == "acc-1"
+	})).Return(nil).Once()
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", db.AnyOwner).Return(want, nil).Once()
+
+	acc, err := bsts.bankService.StaffGetAccountDetails(ctx, claims, StaffAccess{Reason: "customer called about a payment"}, "acc-1")
+	bsts.NoError(err)
+	bsts.Equal(want, acc)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_GetAuditLog() {
+	ctx := context.TODO()
+	entries := []db.AuditEntry{{ID: 2, Action: AuditStaffDeposit}}
+
+	bsts.storer.On("GetAuditEntries", ctx, db.AuditFilter{Subject: "acc-1", Limit: defaultAuditLimit}).Return(entries, nil).Once()
+	got, err := bsts.bankService.GetAuditLog(ctx, db.AuditFilter{Subject: "acc-1"})
+	bsts.NoError(err)
+	bsts.Equal(entries, got)
+
+	bsts.storer.On("GetAuditEntries", ctx, db.AuditFilter{Limit: maxAuditLimit}).Return(nil, nil).Once()
+	_, err = bsts.bankService.GetAuditLog(ctx, db.AuditFilter{Limit: 10000})
+	bsts.NoError(err)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_ChangeAccountStatus() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+
+	err := bsts.bankService.ChangeAccountStatus(ctx, claims, "acc-1", ChangeAccountStatusRequest{Status: "suspended", Reason: "fraud"})
+	bsts.Equal(ErrInvalidAccountStatus, err)
+	err = bsts.bankService.ChangeAccountStatus(ctx, claims, "acc-1", ChangeAccountStatusRequest{Status: db.AccountClosed, Reason: "fraud"})
+	bsts.Equal(ErrInvalidAccountStatus, err)
+	err = bsts.bankService.ChangeAccountStatus(ctx, claims, "acc-1", ChangeAccountStatusRequest{Status: db.AccountFrozen})
+	bsts.Equal(ErrReasonRequired, err)
+
+	// a frozen account can only be reached from the statuses listed for it
+	bsts.storer.On("SetAccountStatus", ctx, "acc-1", []string{db.AccountActive, db.AccountDebitBlocked, db.AccountDormant}, db.AccountFrozen, "card reported stolen").
+		Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditAccountStatusChanged && e.Subject == "acc-1" && strings.Contains(e.Details, "card reported stolen")
+	})).Return(nil).Once()
+	err = bsts.bankService.ChangeAccountStatus(ctx, claims, "acc-1", ChangeAccountStatusRequest{Status: db.AccountFrozen, Reason: " card reported stolen "})
+	bsts.NoError(err)
+
+	bsts.storer.On("SetAccountStatus", ctx, "acc-2", mock.Anything, db.AccountActive, "cleared").Return(db.ErrAccountStatusConflict).Once()
+	err = bsts.bankService.ChangeAccountStatus(ctx, claims, "acc-2", ChangeAccountStatusRequest{Status: db.AccountActive, Reason: "cleared"})
+	bsts.Equal(db.ErrAccountStatusConflict, err)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_CloseAccount() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+
+	_, err := bsts.bankService.CloseAccount(ctx, claims, "acc-1", CloseAccountRequest{Reason: "customer request", PayoutAccountID: "acc-1"})
+	bsts.Equal(ErrSameAccount, err)
+
+	want := db.AccountClosure{AccountID: "acc-1", PaidOut: money.New(2500, 2)}
+	bsts.storer.On("CloseAccount", ctx, "acc-1", []string{db.AccountActive, db.AccountDormant}, "", true, "customer request").Return(want, nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditAccountClosed && e.Subject == "acc-1"
+	})).Return(nil).Once()
+	closure, err := bsts.bankService.CloseAccount(ctx, claims, "acc-1", CloseAccountRequest{Reason: "customer request", PayOutRemainder: true})
+	bsts.NoError(err)
+	bsts.Equal(want, closure)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_AccrueInterest() {
+	ctx := context.TODO()
+
+	_, err := bsts.bankService.AccrueInterest(ctx, time.Now())
+	bsts.Equal(ErrInvalidBusinessDate, err)
+
+	monthEnd := time.Date(2022, time.October, 31, 0, 0, 0, 0, time.UTC)
+	savings := db.InterestAccount{
+		AccountID:        "acc-1",
+		Product:          "savings",
+		AnnualRate:       money.New(365, 4),
+		DayCount:         "ACT/365",
+		Compounding:      "none",
+		PostingFrequency: "monthly",
+		Balance:          money.New(100000, 2),
+	}
+	alreadyAccrued := savings
+	alreadyAccrued.AccountID = "acc-2"
+	alreadyAccrued.AccruedOnDate = true
+
+	bsts.storer.On("GetInterestAccounts", ctx, monthEnd).Return([]db.InterestAccount{savings, alreadyAccrued}, nil).Once()
+	bsts.storer.On("AddInterestAccrual", ctx, mock.MatchedBy(func(a db.InterestAccrual) bool {
+		return a.AccountID == "acc-1" && a.Amount.Equal(money.New(10, 2))
+	})).Return(nil).Once()
+	bsts.storer.On("PostInterest", ctx, "acc-1", monthEnd).Return(money.New(310, 2), nil).Once()
+	bsts.storer.On("PostInterest", ctx, "acc-2", monthEnd).Return(money.Amount{}, nil).Once()
+
+	run, err := bsts.bankService.AccrueInterest(ctx, monthEnd)
+	bsts.NoError(err)
+	bsts.Equal(InterestRun{BusinessDate: "2022-10-31", Accounts: 2, Accrued: 1, Posted: 1}, run)
+
+	// interest is only posted at the end of the posting period
+	midMonth := time.Date(2022, time.October, 15, 0, 0, 0, 0, time.UTC)
+	bsts.storer.On("GetInterestAccounts", ctx, midMonth).Return([]db.InterestAccount{savings}, nil).Once()
+	bsts.storer.On("AddInterestAccrual", ctx, mock.AnythingOfType("db.InterestAccrual")).Return(errors.New("connection refused")).Once()
+
+	run, err = bsts.bankService.AccrueInterest(ctx, midMonth)
+	bsts.Error(err)
+	bsts.Equal(1, run.Failed)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_CreateFeeRule() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+	req := FeeRuleRequest{Name: "ATM withdrawal", Trigger: db.FeeTriggerWithdrawal, Amount: money.New(2, 0), FreePerMonth: 3}
+	negative := money.New(-1, 0)
+	fractional := money.New(10050, 2)
+	huge := money.New(9000000000000000000, 0)
+
+	invalid := []FeeRuleRequest{
+		{Name: " ", Trigger: db.FeeTriggerWithdrawal, Amount: money.New(2, 0)},
+		{Name: "Transfer", Trigger: "transfer", Amount: money.New(2, 0)},
+		{Name: "Maintenance", Trigger: db.FeeTriggerMonthly, Amount: money.New(2, 0), FreePerMonth: 1},
+		{Name: "Maintenance", Trigger: db.FeeTriggerMonthly, Amount: money.New(2, 0), MinBalance: &negative},
+		{Name: "Maintenance", Trigger: db.FeeTriggerMonthly, Amount: money.New(-2, 0)},
+		{Name: "Maintenance", Trigger: db.FeeTriggerMonthly, Currency: "XYZ", Amount: money.New(2, 0)},
+		{Name: "Maintenance", Trigger: db.FeeTriggerMonthly, Currency: "JPY", Amount: money.New(250, 2)},
+		{Name: "Maintenance", Trigger: db.FeeTriggerMonthly, Currency: "JPY", Amount: money.New(2, 0), MinBalance: &fractional},
+		{Name: "Maintenance", Trigger: db.FeeTriggerMonthly, Amount: money.New(2, 0), MinBalance: &huge},
+	}
+	for _, r := range invalid {
+		_, err := bsts.bankService.CreateFeeRule(ctx, claims, r)
+		bsts.Error(err)
+	}
+
+	created := db.FeeRule{ID: 4, Name: req.Name, Trigger: req.Trigger, Amount: money.New(200, 2), FreePerMonth: 3, Active: true}
+	bsts.storer.On("CreateFeeRule", ctx, mock.MatchedBy(func(r db.FeeRule) bool {
+		return r.Active && r.AccountType == nil && r.Currency == "INR" && r.Amount.String() == "2.00"
+	})).Return(created, nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditFeeRuleCreated && e.Subject == "4"
+	})).Return(nil).Once()
+
+	rule, err := bsts.bankService.CreateFeeRule(ctx, claims, req)
+	bsts.NoError(err)
+	bsts.Equal(created, rule)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_ChargeMonthlyFees() {
+	ctx := context.TODO()
+
+	_, err := bsts.bankService.ChargeMonthlyFees(ctx, time.Now())
+	bsts.Equal(ErrInvalidBusinessDate, err)
+
+	period := time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC)
+	charges := []db.FeeCharge{
+		{RuleID: 1, AccountID: "acc-1", Period: period},
+		{RuleID: 1, AccountID: "acc-2", Period: period},
+		{RuleID: 1, AccountID: "acc-3", Period: period},
+	}
+	bsts.storer.On("GetMonthlyFeeCharges", ctx, period).Return(charges, nil).Once()
+	bsts.storer.On("ChargeMonthlyFee", ctx, charges[0]).Return(money.New(500, 2), nil).Once()
+	bsts.storer.On("ChargeMonthlyFee", ctx, charges[1]).Return(money.Amount{}, db.ErrInsufficientFunds).Once()
+	// the balance was above the minimum
+	bsts.storer.On("ChargeMonthlyFee", ctx, charges[2]).Return(money.Amount{}, nil).Once()
+
+	run, err := bsts.bankService.ChargeMonthlyFees(ctx, time.Date(2022, time.October, 17, 0, 0, 0, 0, time.UTC))
+	bsts.NoError(err)
+	bsts.Equal(FeeRun{Month: "2022-10", Due: 3, Charged: 1, Unpaid: 1}, run)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_SetOverdraftLimit() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+
+	err := bsts.bankService.SetOverdraftLimit(ctx, claims, "acc-1", SetOverdraftLimitRequest{Limit: money.New(500, 0)})
+	bsts.Equal(ErrReasonRequired, err)
+
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", db.AnyOwner).Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Times(3)
+	err = bsts.bankService.SetOverdraftLimit(ctx, claims, "acc-1", SetOverdraftLimitRequest{Limit: money.New(-500, 0), Reason: "salary account"})
+	bsts.ErrorIs(err, ErrInvalidAmount)
+
+	bsts.storer.On("SetOverdraftLimit", ctx, "acc-1", money.New(50000, 2)).Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditOverdraftLimitChanged && e.Subject == "acc-1"
+	})).Return(nil).Twice()
+	err = bsts.bankService.SetOverdraftLimit(ctx, claims, "acc-1", SetOverdraftLimitRequest{Limit: money.New(500, 0), Reason: "salary account"})
+	bsts.NoError(err)
+
+	// a zero limit removes the overdraft
+	bsts.storer.On("SetOverdraftLimit", ctx, "acc-1", money.New(0, 2)).Return(nil).Once()
+	err = bsts.bankService.SetOverdraftLimit(ctx, claims, "acc-1", SetOverdraftLimitRequest{Reason: "customer request"})
+	bsts.NoError(err)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_SetAccountLimits() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+	daily := money.New(1000, 0)
+
+	err := bsts.bankService.SetAccountLimits(ctx, claims, "acc-1", SetDebitLimitsRequest{DailyDebitLimit: &daily})
+	bsts.Equal(ErrReasonRequired, err)
+
+	negative := money.New(-1000, 0)
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", db.AnyOwner).Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Twice()
+	err = bsts.bankService.SetAccountLimits(ctx, claims, "acc-1", SetDebitLimitsRequest{DailyDebitLimit: &negative, Reason: "fraud risk"})
+	bsts.ErrorIs(err, ErrInvalidAmount)
+
+	bsts.storer.On("SetAccountDebitLimits", ctx, "acc-1", mock.MatchedBy(func(l db.DebitLimits) bool {
+		return l.Single == nil && l.Monthly == nil && l.Daily != nil && l.Daily.String() == "1000.00"
+	})).Return(nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditDebitLimitsChanged && e.Subject == "acc-1"
+	})).Return(nil).Once()
+	err = bsts.bankService.SetAccountLimits(ctx, claims, "acc-1", SetDebitLimitsRequest{DailyDebitLimit: &daily, Reason: "fraud risk"})
+	bsts.NoError(err)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_CreateStandingOrder() {
+	ctx := context.TODO()
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	three, zero := 3, 0
+
+	invalid := []CreateStandingOrderRequest{
+		{ToAccountID: "acc-2", Amount: money.New(100, 0), Frequency: "hourly"},
+		{Amount: money.New(100, 0), Frequency: db.FrequencyMonthly},
+		{ToAccountID: "acc-2", Amount: money.New(100, 0), Frequency: db.FrequencyOnce, MaxOccurrences: &three},
+		{ToAccountID: "acc-2", Amount: money.New(100, 0), Frequency: db.FrequencyDaily, StartDate: yesterday},
+		{ToAccountID: "acc-2", Amount: money.New(100, 0), Frequency: db.FrequencyDaily, StartDate: tomorrow, EndDate: yesterday},
+		{ToAccountID: "acc-2", Amount: money.New(100, 0), Frequency: db.FrequencyDaily, MaxOccurrences: &zero},
+	}
+	for _, req := range invalid {
+		_, err := bsts.bankService.CreateStandingOrder(ctx, "acc-1", "1", req)
+		bsts.ErrorIs(err, ErrInvalidStandingOrder, "%+v", req)
+	}
+
+	_, err := bsts.bankService.CreateStandingOrder(ctx, "acc-1", "1", CreateStandingOrderRequest{ToAccountID: "acc-1", Amount: money.New(100, 0), Frequency: db.FrequencyDaily})
+	bsts.Equal(ErrSameAccount, err)
+
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", "1").Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Once()
+	bsts.storer.On("CreateStandingOrder", ctx, mock.MatchedBy(func(o db.StandingOrder) bool {
+		return o.FromAccountID == "acc-1" && o.UserID == "1" && o.Interval == 1 && o.Amount.String() == "100.00" &&
+			o.Status == db.StandingOrderActive && o.NextRunDate.Format("2006-01-02") == tomorrow
+	})).Return(db.StandingOrder{ID: "so-1"}, nil).Once()
+	order, err := bsts.bankService.CreateStandingOrder(ctx, "acc-1", "1", CreateStandingOrderRequest{
+		ToAccountID: "acc-2", Amount: money.New(100, 0), Frequency: db.FrequencyMonthly, StartDate: tomorrow, MaxOccurrences: &three,
+	})
+	bsts.NoError(err)
+	bsts.Equal("so-1", order.ID)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_ExecuteStandingOrders() {
+	ctx := context.TODO()
+	now := time.Date(2022, 11, 1, 0, 5, 0, 0, time.Local)
+	runDate := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	bsts.bankService.(*bankService).orderPolicy = standingOrderPolicy{maxRetries: 1, retryInterval: 4 * time.Hour}
+
+	order := func(id string, attempts int) db.StandingOrder {
+		return db.StandingOrder{
+			ID: id, UserID: "1", FromAccountID: "acc-" + id, ToAccountID: "acc-2", Amount: money.New(10000, 2),
+			Frequency: db.FrequencyMonthly, Interval: 1, StartDate: runDate, Attempts: attempts,
+			NextRunDate: &runDate, Status: db.StandingOrderActive,
+		}
+	}
+	for _, id := range []string{"paid", "short", "broke", "down"} {
+		bsts.storer.On("GetAccountDetails", ctx, "acc-"+id, "1").Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Once()
+	}
+	// every order is claimed and paid in a transaction of its own
+	bsts.storer.On("WithTx", ctx, mock.Anything).Return(func(ctx context.Context, op func(context.Context) error) error { return op(ctx) })
+	for _, o := range []db.StandingOrder{order("paid", 0), order("short", 0), order("broke", 1), order("down", 0)} {
+		bsts.storer.On("ClaimDueStandingOrder", ctx, now, mock.AnythingOfType("[]string")).Return(o, nil).Once()
+	}
+	bsts.storer.On("ClaimDueStandingOrder", ctx, now, mock.AnythingOfType("[]string")).Return(db.StandingOrder{}, db.ErrStandingOrderNotExist).Once()
+
+	bsts.storer.On("TransferAmount", ctx, "acc-paid", "acc-2", "1", money.New(10000, 2)).Return(db.Transfer{Reference: "ref-1"}, nil).Once()
+	bsts.storer.On("RecordStandingOrderExecution", ctx, mock.MatchedBy(func(o db.StandingOrder) bool {
+		return o.ID == "paid" && o.Occurrences == 1 && o.Attempts == 0 && o.NextRunDate.Format("2006-01-02") == "2022-12-01"
+	}), mock.MatchedBy(func(e db.StandingOrderExecution) bool {
+		return e.Outcome == db.ExecutionPaid && *e.TransferRef == "ref-1" && e.Attempt == 1
+	})).Return(nil).Once()
+
+	// the first shortfall is retried later the same day
+	bsts.storer.On("TransferAmount", ctx, "acc-short", "acc-2", "1", money.New(10000, 2)).Return(db.Transfer{}, db.ErrInsufficientFunds).Once()
+	bsts.storer.On("RecordStandingOrderExecution", ctx, mock.MatchedBy(func(o db.StandingOrder) bool {
+		return o.ID == "short" && o.Occurrences == 0 && o.Attempts == 1 && o.NextAttemptAt.Equal(now.Add(4*time.Hour))
+	}), mock.MatchedBy(func(e db.StandingOrderExecution) bool {
+		return e.Outcome == db.ExecutionRetrying && *e.Error == db.ErrInsufficientFunds.Error()
+	})).Return(nil).Once()
+
+	// out of retries, the occurrence is given up
+	bsts.storer.On("TransferAmount", ctx, "acc-broke", "acc-2", "1", money.New(10000, 2)).Return(db.Transfer{}, db.ErrInsufficientFunds).Once()
+	bsts.storer.On("RecordStandingOrderExecution", ctx, mock.MatchedBy(func(o db.StandingOrder) bool {
+		return o.ID == "broke" && o.Occurrences == 1 && o.Attempts == 0 && o.NextRunDate.Format("2006-01-02") == "2022-12-01"
+	}), mock.MatchedBy(func(e db.StandingOrderExecution) bool {
+		return e.Outcome == db.ExecutionFailed && e.Attempt == 2
+	})).Return(nil).Once()
+
+	// unexpected errors leave the order for the next run
+	bsts.storer.On("TransferAmount", ctx, "acc-down", "acc-2", "1", money.New(10000, 2)).Return(db.Transfer{}, errors.New("connection refused")).Once()
+
+	run, err := bsts.bankService.ExecuteStandingOrders(ctx, now)
+	bsts.Error(err)
+	bsts.Equal(StandingOrderRun{Due: 4, Paid: 1, Retrying: 1, Rejected: 1, Failed: 1}, run)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_ExecuteStandingOrders_RecordFails() {
+	ctx := context.TODO()
+	now := time.Date(2022, 11, 1, 0, 5, 0, 0, time.Local)
+	runDate := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	o := db.StandingOrder{
+		ID: "so-1", UserID: "1", FromAccountID: "acc-1", ToAccountID: "acc-2", Amount: money.New(10000, 2),
+		Frequency: db.FrequencyMonthly, Interval: 1, StartDate: runDate, NextRunDate: &runDate, Status: db.StandingOrderActive,
+	}
+
+	// the transaction fails with the execution that could not be recorded,
+	// rolling the transfer back, and the order is not claimed again this run
+	var txErrs []error
+	bsts.storer.On("WithTx", ctx, mock.Anything).Return(func(ctx context.Context, op func(context.Context) error) error {
+		err := op(ctx)
+		txErrs = append(txErrs, err)
+		return err
+	})
+	bsts.storer.On("ClaimDueStandingOrder", ctx, now, []string{}).Return(o, nil).Once()
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", "1").Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Once()
+	bsts.storer.On("TransferAmount", ctx, "acc-1", "acc-2", "1", money.New(10000, 2)).Return(db.Transfer{Reference: "ref-1"}, nil).Once()
+	bsts.storer.On("RecordStandingOrderExecution", ctx, mock.AnythingOfType("db.StandingOrder"), mock.AnythingOfType("db.StandingOrderExecution")).
+		Return(errors.New("connection reset")).Once()
+	bsts.storer.On("ClaimDueStandingOrder", ctx, now, []string{"so-1"}).Return(db.StandingOrder{}, db.ErrStandingOrderNotExist).Once()
+
+	run, err := bsts.bankService.ExecuteStandingOrders(ctx, now)
+	bsts.Error(err)
+	bsts.Require().NotEmpty(txErrs)
+	bsts.EqualError(txErrs[0], "connection reset")
+	bsts.Equal(StandingOrderRun{Due: 1, Failed: 1}, run)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_CreateFXRate() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+	past := time.Now().Add(-time.Hour)
+
+	invalid := []FXRateRequest{
+		{QuoteCurrency: "INR", Rate: money.New(80, 0)},
+		{BaseCurrency: "USD", QuoteCurrency: "USD", Rate: money.New(1, 0)},
+		{BaseCurrency: "USD", QuoteCurrency: "INR"},
+		{BaseCurrency: "USD", QuoteCurrency: "INR", Rate: money.New(80, 0), Spread: money.New(1, 0)},
+		{BaseCurrency: "USD", QuoteCurrency: "INR", Rate: money.New(80, 0), EffectiveFrom: &past},
+	}
+	for _, req := range invalid {
+		_, err := bsts.bankService.CreateFXRate(ctx, claims, req)
+		bsts.ErrorIs(err, ErrInvalidFXRate, "%+v", req)
+	}
+
+	_, err := bsts.bankService.CreateFXRate(ctx, claims, FXRateRequest{BaseCurrency: "XYZ", QuoteCurrency: "INR", Rate: money.New(80, 0)})
+	bsts.ErrorIs(err, ErrInvalidCurrency)
+
+	bsts.storer.On("CreateFXRate", ctx, mock.MatchedBy(func(r db.FXRate) bool {
+		return r.BaseCurrency == "USD" && r.QuoteCurrency == "INR" && r.Rate.String() == "80.25" && *r.CreatedBy == "1"
+	})).Return(db.FXRate{ID: 1, BaseCurrency: "USD", QuoteCurrency: "INR", Rate: money.New(8025, 2), Spread: money.New(5, 3)}, nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditFXRateCreated && e.Subject == "USD/INR"
+	})).Return(nil).Once()
+	rate, err := bsts.bankService.CreateFXRate(ctx, claims, FXRateRequest{BaseCurrency: "USD", QuoteCurrency: "INR", Rate: money.New(8025, 2), Spread: money.New(5, 3)})
+	bsts.NoError(err)
+	bsts.Equal(int64(1), rate.ID)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_DepositAmount_CurrencyMismatch() {
+	ctx := context.TODO()
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", "1").Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Once()
+
+	err := bsts.bankService.DepositAmount(ctx, "acc-1", "1", money.New(100, 0), "USD")
+	bsts.ErrorIs(err, ErrCurrencyMismatch)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_CreateHold() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+	bsts.bankService.(*bankService).holdPolicy = holdPolicy{defaultExpiry: 24 * time.Hour, maxExpiry: 7 * 24 * time.Hour}
+
+	_, err := bsts.bankService.CreateHold(ctx, claims, "acc-1", CreateHoldRequest{Amount: money.New(100, 0), Reference: "  "})
+	bsts.ErrorIs(err, ErrInvalidHold)
+
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", db.AnyOwner).Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Twice()
+	_, err = bsts.bankService.CreateHold(ctx, claims, "acc-1", CreateHoldRequest{Amount: money.New(-100, 0), Reference: "auth-1"})
+	bsts.ErrorIs(err, ErrInvalidAmount)
+
+	bsts.storer.On("CreateHold", ctx, mock.MatchedBy(func(h db.Hold) bool {
+		return h.AccountID == "acc-1" && h.Amount.String() == "100.00" && h.Reference == "auth-1" && *h.CreatedBy == "1" &&
+			h.ExpiresAt.After(time.Now().Add(23*time.Hour))
+	})).Return(db.Hold{ID: "hold-1", AccountID: "acc-1", Amount: money.New(10000, 2), Reference: "auth-1", Status: db.HoldActive}, nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditHoldPlaced && e.Subject == "acc-1"
+	})).Return(nil).Once()
+	hold, err := bsts.bankService.CreateHold(ctx, claims, "acc-1", CreateHoldRequest{Amount: money.New(100, 0), Reference: " auth-1 "})
+	bsts.NoError(err)
+	bsts.Equal("hold-1", hold.ID)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_CaptureHold() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+	captured, txnID := money.New(4500, 2), "txn-1"
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", db.AnyOwner).Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Twice()
+
+	tooPrecise := money.New(45001, 3)
+	_, err := bsts.bankService.CaptureHold(ctx, claims, "acc-1", "hold-1", CaptureHoldRequest{Amount: &tooPrecise})
+	bsts.ErrorIs(err, ErrInvalidAmount)
+
+	bsts.storer.On("CaptureHold", ctx, "acc-1", "hold-1", mock.MatchedBy(func(a *money.Amount) bool {
+		return a != nil && a.String() == "45.00"
+	})).Return(db.Hold{ID: "hold-1", Amount: money.New(6000, 2), Status: db.HoldCaptured, CapturedAmount: &captured, TransactionID: &txnID}, nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditHoldCaptured && e.Subject == "acc-1"
+	})).Return(nil).Once()
+	amount := money.New(45, 0)
+	hold, err := bsts.bankService.CaptureHold(ctx, claims, "acc-1", "hold-1", CaptureHoldRequest{Amount: &amount})
+	bsts.NoError(err)
+	bsts.Equal(db.HoldCaptured, hold.Status)
+
+	// without an amount the whole hold is captured, the store checks the hold
+	bsts.storer.On("CaptureHold", ctx, "acc-1", "hold-2", (*money.Amount)(nil)).Return(db.Hold{}, db.ErrHoldNotActive).Once()
+	_, err = bsts.bankService.CaptureHold(ctx, claims, "acc-1", "hold-2", CaptureHoldRequest{})
+	bsts.Equal(db.ErrHoldNotActive, err)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_ReverseTransaction() {
+	ctx := context.TODO()
+	claims := &Claims{UserID: "1", Role: RoleAccountant}
+
+	for _, code := range []string{"", "mistake"} {
+		_, err := bsts.bankService.ReverseTransaction(ctx, claims, "acc-1", "txn-1", ReverseTransactionRequest{ReasonCode: code})
+		bsts.ErrorIs(err, ErrInvalidReversal, code)
+	}
+
+	bsts.storer.On("GetAccountDetails", ctx, "acc-1", db.AnyOwner).Return(db.UserAccountDetails{Account: db.Account{Currency: "INR"}}, nil).Once()
+	negative := money.New(-10, 0)
+	_, err := bsts.bankService.ReverseTransaction(ctx, claims, "acc-1", "txn-1", ReverseTransactionRequest{Amount: &negative, ReasonCode: ReversalRefund})
+	bsts.ErrorIs(err, ErrInvalidAmount)
+
+	bsts.storer.On("ReverseTransaction", ctx, db.Reversal{TransactionID: "txn-1", AccountID: "acc-1", ReasonCode: ReversalDuplicate}).
+		Return(db.Transaction{ID: "txn-2", Type: db.TransactionDebitReversal, Amount: money.New(10000, 2)}, nil).Once()
+	bsts.storer.On("AddAuditEntry", ctx, mock.MatchedBy(func(e db.AuditEntry) bool {
+		return e.Action == AuditTransactionReversed && e.Subject == "acc-1"
+	})).Return(nil).Once()
+	reversal, err := bsts.bankService.ReverseTransaction(ctx, claims, "acc-1", "txn-1", ReverseTransactionRequest{ReasonCode: " duplicate "})
+	bsts.NoError(err)
+	bsts.Equal("txn-2", reversal.ID)
+
+	bsts.storer.On("ReverseTransaction", ctx, db.Reversal{TransactionID: "txn-1", AccountID: "acc-1", ReasonCode: ReversalDuplicate}).
+		Return(db.Transaction{}, db.ErrTransactionReversed).Once()
+	_, err = bsts.bankService.ReverseTransaction(ctx, claims, "acc-1", "txn-1", ReverseTransactionRequest{ReasonCode: ReversalDuplicate})
+	bsts.Equal(db.ErrTransactionReversed, err)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_GetTransactionHistory() {
+	ctx := context.TODO()
+	negative, min, max := money.New(-1, 0), money.New(500, 0), money.New(100, 0)
+
+	for _, req := range []TransactionHistoryRequest{
+		{StartDate: "01-10-2022"},
+		{StartDate: "2022-10-02", EndDate: "2022-10-01"},
+		{Types: []string{"Transfer"}},
+		{MinAmount: &negative},
+		{MinAmount: &min, MaxAmount: &max},
+		{Sort: "newest"},
+	} {
+		_, err := bsts.bankService.GetTransactionHistory(ctx, "acc-1", "1", req)
+		bsts.ErrorIs(err, ErrInvalidTransactionFilter, "%+v", req)
+	}
+
+	// the end date is inclusive, and the latest transactions come first
+	from, to := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC)
+	bsts.storer.On("GetTransactions", ctx, "acc-1", "1", db.TransactionFilter{
+		From: from, To: to, Types: []string{"Debit"}, Sort: db.SortDescending, Limit: maxHistoryLimit,
+	}).Return(db.TransactionPage{Transactions: []db.Transaction{{ID: "txn-1"}}, NextCursor: "next"}, nil).Once()
+	page, err := bsts.bankService.GetTransactionHistory(ctx, "acc-1", "1", TransactionHistoryRequest{
+		StartDate: "2022-10-01", EndDate: "2022-10-01", Types: []string{"Debit"}, Limit: 1000,
+	})
+	bsts.NoError(err)
+	bsts.Equal("next", page.NextCursor)
+
+	bsts.storer.On("GetTransactions", ctx, "acc-1", "1", db.TransactionFilter{Sort: db.SortAscending, Cursor: "bad", Limit: defaultHistoryLimit}).
+		Return(db.TransactionPage{}, db.ErrInvalidCursor).Once()
+	_, err = bsts.bankService.GetTransactionHistory(ctx, "acc-1", "1", TransactionHistoryRequest{Sort: db.SortAscending, Cursor: "bad"})
+	bsts.Equal(db.ErrInvalidCursor, err)
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_GetStatement() {
+	ctx := context.TODO()
+	now := time.Now()
+	_, err := bsts.bankService.GetStatement(ctx, "acc-1", "1", now)
+	bsts.Equal(ErrInvalidStatementMonth, err)
+
+	// the sums are given the digits of the currency of the account
+	from, to := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	bsts.storer.On("GetStatement", ctx, "acc-1", "1", from, to).Return(db.Statement{
+		Account:        db.UserAccountDetails{Account: db.Account{ID: "acc-1", Currency: "INR"}},
+		From:           from,
+		To:             to,
+		OpeningBalance: money.New(0, 0),
+		ClosingBalance: money.New(1050, 1),
+	}, nil).Once()
+	s, err := bsts.bankService.GetStatement(ctx, "acc-1", "1", time.Date(2022, 10, 15, 0, 0, 0, 0, time.UTC))
+	bsts.NoError(err)
+	bsts.Equal("0.00", s.OpeningBalance.String())
+	bsts.Equal("105.00", s.ClosingBalance.String())
+	bsts.Equal("0.00", s.TotalCredits.String())
+}
+
+func (bsts *BankServiceTestSuite) Test_bankService_WriteStatements() {
+	ctx := context.TODO()
+	dir := bsts.T().TempDir()
+	month := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	from, to := month, month.AddDate(0, 1, 0)
+
+	bsts.storer.On("GetAccountList", ctx).Return([]db.UserAccountDetails{
+		{Account: db.Account{ID: "acc-1"}},
+		{Account: db.Account{ID: "acc-2"}},
+	}, nil).Once()
+	bsts.storer.On("GetStatement", ctx, "acc-1", db.AnyOwner, from, to).Return(db.Statement{
+		Account: db.UserAccountDetails{Account: db.Account{ID: "acc-1", Currency: "INR"}}, From: from, To: to,
+	}, nil).Once()
+	bsts.storer.On("GetStatement", ctx, "acc-2", db.AnyOwner, from, to).Return(db.Statement{}, errors.New("mocked error")).Once()
+
+	run, err := bsts.bankService.WriteStatements(ctx, month, dir)
+	bsts.Error(err)
+	bsts.Equal(StatementRun{Month: "2022-10", Accounts: 2, Written: 1, Failed: 1}, run)
+	bsts.FileExists(filepath.Join(dir, "statement-acc-1-2022-10.csv"))
+	bsts.FileExists(filepath.Join(dir, "statement-acc-1-2022-10.pdf"))
+	bsts.NoFileExists(filepath.Join(dir, "statement-acc-2-2022-10.pdf"))
+}
@@ -0,0 +1,165 @@
+package bank
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"example.com/banking/db"
+	"example.com/banking/money"
+)
+
+const (
+	maxReasonLength   = 255
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// validateReason checks that a reason is given for an operation that needs
+// one, and returns it trimmed.
+func validateReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > maxReasonLength {
+		return reason, ErrReasonRequired
+	}
+	return reason, nil
+}
+
+// auditStaffAccess records that a staff member is about to work on a
+// customer's account. Unlike other audit entries the access is refused when
+// the entry cannot be written, so no access goes unrecorded.
+func (b *bankService) auditStaffAccess(ctx context.Context, claims *Claims, access StaffAccess, action, accId, details string) (err error) {
+	reason, err := validateReason(access.Reason)
+	if err != nil {
+		return
+	}
+
+	if details != "" {
+		details = "; " + details
+	}
+	err = b.store.AddAuditEntry(ctx, db.AuditEntry{
+		Action:  action,
+		ActorID: &claims.UserID,
+		Subject: accId,
+		IP:      access.IP,
+		Details: fmt.Sprintf("reason: %v%v", reason, details),
+	})
+	if err != nil {
+		b.logger.Errorf("Err writing audit entry: %v, err: %v", action, err)
+	}
+	return
+}
+
+// StaffGetAccountDetails returns the details of any customer's account.
+func (b *bankService) StaffGetAccountDetails(ctx context.Context, claims *Claims, access StaffAccess, accId string) (acc db.UserAccountDetails, err error) {
+	if err = b.auditStaffAccess(ctx, claims, access, AuditStaffAccountViewed, accId, ""); err != nil {
+		return
+	}
+
+	b.logger.Infof("User: %v getting the customer details for account: %v\n", claims.UserID, accId)
+	acc, err = b.store.GetAccountDetails(ctx, accId, db.AnyOwner)
+	return
+}
+
+// StaffDepositAmount deposits into any customer's account.
+func (b *bankService) StaffDepositAmount(ctx context.Context, claims *Claims, access StaffAccess, accId string, amount money.Amount, currency string) (err error) {
+	cur, err := b.accountCurrency(ctx, accId, db.AnyOwner, currency)
+	if err != nil {
+		return
+	}
+
+	amount, err = validateAmount(amount, cur)
+	if err != nil {
+		return
+	}
+
+	if err = b.auditStaffAccess(ctx, claims, access, AuditStaffDeposit, accId, fmt.Sprintf("amount: %v", amount)); err != nil {
+		return
+	}
+
+	b.logger.Infof("User: %v depositing amount: %v in account: %v\n", claims.UserID, amount, accId)
+	fees, err := b.store.DepositAmount(ctx, accId, db.AnyOwner, amount)
+	if err != nil {
+		return
+	}
+
+	b.logFees(accId, fees)
+	return
+}
+
+// StaffWithdrawAmount withdraws from any customer's account.
+func (b *bankService) StaffWithdrawAmount(ctx context.Context, claims *Claims, access StaffAccess, accId string, amount money.Amount, currency string) (err error) {
+	cur, err := b.accountCurrency(ctx, accId, db.AnyOwner, currency)
+	if err != nil {
+		return
+	}
+
+	amount, err = validateAmount(amount, cur)
+	if err != nil {
+		return
+	}
+
+	if err = b.auditStaffAccess(ctx, claims, access, AuditStaffWithdrawal, accId, fmt.Sprintf("amount: %v", amount)); err != nil {
+		return
+	}
+
+	b.logger.Infof("User: %v withdrawing amount: %v from account: %v\n", claims.UserID, amount, accId)
+	fees, err := b.store.WithdrawAmount(ctx, accId, db.AnyOwner, amount)
+	if err != nil {
+		return
+	}
+
+	b.logFees(accId, fees)
+	return
+}
+
+// StaffTransferAmount transfers from any customer's account.
+func (b *bankService) StaffTransferAmount(ctx context.Context, claims *Claims, access StaffAccess, accId, toAccId string, amount money.Amount, currency string) (transfer db.Transfer, err error) {
+	if accId == toAccId {
+		err = ErrSameAccount
+		return
+	}
+
+	cur, err := b.accountCurrency(ctx, accId, db.AnyOwner, currency)
+	if err != nil {
+		return
+	}
+
+	amount, err = validateAmount(amount, cur)
+	if err != nil {
+		return
+	}
+
+	details := fmt.Sprintf("amount: %v, to account: %v", amount, toAccId)
+	if err = b.auditStaffAccess(ctx, claims, access, AuditStaffTransfer, accId, details); err != nil {
+		return
+	}
+
+	b.logger.Infof("User: %v transferring amount: %v from account: %v to account: %v\n", claims.UserID, amount, accId, toAccId)
+	transfer, err = b.store.TransferAmount(ctx, accId, toAccId, db.AnyOwner, amount)
+	return
+}
+
+// StaffGetTransactionDetails returns the transactions of any customer's
+// account.
+func (b *bankService) StaffGetTransactionDetails(ctx context.Context, claims *Claims, access StaffAccess, accId, startDate, endDate string) (transactions []db.Transaction, err error) {
+	details := fmt.Sprintf("from: %v, to: %v", startDate, endDate)
+	if err = b.auditStaffAccess(ctx, claims, access, AuditStaffTransactionsViewed, accId, details); err != nil {
+		return
+	}
+
+	return b.GetTransactionDetails(ctx, accId, db.AnyOwner, startDate, endDate)
+}
+
+// GetAuditLog returns the audit entries matching the filter, newest first.
+func (b *bankService) GetAuditLog(ctx context.Context, f db.AuditFilter) (entries []db.AuditEntry, err error) {
+	if f.Limit <= 0 {
+		f.Limit = defaultAuditLimit
+	}
+	if f.Limit > maxAuditLimit {
+		f.Limit = maxAuditLimit
+	}
+
+	entries, err = b.store.GetAuditEntries(ctx, f)
+	return
+}
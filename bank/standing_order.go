@@ -0,0 +1,290 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/config"
+	"example.com/banking/db"
+)
+
+var frequencies = []string{db.FrequencyOnce, db.FrequencyDaily, db.FrequencyWeekly, db.FrequencyMonthly, db.FrequencyYearly}
+
+// standingOrderPolicy decides when a standing order that could not be paid
+// is tried again.
+type standingOrderPolicy struct {
+	maxRetries    int
+	retryInterval time.Duration
+}
+
+func newStandingOrderPolicy() standingOrderPolicy {
+	c := config.StandingOrders()
+	return standingOrderPolicy{
+		maxRetries:    c.MaxRetries(),
+		retryInterval: c.RetryInterval(),
+	}
+}
+
+// retry reports whether a payment that failed with err, after the given
+// number of failed attempts, is tried again. Only a lack of funds or of
+// headroom under the debit limits can go away by waiting.
+func (p standingOrderPolicy) retry(err error, attempts int) bool {
+	if err != db.ErrInsufficientFunds && !errors.Is(err, db.ErrLimitExceeded) {
+		return false
+	}
+	return attempts < p.maxRetries
+}
+
+// rejected reports whether a transfer was refused by the rules of the bank,
+// rather than failing on the way.
+func rejected(err error) bool {
+	switch err {
+	case db.ErrInsufficientFunds, db.ErrAccountNotExist, db.ErrPayeeNotExist, db.ErrOperationNotAllowed,
+		db.ErrDebitNotAllowed, db.ErrPayeeCreditNotAllowed, db.ErrFXRateNotExist, db.ErrAmountTooSmall, ErrSameAccount:
+		return true
+	}
+	return errors.Is(err, db.ErrLimitExceeded) || errors.Is(err, ErrInvalidAmount)
+}
+
+// runDate is the date of the nth occurrence of the order, counting from 0.
+// Monthly and yearly orders fall on the last day of shorter months.
+func runDate(o db.StandingOrder, n int) time.Time {
+	step := n * o.Interval
+	switch o.Frequency {
+	case db.FrequencyDaily:
+		return o.StartDate.AddDate(0, 0, step)
+	case db.FrequencyWeekly:
+		return o.StartDate.AddDate(0, 0, 7*step)
+	case db.FrequencyMonthly:
+		return addMonths(o.StartDate, step)
+	case db.FrequencyYearly:
+		return addMonths(o.StartDate, 12*step)
+	}
+	return o.StartDate
+}
+
+func addMonths(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	day := date.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// schedule sets the next occurrence of the order, or completes it when none
+// is left. An occurrence is first tried at the start of its day.
+func schedule(o *db.StandingOrder) {
+	next := runDate(*o, o.Occurrences)
+	if (o.Frequency == db.FrequencyOnce && o.Occurrences > 0) ||
+		(o.MaxOccurrences != nil && o.Occurrences >= *o.MaxOccurrences) ||
+		(o.EndDate != nil && next.After(*o.EndDate)) {
+		o.Status = db.StandingOrderCompleted
+		o.NextRunDate = nil
+		o.NextAttemptAt = nil
+		return
+	}
+
+	attemptAt := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.Local)
+	o.Status = db.StandingOrderActive
+	o.NextRunDate = &next
+	o.NextAttemptAt = &attemptAt
+}
+
+// standingOrder checks a standing order request and turns it into an order
+// from the account, scheduled from today on.
+func standingOrder(accId, userID string, req CreateStandingOrderRequest, now time.Time) (o db.StandingOrder, err error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	o = db.StandingOrder{
+		ID:             uuidgen.New(),
+		UserID:         userID,
+		FromAccountID:  accId,
+		ToAccountID:    strings.TrimSpace(req.ToAccountID),
+		Frequency:      req.Frequency,
+		Interval:       req.Interval,
+		StartDate:      today,
+		MaxOccurrences: req.MaxOccurrences,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if o.Interval == 0 {
+		o.Interval = 1
+	}
+	if req.StartDate != "" {
+		if o.StartDate, err = time.Parse("2006-01-02", req.StartDate); err != nil {
+			return o, fmt.Errorf("%w: start_date must be a date as yyyy-mm-dd", ErrInvalidStandingOrder)
+		}
+	}
+	if req.EndDate != "" {
+		end, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return o, fmt.Errorf("%w: end_date must be a date as yyyy-mm-dd", ErrInvalidStandingOrder)
+		}
+		o.EndDate = &end
+	}
+
+	switch {
+	case o.ToAccountID == "":
+		err = fmt.Errorf("%w: to_account_id must be given", ErrInvalidStandingOrder)
+	case o.ToAccountID == accId:
+		err = ErrSameAccount
+	case !containsString(frequencies, o.Frequency):
+		err = fmt.Errorf("%w: frequency must be one of %v", ErrInvalidStandingOrder, strings.Join(frequencies, ", "))
+	case o.Interval < 0:
+		err = fmt.Errorf("%w: interval must be positive", ErrInvalidStandingOrder)
+	case o.Frequency == db.FrequencyOnce && (o.Interval != 1 || o.EndDate != nil || o.MaxOccurrences != nil):
+		err = fmt.Errorf("%w: interval, end_date and max_occurrences are only allowed for recurring orders", ErrInvalidStandingOrder)
+	case o.StartDate.Before(today):
+		err = fmt.Errorf("%w: start_date must not be in the past", ErrInvalidStandingOrder)
+	case o.EndDate != nil && o.EndDate.Before(o.StartDate):
+		err = fmt.Errorf("%w: end_date must not be before start_date", ErrInvalidStandingOrder)
+	case o.MaxOccurrences != nil && *o.MaxOccurrences < 1:
+		err = fmt.Errorf("%w: max_occurrences must be positive", ErrInvalidStandingOrder)
+	}
+	if err != nil {
+		return
+	}
+
+	schedule(&o)
+	return
+}
+
+// CreateStandingOrder sets up payments from the user's account to another
+// account, made by ExecuteStandingOrders when they are due.
+func (b *bankService) CreateStandingOrder(ctx context.Context, accId, userID string, req CreateStandingOrderRequest) (order db.StandingOrder, err error) {
+	o, err := standingOrder(accId, userID, req, time.Now())
+	if err != nil {
+		return
+	}
+
+	// orders pay in the currency of the account
+	cur, err := b.accountCurrency(ctx, accId, userID, "")
+	if err != nil {
+		return
+	}
+	if o.Amount, err = validateAmount(req.Amount, cur); err != nil {
+		return
+	}
+
+	b.logger.Infof("Creating %v standing order of: %v from account: %v to account: %v\n", o.Frequency, o.Amount, accId, o.ToAccountID)
+	order, err = b.store.CreateStandingOrder(ctx, o)
+	return
+}
+
+func (b *bankService) GetStandingOrders(ctx context.Context, accId, userID string) (orders []db.StandingOrder, err error) {
+	orders, err = b.store.GetAccountStandingOrders(ctx, accId, userID)
+	return
+}
+
+func (b *bankService) GetStandingOrderExecutions(ctx context.Context, accId, orderId, userID string) (executions []db.StandingOrderExecution, err error) {
+	executions, err = b.store.GetStandingOrderExecutions(ctx, accId, orderId, userID)
+	return
+}
+
+// CancelStandingOrder stops the payments of a standing order. Executions
+// already made are kept.
+func (b *bankService) CancelStandingOrder(ctx context.Context, accId, orderId, userID string) (err error) {
+	b.logger.Infof("Cancelling standing order: %v of account: %v\n", orderId, accId)
+	err = b.store.CancelStandingOrder(ctx, accId, orderId, userID)
+	return
+}
+
+// ExecuteStandingOrders pays the standing orders due at now, as transfers
+// of their user. Orders the account cannot pay yet are retried according to
+// the policy, then their occurrence is given up. Orders that failed for
+// another reason are left as they are and tried again on the next run.
+//
+// Each order is claimed, paid and its execution recorded in one transaction,
+// so an occurrence is never paid without being recorded, and runs on other
+// servers skip the orders claimed by this one.
+func (b *bankService) ExecuteStandingOrders(ctx context.Context, now time.Time) (run StandingOrderRun, err error) {
+	tried := make([]string, 0)
+	for {
+		var o db.StandingOrder
+		var outcome string
+		err := b.store.WithTx(ctx, func(ctx context.Context) (err error) {
+			if o, err = b.store.ClaimDueStandingOrder(ctx, now, tried); err != nil {
+				return
+			}
+			outcome, err = b.executeStandingOrder(ctx, o, now)
+			return
+		})
+		if o.ID == "" {
+			if err != db.ErrStandingOrderNotExist {
+				return run, err
+			}
+			break
+		}
+		tried = append(tried, o.ID)
+		run.Due++
+
+		switch {
+		case err != nil:
+			b.logger.Errorf("Err executing standing order: %v from account: %v, err: %v", o.ID, o.FromAccountID, err)
+			run.Failed++
+		case outcome == db.ExecutionPaid:
+			run.Paid++
+		case outcome == db.ExecutionRetrying:
+			run.Retrying++
+		default:
+			run.Rejected++
+		}
+	}
+
+	b.logger.Infof("Executed standing orders, due: %v, paid: %v, retrying: %v, rejected: %v, failed: %v\n",
+		run.Due, run.Paid, run.Retrying, run.Rejected, run.Failed)
+	if run.Failed > 0 {
+		err = fmt.Errorf("standing orders failed for %d of %d orders", run.Failed, run.Due)
+	}
+	return
+}
+
+// executeStandingOrder tries to pay the next occurrence of the order and
+// records how it went.
+func (b *bankService) executeStandingOrder(ctx context.Context, o db.StandingOrder, now time.Time) (outcome string, err error) {
+	e := db.StandingOrderExecution{
+		StandingOrderID: o.ID,
+		RunDate:         *o.NextRunDate,
+		Attempt:         o.Attempts + 1,
+		ExecutedAt:      now,
+	}
+
+	transfer, err := b.TransferAmount(ctx, o.FromAccountID, o.UserID, o.ToAccountID, o.Amount, "")
+	switch {
+	case err == nil:
+		e.Outcome = db.ExecutionPaid
+		e.TransferRef = &transfer.Reference
+	case !rejected(err):
+		return
+	case b.orderPolicy.retry(err, o.Attempts):
+		e.Outcome = db.ExecutionRetrying
+	default:
+		e.Outcome = db.ExecutionFailed
+	}
+	if err != nil {
+		msg := err.Error()
+		e.Error = &msg
+	}
+
+	if e.Outcome == db.ExecutionRetrying {
+		retryAt := now.Add(b.orderPolicy.retryInterval)
+		o.Attempts++
+		o.NextAttemptAt = &retryAt
+	} else {
+		o.Occurrences++
+		o.Attempts = 0
+		schedule(&o)
+	}
+	o.UpdatedAt = now
+
+	if err = b.store.RecordStandingOrderExecution(ctx, o, e); err != nil {
+		return
+	}
+	outcome = e.Outcome
+	return
+}
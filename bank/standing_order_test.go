@@ -0,0 +1,59 @@
+package bank
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"example.com/banking/db"
+)
+
+func TestRunDate(t *testing.T) {
+	start := time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		frequency string
+		interval  int
+		n         int
+		want      string
+	}{
+		{db.FrequencyOnce, 1, 0, "2022-01-31"},
+		{db.FrequencyDaily, 1, 1, "2022-02-01"},
+		{db.FrequencyDaily, 3, 2, "2022-02-06"},
+		{db.FrequencyWeekly, 2, 1, "2022-02-14"},
+		{db.FrequencyMonthly, 1, 1, "2022-02-28"},
+		{db.FrequencyMonthly, 1, 2, "2022-03-31"},
+		{db.FrequencyMonthly, 3, 1, "2022-04-30"},
+		{db.FrequencyYearly, 1, 2, "2024-01-31"},
+	}
+	for _, tt := range tests {
+		o := db.StandingOrder{Frequency: tt.frequency, Interval: tt.interval, StartDate: start}
+		assert.Equal(t, tt.want, runDate(o, tt.n).Format("2006-01-02"), "occurrence %v of %v every %v", tt.n, tt.frequency, tt.interval)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	start := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, 12, 15, 0, 0, 0, 0, time.UTC)
+	max := 3
+
+	o := db.StandingOrder{Frequency: db.FrequencyMonthly, Interval: 1, StartDate: start, EndDate: &end}
+	schedule(&o)
+	assert.Equal(t, db.StandingOrderActive, o.Status)
+	assert.Equal(t, start, *o.NextRunDate)
+
+	o.Occurrences = 2
+	schedule(&o)
+	assert.Equal(t, db.StandingOrderCompleted, o.Status, "past the end date")
+	assert.Nil(t, o.NextRunDate)
+	assert.Nil(t, o.NextAttemptAt)
+
+	o = db.StandingOrder{Frequency: db.FrequencyWeekly, Interval: 1, StartDate: start, MaxOccurrences: &max, Occurrences: 3}
+	schedule(&o)
+	assert.Equal(t, db.StandingOrderCompleted, o.Status, "max occurrences reached")
+
+	o = db.StandingOrder{Frequency: db.FrequencyOnce, Interval: 1, StartDate: start, Occurrences: 1}
+	schedule(&o)
+	assert.Equal(t, db.StandingOrderCompleted, o.Status, "one-off order done")
+}
@@ -0,0 +1,105 @@
+package bank
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"example.com/banking/db"
+	"example.com/banking/money"
+	"example.com/banking/statement"
+)
+
+// statementPeriod returns the first day of a month and of the next, the month
+// having to be over.
+func statementPeriod(month time.Time) (from, to time.Time, err error) {
+	from = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to = from.AddDate(0, 1, 0)
+	if !to.Before(time.Now()) {
+		err = ErrInvalidStatementMonth
+	}
+	return
+}
+
+// GetStatement returns the statement of an account for a month that is over.
+func (b *bankService) GetStatement(ctx context.Context, accId, userID string, month time.Time) (s db.Statement, err error) {
+	from, to, err := statementPeriod(month)
+	if err != nil {
+		return
+	}
+
+	b.logger.Infof("Getting statement of account: %v for month: %v\n", accId, from.Format("2006-01"))
+	s, err = b.store.GetStatement(ctx, accId, userID, from, to)
+	if err != nil {
+		return
+	}
+
+	// the sums read from the database are given the digits of the currency,
+	// a sum of nothing being a plain 0
+	cur, err := money.LookupCurrency(s.Account.Currency)
+	if err != nil {
+		return
+	}
+	for _, a := range []*money.Amount{&s.OpeningBalance, &s.ClosingBalance, &s.TotalCredits, &s.TotalDebits} {
+		if *a, err = cur.Normalize(*a); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// WriteStatements writes the statements of every account for a month into
+// dir, in every format. An account whose statement cannot be written is
+// skipped and counted as failed.
+func (b *bankService) WriteStatements(ctx context.Context, month time.Time, dir string) (run StatementRun, err error) {
+	run.Month = month.Format("2006-01")
+	if _, _, err = statementPeriod(month); err != nil {
+		return
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+
+	accounts, err := b.store.GetAccountList(ctx)
+	if err != nil {
+		return
+	}
+	run.Accounts = len(accounts)
+
+	for _, acc := range accounts {
+		if err := b.writeStatement(ctx, acc.ID, month, dir); err != nil {
+			b.logger.Errorf("Err writing statement of account: %v, month: %v, err: %v", acc.ID, run.Month, err)
+			run.Failed++
+			continue
+		}
+		run.Written++
+	}
+
+	b.logger.Infof("Wrote statements for month: %v, accounts: %v, written: %v, failed: %v\n",
+		run.Month, run.Accounts, run.Written, run.Failed)
+	if run.Failed > 0 {
+		err = fmt.Errorf("statements failed for %d of %d accounts", run.Failed, run.Accounts)
+	}
+	return
+}
+
+func (b *bankService) writeStatement(ctx context.Context, accId string, month time.Time, dir string) (err error) {
+	s, err := b.GetStatement(ctx, accId, db.AnyOwner, month)
+	if err != nil {
+		return
+	}
+
+	for _, format := range []string{statement.FormatCSV, statement.FormatPDF} {
+		var buf bytes.Buffer
+		if err = statement.Write(&buf, s, format); err != nil {
+			return
+		}
+		if err = os.WriteFile(filepath.Join(dir, statement.FileName(accId, month, format)), buf.Bytes(), 0o644); err != nil {
+			return
+		}
+	}
+	return
+}
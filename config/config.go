@@ -3,15 +3,24 @@ package config
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type config struct {
-	appName       string
-	appPort       int
-	migrationPath string
-	db            databaseConfig
+	appName                string
+	appPort                int
+	migrationPath          string
+	idempotencyKeyTTLHours int
+	passwordResetTTLMins   int
+	notifierFilePath       string
+	db                     databaseConfig
+	jwt                    jwtConfig
+	login                  loginConfig
+	mfa                    mfaConfig
+	standingOrders         standingOrderConfig
+	holds                  holdConfig
 }
 
 var appConfig config
@@ -19,6 +28,26 @@ var appConfig config
 func Load() {
 	viper.SetDefault("APP_NAME", "banking_application")
 	viper.SetDefault("APP_PORT", 8000)
+	viper.SetDefault("IDEMPOTENCY_KEY_TTL_HOURS", 24)
+	viper.SetDefault("PASSWORD_RESET_TTL_MINS", 30)
+	viper.SetDefault("NOTIFIER_FILE_PATH", "")
+	viper.SetDefault("JWT_KEYS_DIR", "")
+	viper.SetDefault("JWT_SECRET", "")
+	viper.SetDefault("JWT_SIGNING_KEY_ID", "default")
+	viper.SetDefault("JWT_TOKEN_TTL_MINS", 5)
+	viper.SetDefault("JWT_REFRESH_TOKEN_TTL_HOURS", 168)
+	viper.SetDefault("LOGIN_MAX_FAILED_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP", 20)
+	viper.SetDefault("LOGIN_LOCKOUT_MINS", 15)
+	viper.SetDefault("LOGIN_BACKOFF_BASE_SECS", 1)
+	viper.SetDefault("MFA_REQUIRED_ROLES", "accountant")
+	viper.SetDefault("MFA_CHALLENGE_TTL_MINS", 5)
+	viper.SetDefault("STANDING_ORDER_MAX_RETRIES", 3)
+	viper.SetDefault("STANDING_ORDER_RETRY_INTERVAL_MINS", 240)
+	viper.SetDefault("STANDING_ORDER_POLL_INTERVAL_MINS", 5)
+	viper.SetDefault("HOLD_DEFAULT_EXPIRY_HOURS", 168)
+	viper.SetDefault("HOLD_MAX_EXPIRY_DAYS", 30)
+	viper.SetDefault("HOLD_SWEEP_INTERVAL_MINS", 15)
 
 	viper.AddConfigPath("./")
 	viper.AddConfigPath("./..")
@@ -30,10 +59,18 @@ func Load() {
 	viper.AutomaticEnv()
 
 	appConfig = config{
-		appName:       readEnvString("APP_NAME"),
-		appPort:       readEnvInt("APP_PORT"),
-		migrationPath: readEnvString("MIGRATION_PATH"),
-		db:            newDatabaseConfig(),
+		appName:                readEnvString("APP_NAME"),
+		appPort:                readEnvInt("APP_PORT"),
+		migrationPath:          readEnvString("MIGRATION_PATH"),
+		idempotencyKeyTTLHours: readEnvInt("IDEMPOTENCY_KEY_TTL_HOURS"),
+		passwordResetTTLMins:   readEnvInt("PASSWORD_RESET_TTL_MINS"),
+		notifierFilePath:       readEnvString("NOTIFIER_FILE_PATH"),
+		db:                     newDatabaseConfig(),
+		jwt:                    newJWTConfig(),
+		login:                  newLoginConfig(),
+		mfa:                    newMFAConfig(),
+		standingOrders:         newStandingOrderConfig(),
+		holds:                  newHoldConfig(),
 	}
 
 }
@@ -50,6 +87,23 @@ func MigrationPath() string {
 	return appConfig.migrationPath
 }
 
+// IdempotencyKeyTTL is how long a stored response is replayed for a reused
+// Idempotency-Key header.
+func IdempotencyKeyTTL() time.Duration {
+	return time.Duration(appConfig.idempotencyKeyTTLHours) * time.Hour
+}
+
+// PasswordResetTTL is how long a password reset token can be used.
+func PasswordResetTTL() time.Duration {
+	return time.Duration(appConfig.passwordResetTTLMins) * time.Minute
+}
+
+// NotifierFilePath is the file notifications to users are written to. When
+// empty they are written to the log.
+func NotifierFilePath() string {
+	return appConfig.notifierFilePath
+}
+
 func checkIfSet(key string) {
 	if !viper.IsSet(key) {
 		panic(fmt.Errorf("key %v is not set", key))
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"time"
+)
+
+type holdConfig struct {
+	defaultExpiryHours int
+	maxExpiryDays      int
+	sweepIntervalMins  int
+}
+
+// DefaultExpiry is how long a hold placed without an expiry lasts.
+func (c holdConfig) DefaultExpiry() time.Duration {
+	return time.Duration(c.defaultExpiryHours) * time.Hour
+}
+
+// MaxExpiry is the longest a hold can be placed for.
+func (c holdConfig) MaxExpiry() time.Duration {
+	return time.Duration(c.maxExpiryDays) * 24 * time.Hour
+}
+
+// SweepInterval is how often expired holds are released.
+func (c holdConfig) SweepInterval() time.Duration {
+	return time.Duration(c.sweepIntervalMins) * time.Minute
+}
+
+func newHoldConfig() holdConfig {
+	return holdConfig{
+		defaultExpiryHours: readEnvInt("HOLD_DEFAULT_EXPIRY_HOURS"),
+		maxExpiryDays:      readEnvInt("HOLD_MAX_EXPIRY_DAYS"),
+		sweepIntervalMins:  readEnvInt("HOLD_SWEEP_INTERVAL_MINS"),
+	}
+}
+
+func Holds() holdConfig {
+	return appConfig.holds
+}
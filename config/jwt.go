@@ -0,0 +1,57 @@
+package config
+
+import (
+	"time"
+)
+
+type jwtConfig struct {
+	keysDir      string
+	secret       string
+	signingKeyID string
+	tokenTTLMins int
+
+	refreshTokenTTLHours int
+}
+
+// KeysDir is a directory of signing keys, one file per key named after its
+// key ID: <kid>.pem for RSA or ECDSA private keys, <kid>.secret for HMAC secrets.
+func (c jwtConfig) KeysDir() string {
+	return c.keysDir
+}
+
+// Secret is an optional HMAC secret, registered with the key ID "default".
+func (c jwtConfig) Secret() string {
+	return c.secret
+}
+
+// SigningKeyID is the ID of the key new tokens are signed with. All other
+// loaded keys are only used to verify tokens, which allows rotating keys.
+func (c jwtConfig) SigningKeyID() string {
+	return c.signingKeyID
+}
+
+func (c jwtConfig) TokenTTL() time.Duration {
+	return time.Duration(c.tokenTTLMins) * time.Minute
+}
+
+// RefreshTokenTTL is how long a refresh token can be exchanged for new tokens.
+// Every exchange issues a new refresh token, so a session stays alive as long
+// as it is used within this time.
+func (c jwtConfig) RefreshTokenTTL() time.Duration {
+	return time.Duration(c.refreshTokenTTLHours) * time.Hour
+}
+
+func newJWTConfig() jwtConfig {
+	return jwtConfig{
+		keysDir:      readEnvString("JWT_KEYS_DIR"),
+		secret:       readEnvString("JWT_SECRET"),
+		signingKeyID: readEnvString("JWT_SIGNING_KEY_ID"),
+		tokenTTLMins: readEnvInt("JWT_TOKEN_TTL_MINS"),
+
+		refreshTokenTTLHours: readEnvInt("JWT_REFRESH_TOKEN_TTL_HOURS"),
+	}
+}
+
+func JWT() jwtConfig {
+	return appConfig.jwt
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"time"
+)
+
+type loginConfig struct {
+	maxFailedAttempts      int
+	maxFailedAttemptsPerIP int
+	lockoutMins            int
+	backoffBaseSecs        int
+}
+
+// MaxFailedAttempts is the number of consecutive failed logins for an email
+// after which it is locked out. 0 disables the lockout.
+func (c loginConfig) MaxFailedAttempts() int {
+	return c.maxFailedAttempts
+}
+
+// MaxFailedAttemptsPerIP is the same limit for failed logins from one client.
+func (c loginConfig) MaxFailedAttemptsPerIP() int {
+	return c.maxFailedAttemptsPerIP
+}
+
+// Lockout is how long a lockout lasts. Failed logins older than this are
+// forgotten.
+func (c loginConfig) Lockout() time.Duration {
+	return time.Duration(c.lockoutMins) * time.Minute
+}
+
+// BackoffBase is how long logins are blocked after the first failure. The
+// wait doubles with every further failure.
+func (c loginConfig) BackoffBase() time.Duration {
+	return time.Duration(c.backoffBaseSecs) * time.Second
+}
+
+func newLoginConfig() loginConfig {
+	return loginConfig{
+		maxFailedAttempts:      readEnvInt("LOGIN_MAX_FAILED_ATTEMPTS"),
+		maxFailedAttemptsPerIP: readEnvInt("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP"),
+		lockoutMins:            readEnvInt("LOGIN_LOCKOUT_MINS"),
+		backoffBaseSecs:        readEnvInt("LOGIN_BACKOFF_BASE_SECS"),
+	}
+}
+
+func Login() loginConfig {
+	return appConfig.login
+}
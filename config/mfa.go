@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+type mfaConfig struct {
+	requiredRoles    []string
+	challengeTTLMins int
+}
+
+// RequiredRoles are the roles that must log in with a TOTP code. Users with
+// one of these roles enrol on their next login.
+func (c mfaConfig) RequiredRoles() []string {
+	return c.requiredRoles
+}
+
+// ChallengeTTL is how long after the password check the TOTP code can be
+// given.
+func (c mfaConfig) ChallengeTTL() time.Duration {
+	return time.Duration(c.challengeTTLMins) * time.Minute
+}
+
+func newMFAConfig() mfaConfig {
+	var roles []string
+	for _, role := range strings.Split(readEnvString("MFA_REQUIRED_ROLES"), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+
+	return mfaConfig{
+		requiredRoles:    roles,
+		challengeTTLMins: readEnvInt("MFA_CHALLENGE_TTL_MINS"),
+	}
+}
+
+func MFA() mfaConfig {
+	return appConfig.mfa
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"time"
+)
+
+type standingOrderConfig struct {
+	maxRetries        int
+	retryIntervalMins int
+	pollIntervalMins  int
+}
+
+// MaxRetries is how many more times a standing order is tried when the
+// account does not have the funds or limits for it. The occurrence is given
+// up after that.
+func (c standingOrderConfig) MaxRetries() int {
+	return c.maxRetries
+}
+
+// RetryInterval is how long to wait before trying a standing order again.
+func (c standingOrderConfig) RetryInterval() time.Duration {
+	return time.Duration(c.retryIntervalMins) * time.Minute
+}
+
+// PollInterval is how often the scheduler looks for standing orders that are
+// due.
+func (c standingOrderConfig) PollInterval() time.Duration {
+	return time.Duration(c.pollIntervalMins) * time.Minute
+}
+
+func newStandingOrderConfig() standingOrderConfig {
+	return standingOrderConfig{
+		maxRetries:        readEnvInt("STANDING_ORDER_MAX_RETRIES"),
+		retryIntervalMins: readEnvInt("STANDING_ORDER_RETRY_INTERVAL_MINS"),
+		pollIntervalMins:  readEnvInt("STANDING_ORDER_POLL_INTERVAL_MINS"),
+	}
+}
+
+func StandingOrders() standingOrderConfig {
+	return appConfig.standingOrders
+}
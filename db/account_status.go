@@ -0,0 +1,234 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+// Account statuses. Which changes between them are allowed is decided by the
+// bank service, the store only enforces what each status allows.
+const (
+	AccountActive       = "active"
+	AccountFrozen       = "frozen"
+	AccountDebitBlocked = "debit_blocked"
+	AccountDormant      = "dormant"
+	AccountClosed       = "closed"
+)
+
+const (
+	setAccountStatusQuery  = `UPDATE accounts SET status=$2, status_reason=$3 WHERE id=$1 AND user_id IS NOT NULL AND status = ANY($4)`
+	getAccountStatusQuery  = `SELECT status FROM accounts WHERE id=$1 AND user_id IS NOT NULL`
+	setOverdraftLimitQuery = `UPDATE accounts SET overdraft_limit=$2 WHERE id=$1`
+)
+
+// AccountClosure is the outcome of closing an account. A remaining balance
+// is paid out in cash, or into the payout account if there is one.
+type AccountClosure struct {
+	AccountID       string       `json:"account_id"`
+	PaidOut         money.Amount `json:"paid_out"`
+	PayoutAccountID *string      `json:"payout_account_id,omitempty"`
+	TransferRef     *string      `json:"transfer_reference,omitempty"`
+}
+
+// allowsCredit reports whether money can be paid into an account.
+func allowsCredit(status string) bool {
+	return status == AccountActive || status == AccountDebitBlocked || status == AccountDormant
+}
+
+// allowsDebit reports whether money can be taken out of an account.
+func allowsDebit(status string) bool {
+	return status == AccountActive
+}
+
+// SetAccountStatus puts the account in the status, if its current status is
+// one of from.
+func (s *store) SetAccountStatus(ctx context.Context, accID string, from []string, status, reason string) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		res, err := s.conn(ctx).ExecContext(ctx, setAccountStatusQuery, accID, status, reason, pq.Array(from))
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n > 0 {
+			return err
+		}
+
+		var current string
+		err = sqlx.GetContext(ctx, s.conn(ctx), &current, getAccountStatusQuery, accID)
+		if err == sql.ErrNoRows {
+			return ErrAccountNotExist
+		}
+		if err != nil {
+			return err
+		}
+		return ErrAccountStatusConflict
+	})
+	return
+}
+
+// SetOverdraftLimit lets the balance of an account go down to -limit. Only
+// open accounts whose type allows money to be taken out can be overdrawn.
+// Lowering the limit below what is already overdrawn does not change the
+// balance, it only stops further debits.
+func (s *store) SetOverdraftLimit(ctx context.Context, accID string, limit money.Amount) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		acc, err := s.lockAccount(ctx, accID)
+		if err != nil {
+			return err
+		}
+		if acc.Status == AccountClosed {
+			return ErrAccountStatusConflict
+		}
+		if !acc.Product.AllowsWithdrawal && !acc.Product.AllowsTransferOut && limit.Sign() > 0 {
+			return ErrOperationNotAllowed
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, setOverdraftLimitQuery, accID, limit)
+		return err
+	})
+	return
+}
+
+// CloseAccount closes an account whose current status is one of from. A
+// remaining balance is only paid out if payOut is set, otherwise the balance
+// must be zero.
+func (s *store) CloseAccount(ctx context.Context, accID string, from []string, payoutAccID string, payOut bool, reason string) (closure AccountClosure, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		var acc, payee Account
+		var err error
+		if payoutAccID != "" {
+			acc, payee, err = s.lockAccountPair(ctx, accID, payoutAccID)
+		} else {
+			acc, err = s.lockAccount(ctx, accID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if !contains(from, acc.Status) {
+			return ErrAccountStatusConflict
+		}
+		// the money of a hold is promised to a payment that has yet to settle
+		if acc.Held.Sign() > 0 {
+			return ErrHoldsActive
+		}
+
+		cur, err := money.LookupCurrency(acc.Currency)
+		if err != nil {
+			return err
+		}
+		closure = AccountClosure{AccountID: accID, PaidOut: money.New(0, cur.Digits)}
+		switch {
+		case acc.Balance.IsZero():
+		case acc.Balance.Sign() < 0 || !payOut:
+			return ErrBalanceNotZero
+		case payoutAccID != "":
+			if !allowsCredit(payee.Status) {
+				return ErrPayeeCreditNotAllowed
+			}
+			if payee.Currency != acc.Currency {
+				return ErrCurrencyMismatch
+			}
+			ref, err := s.payOutToAccount(ctx, acc, payee.ID)
+			if err != nil {
+				return err
+			}
+			closure.PaidOut = acc.Balance
+			closure.PayoutAccountID = &payee.ID
+			closure.TransferRef = &ref
+		default:
+			if err := s.payOutCash(ctx, acc); err != nil {
+				return err
+			}
+			closure.PaidOut = acc.Balance
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, setAccountStatusQuery, accID, AccountClosed, reason, pq.Array(from))
+		if err != nil {
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, cancelAccountStandingOrdersQuery, accID, time.Now())
+		return err
+	})
+	if err != nil {
+		closure = AccountClosure{}
+	}
+	return
+}
+
+// payOutCash pays the whole balance of the account out in cash.
+func (s *store) payOutCash(ctx context.Context, acc Account) (err error) {
+	entry := newJournalEntry("Closing payout",
+		Posting{AccountID: acc.ID, Amount: acc.Balance.Neg()},
+		Posting{AccountID: internalAccountID(CashAccountID, acc.Currency), Amount: acc.Balance},
+	)
+	balances, err := s.postJournalEntry(ctx, entry)
+	if err != nil {
+		return
+	}
+
+	err = s.AddTransaction(ctx, Transaction{
+		ID:             uuidgen.New(),
+		Type:           "Debit",
+		Amount:         acc.Balance,
+		Balance:        balances[acc.ID],
+		CreatedAt:      entry.CreatedAt,
+		AccountID:      acc.ID,
+		JournalEntryID: &entry.ID,
+	})
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("Paid out amount: %v, from closed account: %v in cash\n", acc.Balance, acc.ID)
+	return
+}
+
+// payOutToAccount transfers the whole balance of the account to the payee.
+func (s *store) payOutToAccount(ctx context.Context, acc Account, payeeID string) (ref string, err error) {
+	entry := newJournalEntry("Closing payout",
+		Posting{AccountID: acc.ID, Amount: acc.Balance.Neg()},
+		Posting{AccountID: payeeID, Amount: acc.Balance},
+	)
+	balances, err := s.postJournalEntry(ctx, entry)
+	if err != nil {
+		return
+	}
+
+	ref = uuidgen.New()
+	transactions := []Transaction{
+		{Type: "Debit", AccountID: acc.ID, Balance: balances[acc.ID]},
+		{Type: "Credit", AccountID: payeeID, Balance: balances[payeeID]},
+	}
+	for _, t := range transactions {
+		t.ID = uuidgen.New()
+		t.Amount = acc.Balance
+		t.CreatedAt = entry.CreatedAt
+		t.TransferRef = &ref
+		t.JournalEntryID = &entry.ID
+		if err = s.AddTransaction(ctx, t); err != nil {
+			return
+		}
+	}
+
+	fmt.Printf("Paid out amount: %v, from closed account: %v to account: %v. Reference: %v\n",
+		acc.Balance, acc.ID, payeeID, ref)
+	return
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
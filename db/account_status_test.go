@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_SetAccountStatus() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(10000, 2))
+	otherID, _ := sts.createFundedAccount(money.New(0, 2))
+
+	sts.Require().NoError(sts.store.SetAccountStatus(ctx, accID, []string{AccountActive}, AccountDebitBlocked, "court order"))
+	err := sts.store.SetAccountStatus(ctx, accID, []string{AccountActive}, AccountDormant, "no activity")
+	sts.Equal(ErrAccountStatusConflict, err)
+	sts.Equal(ErrAccountNotExist, sts.store.SetAccountStatus(ctx, CashAccountID, []string{AccountActive}, AccountFrozen, "test"))
+
+	acc, err := sts.store.GetAccountDetails(ctx, accID, userID)
+	sts.Require().NoError(err)
+	sts.Equal(AccountDebitBlocked, acc.Status)
+	sts.Equal("court order", *acc.StatusReason)
+
+	// a debit blocked account still receives money
+	_, err = sts.store.DepositAmount(ctx, accID, userID, money.New(100, 2))
+	sts.NoError(err)
+	_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(100, 2))
+	sts.Equal(ErrDebitNotAllowed, err)
+	_, err = sts.store.TransferAmount(ctx, accID, otherID, userID, money.New(100, 2))
+	sts.Equal(ErrDebitNotAllowed, err)
+
+	// a frozen account does not
+	sts.Require().NoError(sts.store.SetAccountStatus(ctx, accID, []string{AccountDebitBlocked}, AccountFrozen, "fraud"))
+	_, err = sts.store.DepositAmount(ctx, accID, userID, money.New(100, 2))
+	sts.Equal(ErrCreditNotAllowed, err)
+}
+
+func (sts *StoreTestSuite) Test_store_CloseAccount() {
+	ctx := context.Background()
+	from := []string{AccountActive, AccountDormant}
+	accID, _ := sts.createFundedAccount(money.New(10000, 2))
+	payeeID, _ := sts.createFundedAccount(money.New(0, 2))
+
+	_, err := sts.store.CloseAccount(ctx, accID, from, "", false, "customer request")
+	sts.Equal(ErrBalanceNotZero, err)
+
+	closure, err := sts.store.CloseAccount(ctx, accID, from, payeeID, true, "customer request")
+	sts.Require().NoError(err)
+	sts.Equal("100", closure.PaidOut.Round(0).String())
+	sts.Equal(payeeID, *closure.PayoutAccountID)
+	sts.True(sts.balance(accID).IsZero())
+	sts.Equal("100", sts.balance(payeeID).Round(0).String())
+
+	// closed is final
+	_, err = sts.store.CloseAccount(ctx, accID, from, "", true, "again")
+	sts.Equal(ErrAccountStatusConflict, err)
+	_, err = sts.store.TransferAmount(ctx, payeeID, accID, AnyOwner, money.New(100, 2))
+	sts.Equal(ErrPayeeCreditNotAllowed, err)
+}
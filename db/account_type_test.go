@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_OpenAccount() {
+	ctx := context.Background()
+	u := sts.createCustomer()
+
+	fd := Account{ID: uuidgen.New(), Balance: money.New(0, 2), Type: "fixed_deposit"}
+	sts.Require().NoError(sts.store.OpenAccount(ctx, u.ID, fd))
+
+	err := sts.store.OpenAccount(ctx, u.ID, Account{ID: uuidgen.New(), Balance: money.New(0, 2), Type: "internal"})
+	sts.Equal(ErrAccountTypeNotExist, err)
+	err = sts.store.OpenAccount(ctx, "0", Account{ID: uuidgen.New(), Balance: money.New(0, 2), Type: "current"})
+	sts.Equal(ErrUserNotExist, err)
+
+	accounts, err := sts.store.GetUserAccounts(ctx, u.ID)
+	sts.Require().NoError(err)
+	sts.Require().Len(accounts, 2)
+	sts.Equal("fixed_deposit", accounts[0].Type)
+	sts.Equal("savings", accounts[1].Type)
+
+	// money can be paid into a fixed deposit but not taken out
+	_, err = sts.store.DepositAmount(ctx, fd.ID, u.ID, money.New(10000, 2))
+	sts.Require().NoError(err)
+	_, err = sts.store.WithdrawAmount(ctx, fd.ID, u.ID, money.New(100, 2))
+	sts.Equal(ErrOperationNotAllowed, err)
+	_, err = sts.store.TransferAmount(ctx, fd.ID, accounts[1].ID, u.ID, money.New(100, 2))
+	sts.Equal(ErrOperationNotAllowed, err)
+	sts.Equal("100", sts.balance(fd.ID).Round(0).String())
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	createAuditEntryQuery = `INSERT INTO audit_log(created_at, action, actor_id, subject, ip, details) VALUES ($1, $2, $3, $4, $5, $6)`
+	getAuditEntriesQuery  = `SELECT * FROM audit_log WHERE ($1 = '' OR action=$1) AND ($2 = '' OR subject=$2) AND ($3::INTEGER IS NULL OR actor_id=$3) AND id < $4 ORDER BY id DESC LIMIT $5`
+)
+
+// AuditEntry records a security relevant action: who did what, to whom and
+// from where.
+type AuditEntry struct {
+	ID        int64     `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	Action    string    `json:"action" db:"action"`
+	ActorID   *string   `json:"actor_id,omitempty" db:"actor_id"`
+	Subject   string    `json:"subject" db:"subject"`
+	IP        string    `json:"ip,omitempty" db:"ip"`
+	Details   string    `json:"details,omitempty" db:"details"`
+}
+
+// AuditFilter selects audit entries. Empty fields match every entry. Entries
+// are returned newest first, starting before the entry with ID BeforeID when
+// it is set.
+type AuditFilter struct {
+	Action   string
+	ActorID  *string
+	Subject  string
+	BeforeID int64
+	Limit    int
+}
+
+func (s *store) AddAuditEntry(ctx context.Context, e AuditEntry) (err error) {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		_, err := s.conn(ctx).ExecContext(ctx, createAuditEntryQuery, e.CreatedAt, e.Action, e.ActorID, e.Subject, e.IP, e.Details)
+		return err
+	})
+	return
+}
+
+func (s *store) GetAuditEntries(ctx context.Context, f AuditFilter) (entries []AuditEntry, err error) {
+	beforeID := f.BeforeID
+	if beforeID <= 0 {
+		beforeID = math.MaxInt64
+	}
+
+	entries = make([]AuditEntry, 0)
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.SelectContext(ctx, s.conn(ctx), &entries, getAuditEntriesQuery, f.Action, f.Subject, f.ActorID, beforeID, f.Limit)
+	})
+	return
+}
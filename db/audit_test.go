@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_AnyOwner() {
+	ctx := context.Background()
+	accID, _ := sts.createFundedAccount(money.New(10000, 2))
+
+	// the account cannot be reached with another user's ID
+	_, err := sts.store.GetAccountDetails(ctx, accID, "0")
+	sts.Equal(ErrAccountNotExist, err)
+	_, err = sts.store.DepositAmount(ctx, accID, "0", money.New(100, 2))
+	sts.Equal(ErrAccountNotExist, err)
+
+	acc, err := sts.store.GetAccountDetails(ctx, accID, AnyOwner)
+	sts.Require().NoError(err)
+	sts.Equal(accID, acc.ID)
+
+	_, err = sts.store.WithdrawAmount(ctx, accID, AnyOwner, money.New(2500, 2))
+	sts.Require().NoError(err)
+	sts.Equal("75", sts.balance(accID).Round(0).String())
+}
+
+func (sts *StoreTestSuite) Test_store_GetAuditEntries() {
+	ctx := context.Background()
+	u := sts.createCustomer()
+	subject := uuidgen.New()
+
+	for _, action := range []string{"staff_account_viewed", "staff_deposit", "staff_deposit"} {
+		sts.Require().NoError(sts.store.AddAuditEntry(ctx, AuditEntry{Action: action, ActorID: &u.ID, Subject: subject, Details: "reason: test"}))
+	}
+
+	entries, err := sts.store.GetAuditEntries(ctx, AuditFilter{Subject: subject, Limit: 10})
+	sts.Require().NoError(err)
+	sts.Require().Len(entries, 3)
+	sts.Equal("staff_deposit", entries[0].Action)
+	sts.Equal(u.ID, *entries[0].ActorID)
+
+	entries, err = sts.store.GetAuditEntries(ctx, AuditFilter{Action: "staff_deposit", ActorID: &u.ID, Subject: subject, Limit: 1})
+	sts.Require().NoError(err)
+	sts.Require().Len(entries, 1)
+
+	// the next page starts before the last entry seen
+	entries, err = sts.store.GetAuditEntries(ctx, AuditFilter{Subject: subject, BeforeID: entries[0].ID, Limit: 10})
+	sts.Require().NoError(err)
+	sts.Len(entries, 2)
+}
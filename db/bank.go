@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	uuidgen "github.com/pborman/uuid"
-	"github.com/pkg/errors"
+
+	"example.com/banking/money"
 )
 
 const (
@@ -15,16 +17,34 @@ const (
 	getUserByEmailAndPasswordQuery = `SELECT * FROM users WHERE email=$1 and password=crypt($2, password)`
 	deleteUserByIDQuery            = `DELETE FROM users WHERE id=$1`
 
-	createAccountQuery               = `INSERT INTO accounts(id, balance, user_id) VALUES ($1, $2, $3)`
-	listAccountsQuery                = `SELECT accounts.id, accounts.balance, users.email, users.phone_number from accounts inner join users on accounts.user_id=users.id`
-	getAccountByAccIDQuery           = `SELECT accounts.id, accounts.balance, users.email, users.phone_number from accounts inner join users on accounts.user_id=users.id where accounts.id=$1 and accounts.user_id=$2`
-	updateAccountBalanceByAccIDQuery = `UPDATE accounts SET balance=$1 WHERE id=$2`
-	deleteAccountByIDQuery           = `DELETE FROM accounts WHERE id=$1`
-
-	createTransactionQuery      = `INSERT INTO transactions(id, type, amount, balance, created_at, account_id) VALUES ($1, $2, $3, $4, $5, $6)`
-	getTransactionsByAccIDQuery = `SELECT * FROM transactions WHERE account_id=$1`
+	createAccountQuery     = `INSERT INTO accounts(id, balance, user_id, type, currency) VALUES ($1, $2, $3, $4, $5)`
+	listAccountsQuery      = `SELECT accounts.id, accounts.balance, accounts.overdraft_limit, accounts.held, accounts.balance + accounts.overdraft_limit - accounts.held AS available_balance, accounts.type, accounts.currency, accounts.status, accounts.status_reason, users.email, users.phone_number from accounts inner join users on accounts.user_id=users.id`
+	getAccountByAccIDQuery = `SELECT accounts.id, accounts.balance, accounts.overdraft_limit, accounts.held, accounts.balance + accounts.overdraft_limit - accounts.held AS available_balance, accounts.type, accounts.currency, accounts.status, accounts.status_reason, users.email, users.phone_number from accounts inner join users on accounts.user_id=users.id where accounts.id=$1 and accounts.user_id=$2`
+	getAnyAccountByIDQuery = `SELECT accounts.id, accounts.balance, accounts.overdraft_limit, accounts.held, accounts.balance + accounts.overdraft_limit - accounts.held AS available_balance, accounts.type, accounts.currency, accounts.status, accounts.status_reason, users.email, users.phone_number from accounts inner join users on accounts.user_id=users.id where accounts.id=$1`
+	getUserAccountsQuery   = `SELECT id, balance, overdraft_limit, held, user_id, type, currency, status, status_reason FROM accounts WHERE user_id=$1 ORDER BY type, id`
+	deleteAccountByIDQuery = `DELETE FROM accounts WHERE id=$1`
+	getAccountTypeQuery    = `SELECT name, description, allows_withdrawal, allows_transfer_out FROM account_types WHERE name=$1 AND name <> 'internal'`
+
+	getAccountForUpdateQuery = `SELECT accounts.id, accounts.balance, accounts.overdraft_limit, accounts.held, accounts.user_id, accounts.type, accounts.currency, accounts.status,
+		account_types.allows_withdrawal AS "product.allows_withdrawal", account_types.allows_transfer_out AS "product.allows_transfer_out"
+		FROM accounts INNER JOIN account_types ON account_types.name=accounts.type
+		WHERE accounts.id=$1 AND accounts.user_id IS NOT NULL FOR UPDATE OF accounts`
+
+	// a transaction is in the currency of its account unless told otherwise
+	createTransactionQuery = `INSERT INTO transactions(id, type, amount, balance, created_at, account_id, transfer_ref, journal_entry_id, linked_transaction_id,
+		currency, fx_rate, counter_amount, counter_currency, reason_code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE(NULLIF($10, ''), (SELECT currency FROM accounts WHERE id=$6)), $11, $12, $13, $14)`
+	// transactions show how much of them was reversed
+	getTransactionsByAccIDQuery = `SELECT transactions.*, (SELECT SUM(reversals.amount) FROM transactions reversals
+		WHERE reversals.linked_transaction_id=transactions.id AND reversals.type IN ('Credit Reversal', 'Debit Reversal')) AS reversed_amount
+		FROM transactions WHERE account_id=$1`
 )
 
+// AnyOwner is passed as the user ID by staff operating on customer accounts.
+// Account operations then skip the check that the account belongs to the
+// user. It can never match a real user ID, which is numeric.
+const AnyOwner = "*"
+
 type User struct {
 	ID          string `json:"id" db:"id"`
 	Email       string `json:"email" db:"email"`
@@ -34,24 +54,81 @@ type User struct {
 }
 
 type Account struct {
-	ID      string  `json:"account_id" db:"id"`
-	Balance float32 `json:"balance" db:"balance"`
-	UserID  string  `json:"-" db:"user_id"`
+	ID string `json:"account_id" db:"id"`
+	// Balance is the ledger balance, negative while the account is overdrawn
+	Balance money.Amount `json:"balance" db:"balance"`
+	// OverdraftLimit is how far the balance may go below zero
+	OverdraftLimit money.Amount `json:"overdraft_limit" db:"overdraft_limit"`
+	// Held is reserved by the active holds on the account
+	Held   money.Amount `json:"held" db:"held"`
+	UserID string       `json:"-" db:"user_id"`
+	Type   string       `json:"account_type" db:"type"`
+	// Currency is the ISO 4217 code of the money the account holds
+	Currency string `json:"currency" db:"currency"`
+	Status   string `json:"status" db:"status"`
+	// StatusReason is why the account was last put in its status
+	StatusReason *string `json:"status_reason,omitempty" db:"status_reason"`
+	// Product holds the rules of the account type, it is only read together
+	// with a locked account
+	Product AccountType `json:"-" db:"product"`
+}
+
+// AccountType is an account product. What customers can do with an account
+// depends on its type.
+type AccountType struct {
+	Name              string `json:"name" db:"name"`
+	Description       string `json:"description" db:"description"`
+	AllowsWithdrawal  bool   `json:"allows_withdrawal" db:"allows_withdrawal"`
+	AllowsTransferOut bool   `json:"allows_transfer_out" db:"allows_transfer_out"`
 }
 
 type UserAccountDetails struct {
 	Account
-	Email       string `json:"email" db:"email"`
-	PhoneNumber string `json:"phone_number" db:"phone_number"`
+	// AvailableBalance is what can be withdrawn, including the overdraft and
+	// less the holds
+	AvailableBalance money.Amount `json:"available_balance" db:"available_balance"`
+	Email            string       `json:"email" db:"email"`
+	PhoneNumber      string       `json:"phone_number" db:"phone_number"`
 }
 
 type Transaction struct {
-	ID        string  `json:"-" db:"id"`
-	Type      string  `json:"type" db:"type"`
-	Amount    float32 `json:"amount" db:"amount"`
-	Balance   float32 `json:"balance" db:"balance"`
-	CreatedAt string  `json:"created_at" db:"created_at"`
-	AccountID string  `json:"-" db:"account_id"`
+	ID             string       `json:"transaction_id" db:"id"`
+	Type           string       `json:"type" db:"type"`
+	Amount         money.Amount `json:"amount" db:"amount"`
+	Balance        money.Amount `json:"balance" db:"balance"`
+	CreatedAt      string       `json:"created_at" db:"created_at"`
+	AccountID      string       `json:"-" db:"account_id"`
+	TransferRef    *string      `json:"transfer_reference,omitempty" db:"transfer_ref"`
+	JournalEntryID *string      `json:"-" db:"journal_entry_id"`
+	// LinkedTransactionID is the transaction a fee was charged for, or that a
+	// reversal reverses
+	LinkedTransactionID *string `json:"linked_transaction_id,omitempty" db:"linked_transaction_id"`
+	Currency            string  `json:"currency,omitempty" db:"currency"`
+	// FXRate is the rate a transfer between currencies was exchanged at,
+	// CounterAmount what it came to in CounterCurrency on the other side
+	FXRate          *money.Amount `json:"fx_rate,omitempty" db:"fx_rate"`
+	CounterAmount   *money.Amount `json:"counter_amount,omitempty" db:"counter_amount"`
+	CounterCurrency *string       `json:"counter_currency,omitempty" db:"counter_currency"`
+	// ReasonCode is why a reversal was posted
+	ReasonCode *string `json:"reason_code,omitempty" db:"reason_code"`
+	// ReversedAmount is how much of the transaction reversals gave back, it
+	// is only read with the history of an account
+	ReversedAmount *money.Amount `json:"reversed_amount,omitempty" db:"reversed_amount"`
+}
+
+type Transfer struct {
+	Reference     string       `json:"reference"`
+	FromAccountID string       `json:"from_account_id"`
+	ToAccountID   string       `json:"to_account_id"`
+	Amount        money.Amount `json:"amount"`
+	Currency      string       `json:"currency"`
+	// ToAmount is what the payee got, in ToCurrency. It differs from
+	// Amount when the accounts hold different currencies, exchanged at FXRate
+	ToAmount    money.Amount  `json:"to_amount"`
+	ToCurrency  string        `json:"to_currency"`
+	FXRate      *money.Amount `json:"fx_rate,omitempty"`
+	FromBalance money.Amount  `json:"from_balance"`
+	ToBalance   money.Amount  `json:"to_balance"`
 }
 
 func (s *store) GetUserByEmailAndPassword(ctx context.Context, email string, password string) (u User, err error) {
@@ -67,33 +144,20 @@ func (s *store) GetUserByEmailAndPassword(ctx context.Context, email string, pas
 }
 
 func (s *store) CreateAccount(ctx context.Context, u User, acc Account) (err error) {
-	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
-	if err != nil {
-		return
-	}
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		var user_id int64
 
-	defer func() {
-		if err != nil {
-			e := tx.Rollback()
-			if e != nil {
-				err = errors.WithStack(e)
-				return
-			}
+		if _, err := s.getAccountType(ctx, acc.Type); err != nil {
+			return err
 		}
-		tx.Commit()
-	}()
-
-	ctxWithTx := newContext(ctx, tx)
-	err = WithDefaultTimeout(ctxWithTx, func(ctx context.Context) error {
-		var user_id int64
 
 		// Create user
-		if err := s.db.GetContext(ctx, &user_id, createUserQuery, u.Email, u.PhoneNumber, u.Password, u.Type); err != nil {
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &user_id, createUserQuery, u.Email, u.PhoneNumber, u.Password, u.Type); err != nil {
 			return err
 		}
 
 		// Create user account
-		if _, err := s.db.Exec(createAccountQuery, acc.ID, acc.Balance, user_id); err != nil {
+		if _, err := s.conn(ctx).ExecContext(ctx, createAccountQuery, acc.ID, acc.Balance, user_id, acc.Type, currencyOrDefault(acc.Currency)); err != nil {
 			return err
 		}
 
@@ -102,10 +166,49 @@ func (s *store) CreateAccount(ctx context.Context, u User, acc Account) (err err
 	return
 }
 
+// OpenAccount opens another account for an existing customer.
+func (s *store) OpenAccount(ctx context.Context, userID string, acc Account) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		if _, err := s.getAccountType(ctx, acc.Type); err != nil {
+			return err
+		}
+
+		var u User
+		err := sqlx.GetContext(ctx, s.conn(ctx), &u, getUserByIDQuery, userID)
+		if err == sql.ErrNoRows || (err == nil && u.Type != "customer") {
+			return ErrUserNotExist
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, createAccountQuery, acc.ID, acc.Balance, userID, acc.Type, currencyOrDefault(acc.Currency))
+		return err
+	})
+	return
+}
+
+func (s *store) getAccountType(ctx context.Context, name string) (t AccountType, err error) {
+	err = sqlx.GetContext(ctx, s.conn(ctx), &t, getAccountTypeQuery, name)
+	if err == sql.ErrNoRows {
+		return t, ErrAccountTypeNotExist
+	}
+	return
+}
+
+// GetUserAccounts lists the accounts of a customer.
+func (s *store) GetUserAccounts(ctx context.Context, userID string) (accounts []Account, err error) {
+	accounts = make([]Account, 0)
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.SelectContext(ctx, s.conn(ctx), &accounts, getUserAccountsQuery, userID)
+	})
+	return
+}
+
 func (s *store) GetAccountList(ctx context.Context) (accounts []UserAccountDetails, err error) {
 
 	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
-		return s.db.SelectContext(ctx, &accounts, listAccountsQuery)
+		return sqlx.SelectContext(ctx, s.conn(ctx), &accounts, listAccountsQuery)
 	})
 
 	if err == sql.ErrNoRows {
@@ -118,7 +221,10 @@ func (s *store) GetAccountList(ctx context.Context) (accounts []UserAccountDetai
 func (s *store) GetAccountDetails(ctx context.Context, accID, userID string) (acc UserAccountDetails, err error) {
 	fmt.Println("accID:", accID, "userID:", userID)
 	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
-		return s.db.GetContext(ctx, &acc, getAccountByAccIDQuery, accID, userID)
+		if userID == AnyOwner {
+			return sqlx.GetContext(ctx, s.conn(ctx), &acc, getAnyAccountByIDQuery, accID)
+		}
+		return sqlx.GetContext(ctx, s.conn(ctx), &acc, getAccountByAccIDQuery, accID, userID)
 	})
 
 	if err == sql.ErrNoRows {
@@ -130,138 +236,301 @@ func (s *store) GetAccountDetails(ctx context.Context, accID, userID string) (ac
 
 func (s *store) AddTransaction(ctx context.Context, t Transaction) (err error) {
 	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
-		_, err = s.db.Exec(createTransactionQuery, t.ID, t.Type, t.Amount, t.Balance, t.CreatedAt, t.AccountID)
+		_, err = s.conn(ctx).ExecContext(ctx, createTransactionQuery, t.ID, t.Type, t.Amount, t.Balance, t.CreatedAt, t.AccountID, t.TransferRef, t.JournalEntryID, t.LinkedTransactionID,
+			t.Currency, t.FXRate, t.CounterAmount, t.CounterCurrency, t.ReasonCode)
 		return err
 	})
 
 	return
 }
 
-func (s *store) DepositAmount(ctx context.Context, accID, userID string, amount float32) (err error) {
-	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
-	if err != nil {
+// lockAccount reads a customer account and locks its row until the
+// surrounding transaction ends, so that concurrent balance changes to the
+// account are applied one after the other.
+func (s *store) lockAccount(ctx context.Context, accID string) (acc Account, err error) {
+	err = sqlx.GetContext(ctx, s.conn(ctx), &acc, getAccountForUpdateQuery, accID)
+	if err == sql.ErrNoRows {
+		return acc, ErrAccountNotExist
+	}
+	return
+}
+
+// lockAccountPair locks the accounts money moves between, always in the same
+// order so that two opposite transfers cannot deadlock each other.
+func (s *store) lockAccountPair(ctx context.Context, fromAccID, toAccID string) (from, to Account, err error) {
+	lockPayee := func() (err error) {
+		if to, err = s.lockAccount(ctx, toAccID); err == ErrAccountNotExist {
+			err = ErrPayeeNotExist
+		}
 		return
 	}
 
-	defer func() {
-		if err != nil {
-			e := tx.Rollback()
-			if e != nil {
-				err = errors.WithStack(e)
-				return
-			}
+	if fromAccID < toAccID {
+		if from, err = s.lockAccount(ctx, fromAccID); err == nil {
+			err = lockPayee()
 		}
-		tx.Commit()
-	}()
+	} else {
+		if err = lockPayee(); err == nil {
+			from, err = s.lockAccount(ctx, fromAccID)
+		}
+	}
+	return
+}
 
-	ctxWithTx := newContext(ctx, tx)
-	err = WithDefaultTimeout(ctxWithTx, func(ctx context.Context) error {
+// available returns what can be taken out of the account: its balance and
+// the overdraft on top of it, less what holds reserve.
+func (acc Account) available() money.Amount {
+	return acc.Balance.Add(acc.OverdraftLimit).Sub(acc.Held)
+}
+
+// ownedBy reports whether acc belongs to the user, which is always the case
+// for AnyOwner.
+func ownedBy(acc Account, userID string) bool {
+	return userID == AnyOwner || acc.UserID == userID
+}
+
+// DepositAmount pays amount into the account, together with the fees the
+// deposit triggers. The balance after the deposit, overdraft included, must
+// cover the fees.
+func (s *store) DepositAmount(ctx context.Context, accID, userID string, amount money.Amount) (fees []Transaction, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
 		// get the account details
-		acc, err := s.GetAccountDetails(ctx, accID, userID)
+		acc, err := s.lockAccount(ctx, accID)
 		if err != nil {
 			return err
 		}
+		if !ownedBy(acc, userID) {
+			return ErrAccountNotExist
+		}
+		if !allowsCredit(acc.Status) {
+			return ErrCreditNotAllowed
+		}
 
-		// update the user balance
-		balance := acc.Balance + amount
+		rules, fee, err := s.transactionFees(ctx, acc, FeeTriggerDeposit, acc.Balance.Add(amount))
+		if err != nil {
+			return err
+		}
+		if acc.available().Add(amount).Cmp(fee) < 0 {
+			fmt.Printf("amount %v cannot be deposited in account %v. insufficient funds for fees: %v",
+				amount, accID, fee)
+			return ErrInsufficientFunds
+		}
 
-		// update user details
-		if _, err = s.db.Exec(updateAccountBalanceByAccIDQuery, balance, accID); err != nil {
+		// post the deposit to the ledger, cash in hand moves into the account
+		entry := newJournalEntry("Deposit",
+			Posting{AccountID: acc.ID, Amount: amount},
+			Posting{AccountID: internalAccountID(CashAccountID, acc.Currency), Amount: amount.Neg()},
+		)
+		balances, err := s.postJournalEntry(ctx, entry)
+		if err != nil {
 			return err
 		}
+		balance := balances[acc.ID]
 
 		// Add a transaction
 		t := Transaction{
-			ID:        uuidgen.New(),
-			Type:      "Credit",
-			Amount:    amount,
-			Balance:   balance,
-			CreatedAt: time.Now().Format("2006-01-02 15:04:05.000"),
-			AccountID: accID,
+			ID:             uuidgen.New(),
+			Type:           "Credit",
+			Amount:         amount,
+			Balance:        balance,
+			CreatedAt:      entry.CreatedAt,
+			AccountID:      accID,
+			JournalEntryID: &entry.ID,
 		}
 		if err = s.AddTransaction(ctx, t); err != nil {
 			return err
 		}
 
 		fmt.Printf("Credited amount: %v, in account: %v. Balance: %v\n", amount, accID, balance)
+		fees, err = s.chargeFees(ctx, acc, rules, t.ID)
 		return err
 	})
-	return
-}
-
-func (s *store) WithdrawAmount(ctx context.Context, accID, userID string, amount float32) (err error) {
-	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
 	if err != nil {
-		return
+		fees = nil
 	}
+	return
+}
 
-	defer func() {
+// WithdrawAmount pays amount out of the account, together with the fees the
+// withdrawal triggers. The balance, overdraft included, must cover the
+// amount and the fees.
+func (s *store) WithdrawAmount(ctx context.Context, accID, userID string, amount money.Amount) (fees []Transaction, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		acc, err := s.lockAccount(ctx, accID)
 		if err != nil {
-			e := tx.Rollback()
-			if e != nil {
-				err = errors.WithStack(e)
-				return
-			}
+			return err
+		}
+		if !ownedBy(acc, userID) {
+			return ErrAccountNotExist
+		}
+		if !acc.Product.AllowsWithdrawal {
+			return ErrOperationNotAllowed
+		}
+		if !allowsDebit(acc.Status) {
+			return ErrDebitNotAllowed
+		}
 
-			tx.Commit()
+		if err := s.checkDebitLimits(ctx, acc.ID, amount); err != nil {
+			return err
 		}
-	}()
 
-	ctxWithTx := newContext(ctx, tx)
-	err = WithDefaultTimeout(ctxWithTx, func(ctx context.Context) error {
-		acc, err := s.GetAccountDetails(ctx, accID, userID)
+		rules, fee, err := s.transactionFees(ctx, acc, FeeTriggerWithdrawal, acc.Balance.Sub(amount))
 		if err != nil {
 			return err
 		}
 
-		// verify if amount can be debited
-		if acc.Balance < amount {
+		// verify if amount and its fees can be debited, the balance may go
+		// down to the overdraft limit
+		if acc.available().Cmp(amount.Add(fee)) < 0 {
 			fmt.Printf("amount %v cannot be debited from account %v. insufficient funds: %v",
-				amount, accID, acc.Balance)
+				amount, accID, acc.available())
 			return ErrInsufficientFunds
 		}
 
-		// update the user balance
-		balance := acc.Balance - amount
-
-		// update user details
-		if _, err = s.db.Exec(updateAccountBalanceByAccIDQuery, balance, accID); err != nil {
+		// post the withdrawal to the ledger, the account pays out cash
+		entry := newJournalEntry("Withdrawal",
+			Posting{AccountID: acc.ID, Amount: amount.Neg()},
+			Posting{AccountID: internalAccountID(CashAccountID, acc.Currency), Amount: amount},
+		)
+		balances, err := s.postJournalEntry(ctx, entry)
+		if err != nil {
 			return err
 		}
+		balance := balances[acc.ID]
 
-		// sdd a transaction
+		// add a transaction
 		t := Transaction{
-			ID:        uuidgen.New(),
-			Type:      "Debit",
-			Amount:    amount,
-			Balance:   balance,
-			CreatedAt: time.Now().Format("2006-01-02 15:04:05.000"),
-			AccountID: accID,
+			ID:             uuidgen.New(),
+			Type:           "Debit",
+			Amount:         amount,
+			Balance:        balance,
+			CreatedAt:      entry.CreatedAt,
+			AccountID:      accID,
+			JournalEntryID: &entry.ID,
 		}
 		if err = s.AddTransaction(ctx, t); err != nil {
 			return err
 		}
 
 		fmt.Printf("Debited amount: %v, from account: %v. Balance: %v\n", amount, accID, balance)
+		fees, err = s.chargeFees(ctx, acc, rules, t.ID)
 		return err
 	})
+	if err != nil {
+		fees = nil
+	}
 	return
 }
 
-func (s *store) GetTransactions(ctx context.Context, accID, userID string) (transactions []Transaction, err error) {
+func (s *store) TransferAmount(ctx context.Context, fromAccID, toAccID, userID string, amount money.Amount) (t Transfer, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		from, to, err := s.lockAccountPair(ctx, fromAccID, toAccID)
+		if err != nil {
+			return err
+		}
 
-	if _, err = s.GetAccountDetails(ctx, accID, userID); err != nil {
-		return
-	}
+		if !ownedBy(from, userID) {
+			return ErrAccountNotExist
+		}
+		if !from.Product.AllowsTransferOut {
+			return ErrOperationNotAllowed
+		}
+		if !allowsDebit(from.Status) {
+			return ErrDebitNotAllowed
+		}
+		if !allowsCredit(to.Status) {
+			return ErrPayeeCreditNotAllowed
+		}
+		if err := s.checkDebitLimits(ctx, fromAccID, amount); err != nil {
+			return err
+		}
 
-	err = s.db.SelectContext(ctx, &transactions, getTransactionsByAccIDQuery, accID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return transactions, ErrTransactionNotExist
+		// verify if amount can be debited
+		if from.available().Cmp(amount) < 0 {
+			fmt.Printf("amount %v cannot be transferred from account %v. insufficient funds: %v",
+				amount, fromAccID, from.available())
+			return ErrInsufficientFunds
 		}
-		return
-	}
 
-	fmt.Println("Transactions details:", transactions)
+		t = Transfer{
+			Reference:     uuidgen.New(),
+			FromAccountID: fromAccID,
+			ToAccountID:   toAccID,
+			Amount:        amount,
+			Currency:      from.Currency,
+			ToAmount:      amount,
+			ToCurrency:    to.Currency,
+		}
+
+		// between currencies the money goes through the positions of the
+		// bank in both, so that each side of the entry stays in one currency
+		postings := []Posting{
+			{AccountID: fromAccID, Amount: amount.Neg()},
+			{AccountID: toAccID, Amount: amount},
+		}
+		if from.Currency != to.Currency {
+			toAmount, rate, err := s.exchange(ctx, amount, from.Currency, to.Currency, time.Now())
+			if err != nil {
+				return err
+			}
+			t.ToAmount, t.FXRate = toAmount, &rate
+
+			postings = []Posting{
+				{AccountID: fromAccID, Amount: amount.Neg()},
+				{AccountID: fxPositionAccountIDs[from.Currency], Amount: amount},
+				{AccountID: fxPositionAccountIDs[to.Currency], Amount: toAmount.Neg()},
+				{AccountID: toAccID, Amount: toAmount},
+			}
+		}
+
+		entry := newJournalEntry("Transfer", postings...)
+		balances, err := s.postJournalEntry(ctx, entry)
+		if err != nil {
+			return err
+		}
+		t.FromBalance, t.ToBalance = balances[fromAccID], balances[toAccID]
+
+		// add the linked debit and credit transactions
+		debit := Transaction{
+			ID:             uuidgen.New(),
+			Type:           "Debit",
+			Amount:         amount,
+			Balance:        t.FromBalance,
+			CreatedAt:      entry.CreatedAt,
+			AccountID:      fromAccID,
+			TransferRef:    &t.Reference,
+			JournalEntryID: &entry.ID,
+			Currency:       t.Currency,
+		}
+		credit := Transaction{
+			ID:             uuidgen.New(),
+			Type:           "Credit",
+			Amount:         t.ToAmount,
+			Balance:        t.ToBalance,
+			CreatedAt:      entry.CreatedAt,
+			AccountID:      toAccID,
+			TransferRef:    &t.Reference,
+			JournalEntryID: &entry.ID,
+			Currency:       t.ToCurrency,
+		}
+		if t.FXRate != nil {
+			debit.FXRate, debit.CounterAmount, debit.CounterCurrency = t.FXRate, &t.ToAmount, &t.ToCurrency
+			credit.FXRate, credit.CounterAmount, credit.CounterCurrency = t.FXRate, &t.Amount, &t.Currency
+		}
+
+		if err := s.AddTransaction(ctx, debit); err != nil {
+			return err
+		}
+		if err := s.AddTransaction(ctx, credit); err != nil {
+			return err
+		}
+
+		fmt.Printf("Transferred amount: %v %v, from account: %v to account: %v (%v %v). Reference: %v\n",
+			amount, t.Currency, fromAccID, toAccID, t.ToAmount, t.ToCurrency, t.Reference)
+		return nil
+	})
+	if err != nil {
+		t = Transfer{}
+	}
 	return
 }
@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	uuidgen "github.com/pborman/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"example.com/banking/money"
+)
+
+// StoreTestSuite runs against a real, migrated postgres database given by
+// TEST_DB_URL, e.g. "host=localhost port=5432 user=postgres password=12345
+// dbname=bank_test sslmode=disable". The suite is skipped when it is not set.
+type StoreTestSuite struct {
+	suite.Suite
+	db    *sqlx.DB
+	store *store
+}
+
+func TestStoreTestSuite(t *testing.T) {
+	url := os.Getenv("TEST_DB_URL")
+	if url == "" {
+		t.Skip("TEST_DB_URL is not set")
+	}
+
+	suite.Run(t, &StoreTestSuite{})
+}
+
+func (sts *StoreTestSuite) SetupSuite() {
+	d, err := sqlx.Open("postgres", os.Getenv("TEST_DB_URL"))
+	sts.Require().NoError(err)
+	d.SetMaxOpenConns(20)
+
+	sts.db = d
+	sts.store = &store{db: d}
+}
+
+func (sts *StoreTestSuite) TearDownSuite() {
+	sts.db.Close()
+}
+
+// createFundedAccount creates a customer with one account holding balance
+func (sts *StoreTestSuite) createFundedAccount(balance money.Amount) (accID, userID string) {
+	ctx := context.Background()
+	u := User{
+		Email:       fmt.Sprintf("%s@test.com", uuidgen.New()[:8]),
+		PhoneNumber: "9999999999",
+		Password:    uuidgen.New(),
+		Type:        "customer",
+	}
+	accID = uuidgen.New()
+
+	sts.Require().NoError(sts.store.CreateAccount(ctx, u, Account{ID: accID, Balance: money.New(0, 2), Type: "savings"}))
+	sts.Require().NoError(sts.db.GetContext(ctx, &userID, `SELECT user_id FROM accounts WHERE id=$1`, accID))
+
+	if balance.Sign() > 0 {
+		_, err := sts.store.DepositAmount(ctx, accID, userID, balance)
+		sts.Require().NoError(err)
+	}
+	return
+}
+
+func (sts *StoreTestSuite) balance(accID string) (b money.Amount) {
+	sts.Require().NoError(sts.db.Get(&b, `SELECT balance FROM accounts WHERE id=$1`, accID))
+	return
+}
+
+func (sts *StoreTestSuite) Test_store_WithdrawAmount_Concurrent() {
+	accID, userID := sts.createFundedAccount(money.New(10000, 2))
+
+	const workers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := sts.store.WithdrawAmount(context.Background(), accID, userID, money.New(1000, 2))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded, insufficient := 0, 0
+	for err := range errs {
+		switch err {
+		case nil:
+			succeeded++
+		case ErrInsufficientFunds:
+			insufficient++
+		default:
+			sts.Fail("unexpected error", err.Error())
+		}
+	}
+
+	sts.Equal(10, succeeded)
+	sts.Equal(workers-10, insufficient)
+	sts.True(sts.balance(accID).IsZero(), "balance must not be overdrawn or lose updates")
+
+	var debits int
+	sts.Require().NoError(sts.db.Get(&debits, `SELECT count(*) FROM transactions WHERE account_id=$1 AND type='Debit'`, accID))
+	sts.Equal(10, debits)
+}
+
+func (sts *StoreTestSuite) Test_store_DepositAmount_Concurrent() {
+	accID, userID := sts.createFundedAccount(money.Amount{})
+
+	const workers = 50
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := sts.store.DepositAmount(context.Background(), accID, userID, money.New(1, 2))
+			sts.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	sts.True(money.New(workers, 2).Equal(sts.balance(accID)), "got balance "+sts.balance(accID).String())
+}
+
+func (sts *StoreTestSuite) Test_store_WithTx_Panic() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(10000, 2))
+
+	// a panic rolls the transaction back, and savepoints in it, then goes on
+	sts.PanicsWithValue("boom", func() {
+		_ = sts.store.WithTx(ctx, func(ctx context.Context) error {
+			_, err := sts.store.DepositAmount(ctx, accID, userID, money.New(100, 2))
+			sts.Require().NoError(err)
+			_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(50, 2))
+			sts.Require().NoError(err)
+			panic("boom")
+		})
+	})
+	sts.Equal(money.New(10000, 2), sts.balance(accID))
+
+	// as it does when a deposit overflows the balance
+	sts.Panics(func() {
+		_, _ = sts.store.DepositAmount(ctx, accID, userID, money.New(9223372036854775000, 2))
+	})
+	sts.Equal(money.New(10000, 2), sts.balance(accID))
+}
+
+func (sts *StoreTestSuite) Test_store_TransferAmount_Concurrent() {
+	accA, userA := sts.createFundedAccount(money.New(100, 0))
+	accB, userB := sts.createFundedAccount(money.New(100, 0))
+
+	// opposite transfers between the same two accounts must neither deadlock
+	// nor lose updates
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := sts.store.TransferAmount(context.Background(), accA, accB, userA, money.New(1, 0))
+			sts.NoError(err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := sts.store.TransferAmount(context.Background(), accB, accA, userB, money.New(2, 0))
+			sts.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	sts.Equal("120", sts.balance(accA).Round(0).String())
+	sts.Equal("80", sts.balance(accB).Round(0).String())
+
+	mismatches, err := sts.store.ReconcileLedger(context.Background())
+	sts.Require().NoError(err)
+	for _, m := range mismatches {
+		sts.NotContains([]string{accA, accB}, m.AccountID, "ledger mismatch for "+strconv.Quote(m.AccountID))
+	}
+}
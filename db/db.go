@@ -2,9 +2,13 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"example.com/banking/money"
 )
 
 type ctxKey int
@@ -15,14 +19,73 @@ const (
 )
 
 type Storer interface {
+	WithTx(ctx context.Context, op func(ctx context.Context) error) (err error)
 	GetUserByEmailAndPassword(ctx context.Context, email string, password string) (u User, err error)
 	CreateAccount(ctx context.Context, u User, acc Account) (err error)
+	OpenAccount(ctx context.Context, userID string, acc Account) (err error)
+	GetUserAccounts(ctx context.Context, userID string) (accounts []Account, err error)
 	GetAccountList(ctx context.Context) (accounts []UserAccountDetails, err error)
+	SetAccountStatus(ctx context.Context, accID string, from []string, status, reason string) (err error)
+	CloseAccount(ctx context.Context, accID string, from []string, payoutAccID string, payOut bool, reason string) (closure AccountClosure, err error)
+	SetOverdraftLimit(ctx context.Context, accID string, limit money.Amount) (err error)
+	GetAccountLimits(ctx context.Context, accID, userID string) (limits AccountLimits, err error)
+	SetAccountDebitLimits(ctx context.Context, accID string, limits DebitLimits) (err error)
 	GetAccountDetails(ctx context.Context, accID, userID string) (acc UserAccountDetails, err error)
 	AddTransaction(ctx context.Context, t Transaction) (err error)
-	DepositAmount(ctx context.Context, accID, userID string, amount float32) (err error)
-	WithdrawAmount(ctx context.Context, accID, userID string, amount float32) (err error)
-	GetTransactions(ctx context.Context, accID, userID string) (transactions []Transaction, err error)
+	DepositAmount(ctx context.Context, accID, userID string, amount money.Amount) (fees []Transaction, err error)
+	WithdrawAmount(ctx context.Context, accID, userID string, amount money.Amount) (fees []Transaction, err error)
+	GetTransactions(ctx context.Context, accID, userID string, f TransactionFilter) (page TransactionPage, err error)
+	GetStatement(ctx context.Context, accID, userID string, from, to time.Time) (st Statement, err error)
+	TransferAmount(ctx context.Context, fromAccID, toAccID, userID string, amount money.Amount) (t Transfer, err error)
+	ReconcileLedger(ctx context.Context) (mismatches []BalanceMismatch, err error)
+	GetInterestAccounts(ctx context.Context, businessDate time.Time) (accounts []InterestAccount, err error)
+	AddInterestAccrual(ctx context.Context, a InterestAccrual) (err error)
+	PostInterest(ctx context.Context, accID string, businessDate time.Time) (posted money.Amount, err error)
+	GetFeeRules(ctx context.Context) (rules []FeeRule, err error)
+	CreateFeeRule(ctx context.Context, r FeeRule) (created FeeRule, err error)
+	UpdateFeeRule(ctx context.Context, r FeeRule) (updated FeeRule, err error)
+	GetMonthlyFeeCharges(ctx context.Context, period time.Time) (charges []FeeCharge, err error)
+	ChargeMonthlyFee(ctx context.Context, c FeeCharge) (charged money.Amount, err error)
+	GetFXRates(ctx context.Context) (rates []FXRate, err error)
+	CreateFXRate(ctx context.Context, r FXRate) (created FXRate, err error)
+	CreateStandingOrder(ctx context.Context, o StandingOrder) (created StandingOrder, err error)
+	GetAccountStandingOrders(ctx context.Context, accID, userID string) (orders []StandingOrder, err error)
+	GetStandingOrderExecutions(ctx context.Context, accID, orderID, userID string) (executions []StandingOrderExecution, err error)
+	CancelStandingOrder(ctx context.Context, accID, orderID, userID string) (err error)
+	ClaimDueStandingOrder(ctx context.Context, now time.Time, skip []string) (o StandingOrder, err error)
+	RecordStandingOrderExecution(ctx context.Context, o StandingOrder, e StandingOrderExecution) (err error)
+	CreateHold(ctx context.Context, h Hold) (created Hold, err error)
+	GetAccountHolds(ctx context.Context, accID string) (holds []Hold, err error)
+	CaptureHold(ctx context.Context, accID, holdID string, amount *money.Amount) (captured Hold, err error)
+	ReleaseHold(ctx context.Context, accID, holdID string) (released Hold, err error)
+	ExpireHolds(ctx context.Context, now time.Time) (expired []Hold, err error)
+	ReverseTransaction(ctx context.Context, r Reversal) (reversal Transaction, err error)
+	ReserveIdempotencyKey(ctx context.Context, k IdempotencyKey, ttl time.Duration) (existing IdempotencyKey, reserved bool, err error)
+	SaveIdempotentResponse(ctx context.Context, userID, key string, statusCode int, body []byte) (err error)
+	DeleteIdempotencyKey(ctx context.Context, userID, key string) (err error)
+	CreateSession(ctx context.Context, sess Session, rt RefreshToken) (err error)
+	RotateRefreshToken(ctx context.Context, tokenHash string, next RefreshToken) (sess Session, err error)
+	RevokeSession(ctx context.Context, sessionID, userID string) (err error)
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) (err error)
+	IsTokenRevoked(ctx context.Context, jti, sessionID string) (revoked bool, err error)
+	ChangePassword(ctx context.Context, userID, sessionID, oldPassword, newPassword string) (err error)
+	CreatePasswordResetToken(ctx context.Context, email string, t PasswordResetToken) (err error)
+	ResetPassword(ctx context.Context, tokenHash, newPassword string) (err error)
+	GetLoginAttempts(ctx context.Context, email, ip string) (attempts []LoginAttempt, err error)
+	RecordLoginFailure(ctx context.Context, kind, key string, resetBefore time.Time) (attempt LoginAttempt, err error)
+	ResetLoginFailures(ctx context.Context, kind, key string) (err error)
+	UnlockUser(ctx context.Context, userID string) (err error)
+	AddAuditEntry(ctx context.Context, e AuditEntry) (err error)
+	GetAuditEntries(ctx context.Context, f AuditFilter) (entries []AuditEntry, err error)
+	GetUserByID(ctx context.Context, userID string) (u User, err error)
+	GetUserByEmail(ctx context.Context, email string) (u User, err error)
+	GetMFA(ctx context.Context, userID string) (m MFA, err error)
+	SaveMFASecret(ctx context.Context, userID, secret string) (err error)
+	ConfirmMFA(ctx context.Context, userID string, counter int64, recoveryCodeHashes []string) (err error)
+	UseMFACode(ctx context.Context, userID string, counter int64) (err error)
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (err error)
+	RoleHasPermission(ctx context.Context, role, permission string) (allowed bool, err error)
+	SetUserRole(ctx context.Context, userID, role string) (err error)
 }
 
 type store struct {
@@ -39,6 +102,79 @@ func newContext(ctx context.Context, tx *sqlx.Tx) context.Context {
 	return context.WithValue(ctx, dbKey, tx)
 }
 
+// conn returns the transaction stored in ctx by newContext, falling back to
+// the database handle when there is none.
+func (s *store) conn(ctx context.Context) sqlx.ExtContext {
+	if tx, ok := ctx.Value(dbKey).(*sqlx.Tx); ok {
+		return tx
+	}
+	return s.db
+}
+
+// WithTx runs op inside a database transaction, which the store methods op
+// calls with its context join. Their changes are committed together if op
+// succeeds and rolled back together otherwise.
+func (s *store) WithTx(ctx context.Context, op func(ctx context.Context) error) (err error) {
+	return s.withTx(ctx, op)
+}
+
+// withTx runs op inside a database transaction, which op reaches through
+// conn. The transaction is committed if op succeeds and rolled back otherwise,
+// also when op panics, the panic then carrying on. Inside a transaction
+// already, op runs in a savepoint of it instead, so that its failure only
+// undoes its own changes.
+func (s *store) withTx(ctx context.Context, op func(ctx context.Context) error) (err error) {
+	if tx, ok := ctx.Value(dbKey).(*sqlx.Tx); ok {
+		return withSavepoint(ctx, tx, op)
+	}
+
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if e := tx.Rollback(); e != nil {
+				err = errors.Wrapf(err, "rollback failed: %v", e)
+			}
+			return
+		}
+		err = errors.WithStack(tx.Commit())
+	}()
+
+	err = WithDefaultTimeout(newContext(ctx, tx), op)
+	return
+}
+
+func withSavepoint(ctx context.Context, tx *sqlx.Tx, op func(ctx context.Context) error) (err error) {
+	if _, err = tx.ExecContext(ctx, "SAVEPOINT nested"); err != nil {
+		return
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT nested")
+			panic(p)
+		}
+		if err != nil {
+			if _, e := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT nested"); e != nil {
+				err = errors.Wrapf(err, "rollback to savepoint failed: %v", e)
+			}
+			return
+		}
+		_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT nested")
+		err = errors.WithStack(err)
+	}()
+
+	err = WithDefaultTimeout(ctx, op)
+	return
+}
+
 func WithTimeout(ctx context.Context, timeout time.Duration, op func(ctx context.Context) error) (err error) {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -7,4 +7,48 @@ var (
 	ErrUserNotExist        = errors.New("user does not exist in db")
 	ErrTransactionNotExist = errors.New("transactions for the user for not exist in db")
 	ErrInsufficientFunds   = errors.New("insufficient funds")
+	ErrPayeeNotExist       = errors.New("payee account does not exist in db")
+	ErrUnbalancedEntry     = errors.New("journal entry postings do not add up to zero")
+	ErrAccountTypeNotExist = errors.New("account type does not exist in db")
+	ErrOperationNotAllowed = errors.New("operation is not allowed for the account type")
+	ErrFeeRuleNotExist     = errors.New("fee rule does not exist in db")
+	ErrLimitExceeded       = errors.New("debit limit exceeded")
+	ErrFXRateNotExist      = errors.New("no exchange rate exists in db for the currencies")
+	ErrAmountTooSmall      = errors.New("amount is too small to exchange")
+	ErrCurrencyMismatch    = errors.New("accounts hold different currencies")
+
+	ErrStandingOrderNotExist  = errors.New("standing order does not exist in db")
+	ErrStandingOrderNotActive = errors.New("standing order is not active")
+
+	ErrHoldNotExist        = errors.New("hold does not exist in db")
+	ErrHoldNotActive       = errors.New("hold is not active")
+	ErrHoldReferenceExists = errors.New("a hold with the reference already exists on the account")
+	ErrCaptureExceedsHold  = errors.New("capture amount exceeds the hold")
+	ErrHoldsActive         = errors.New("account has active holds")
+
+	ErrTransactionNotReversible   = errors.New("only credits and debits can be reversed")
+	ErrTransactionReversed        = errors.New("transaction is already reversed in full")
+	ErrReversalExceedsTransaction = errors.New("reversal exceeds what is left of the transaction")
+	ErrInvalidCursor              = errors.New("invalid transaction history cursor")
+
+	ErrCreditNotAllowed      = errors.New("account status does not allow credits")
+	ErrDebitNotAllowed       = errors.New("account status does not allow debits")
+	ErrPayeeCreditNotAllowed = errors.New("payee account status does not allow credits")
+	ErrAccountStatusConflict = errors.New("account status does not allow the change")
+	ErrBalanceNotZero        = errors.New("account balance is not zero")
+
+	ErrIdempotencyKeyNotExist = errors.New("idempotency key does not exist in db")
+
+	ErrRefreshTokenNotExist = errors.New("refresh token does not exist in db")
+	ErrRefreshTokenExpired  = errors.New("refresh token has expired")
+	ErrRefreshTokenReused   = errors.New("refresh token was already used")
+	ErrSessionRevoked       = errors.New("session has been revoked")
+
+	ErrPasswordResetTokenNotExist = errors.New("password reset token does not exist in db")
+	ErrPasswordResetTokenExpired  = errors.New("password reset token has expired")
+
+	ErrMFANotEnrolled       = errors.New("user is not enrolled in mfa")
+	ErrMFAAlreadyEnrolled   = errors.New("user is already enrolled in mfa")
+	ErrMFACodeUsed          = errors.New("mfa code was already used")
+	ErrRecoveryCodeNotExist = errors.New("recovery code does not exist in db")
 )
@@ -0,0 +1,273 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+// Events that charge the fee of a fee rule
+const (
+	FeeTriggerWithdrawal = "withdrawal"
+	FeeTriggerDeposit    = "deposit"
+	FeeTriggerMonthly    = "monthly"
+)
+
+// feeTriggerTypes are the transaction types counted against the free
+// transactions of a month
+var feeTriggerTypes = map[string]string{
+	FeeTriggerWithdrawal: "Debit",
+	FeeTriggerDeposit:    "Credit",
+}
+
+const (
+	listFeeRulesQuery  = `SELECT * FROM fee_rules ORDER BY id`
+	getFeeRuleQuery    = `SELECT * FROM fee_rules WHERE id=$1`
+	createFeeRuleQuery = `INSERT INTO fee_rules(name, trigger, account_type, currency, amount, free_per_month, min_balance, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9) RETURNING *`
+	updateFeeRuleQuery = `UPDATE fee_rules SET name=$2, trigger=$3, account_type=$4, currency=$5, amount=$6, free_per_month=$7, min_balance=$8, active=$9,
+		updated_at=$10 WHERE id=$1 RETURNING *`
+	// rules only apply to the accounts in their currency
+	getAccountFeeRulesQuery = `SELECT * FROM fee_rules WHERE active AND trigger=$1 AND (account_type IS NULL OR account_type=$2) AND currency=$3 ORDER BY id`
+	// transfers are not withdrawals or deposits
+	countMonthTransactionsQuery = `SELECT COUNT(*) FROM transactions
+		WHERE account_id=$1 AND type=$2 AND transfer_ref IS NULL AND created_at >= date_trunc('month', $3::TIMESTAMP)`
+
+	getMonthlyFeeChargesQuery = `SELECT fee_rules.id AS rule_id, accounts.id AS account_id FROM fee_rules
+		INNER JOIN accounts ON (fee_rules.account_type IS NULL OR fee_rules.account_type=accounts.type) AND fee_rules.currency=accounts.currency
+		WHERE fee_rules.active AND fee_rules.trigger='monthly' AND accounts.user_id IS NOT NULL AND accounts.status <> 'closed'
+		AND NOT EXISTS (SELECT 1 FROM fee_charges WHERE rule_id=fee_rules.id AND account_id=accounts.id AND period=$1::DATE)
+		ORDER BY accounts.id, fee_rules.id`
+	isFeeChargedQuery     = `SELECT EXISTS (SELECT 1 FROM fee_charges WHERE rule_id=$1 AND account_id=$2 AND period=$3::DATE)`
+	createFeeChargeQuery  = `INSERT INTO fee_charges(rule_id, account_id, period, transaction_id) VALUES ($1, $2, $3::DATE, $4)`
+	getBalanceBeforeQuery = `SELECT COALESCE(SUM(postings.amount), 0) FROM postings
+		INNER JOIN journal_entries ON journal_entries.id=postings.journal_entry_id
+		WHERE postings.account_id=$1 AND journal_entries.created_at < $2::DATE`
+)
+
+// FeeRule describes a fee charged to customer accounts. Withdrawal and
+// deposit fees are charged with every transaction after the first
+// FreePerMonth of the month, monthly fees once a month. When MinBalance is
+// set the fee is only charged while the balance is below it. Amount and
+// MinBalance are in Currency, the rule only applies to accounts in it.
+type FeeRule struct {
+	ID      int64  `json:"id" db:"id"`
+	Name    string `json:"name" db:"name"`
+	Trigger string `json:"trigger" db:"trigger"`
+	// AccountType the rule applies to, every type if not set
+	AccountType  *string       `json:"account_type,omitempty" db:"account_type"`
+	Currency     string        `json:"currency" db:"currency"`
+	Amount       money.Amount  `json:"amount" db:"amount"`
+	FreePerMonth int           `json:"free_per_month" db:"free_per_month"`
+	MinBalance   *money.Amount `json:"min_balance,omitempty" db:"min_balance"`
+	Active       bool          `json:"active" db:"active"`
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// FeeCharge is a monthly fee due from an account. Period is the first day of
+// the month the fee is for.
+type FeeCharge struct {
+	RuleID    int64     `db:"rule_id"`
+	AccountID string    `db:"account_id"`
+	Period    time.Time `db:"-"`
+}
+
+// applies reports whether the rule charges its fee, given the number of
+// transactions of its trigger this month, including the one being made, and
+// the balance.
+func (r FeeRule) applies(count int, balance money.Amount) bool {
+	if count <= r.FreePerMonth {
+		return false
+	}
+	return r.MinBalance == nil || balance.Cmp(*r.MinBalance) < 0
+}
+
+func (s *store) GetFeeRules(ctx context.Context) (rules []FeeRule, err error) {
+	rules = make([]FeeRule, 0)
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.SelectContext(ctx, s.conn(ctx), &rules, listFeeRulesQuery)
+	})
+	return
+}
+
+func (s *store) CreateFeeRule(ctx context.Context, r FeeRule) (created FeeRule, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		if r.AccountType != nil {
+			if _, err := s.getAccountType(ctx, *r.AccountType); err != nil {
+				return err
+			}
+		}
+
+		return sqlx.GetContext(ctx, s.conn(ctx), &created, createFeeRuleQuery,
+			r.Name, r.Trigger, r.AccountType, currencyOrDefault(r.Currency), r.Amount, r.FreePerMonth, r.MinBalance, r.Active, time.Now())
+	})
+	return
+}
+
+// UpdateFeeRule replaces the rule with the ID of r. Fees already charged are
+// not changed.
+func (s *store) UpdateFeeRule(ctx context.Context, r FeeRule) (updated FeeRule, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		if r.AccountType != nil {
+			if _, err := s.getAccountType(ctx, *r.AccountType); err != nil {
+				return err
+			}
+		}
+
+		err := sqlx.GetContext(ctx, s.conn(ctx), &updated, updateFeeRuleQuery,
+			r.ID, r.Name, r.Trigger, r.AccountType, currencyOrDefault(r.Currency), r.Amount, r.FreePerMonth, r.MinBalance, r.Active, time.Now())
+		if err == sql.ErrNoRows {
+			return ErrFeeRuleNotExist
+		}
+		return err
+	})
+	return
+}
+
+// transactionFees returns the fee rules a withdrawal or deposit on the
+// locked account triggers, and their total. balance is the balance after the
+// transaction, which must not be recorded yet.
+func (s *store) transactionFees(ctx context.Context, acc Account, trigger string, balance money.Amount) (rules []FeeRule, total money.Amount, err error) {
+	// fees are debits, they are not charged where debits are blocked
+	if !allowsDebit(acc.Status) {
+		return
+	}
+
+	var candidates []FeeRule
+	if err = sqlx.SelectContext(ctx, s.conn(ctx), &candidates, getAccountFeeRulesQuery, trigger, acc.Type, acc.Currency); err != nil || len(candidates) == 0 {
+		return
+	}
+
+	var count int
+	now := time.Now().Format("2006-01-02 15:04:05.000")
+	if err = sqlx.GetContext(ctx, s.conn(ctx), &count, countMonthTransactionsQuery, acc.ID, feeTriggerTypes[trigger], now); err != nil {
+		return
+	}
+
+	for _, r := range candidates {
+		if r.applies(count+1, balance) {
+			rules = append(rules, r)
+			total = total.Add(r.Amount)
+		}
+	}
+	return
+}
+
+// chargeFees charges the fee of every rule to the account, linked to the
+// transaction that triggered them.
+func (s *store) chargeFees(ctx context.Context, acc Account, rules []FeeRule, transactionID string) (fees []Transaction, err error) {
+	for _, r := range rules {
+		t, err := s.postFee(ctx, acc, r, &transactionID)
+		if err != nil {
+			return nil, err
+		}
+		fees = append(fees, t)
+	}
+	return
+}
+
+// postFee moves the fee of the rule from the account to the fee income of the
+// bank and records it as a Fee transaction.
+func (s *store) postFee(ctx context.Context, acc Account, r FeeRule, linkedID *string) (t Transaction, err error) {
+	entry := newJournalEntry("Fee",
+		Posting{AccountID: acc.ID, Amount: r.Amount.Neg()},
+		Posting{AccountID: internalAccountID(FeeIncomeAccountID, acc.Currency), Amount: r.Amount},
+	)
+	balances, err := s.postJournalEntry(ctx, entry)
+	if err != nil {
+		return
+	}
+
+	t = Transaction{
+		ID:                  uuidgen.New(),
+		Type:                "Fee",
+		Amount:              r.Amount,
+		Balance:             balances[acc.ID],
+		CreatedAt:           entry.CreatedAt,
+		AccountID:           acc.ID,
+		JournalEntryID:      &entry.ID,
+		LinkedTransactionID: linkedID,
+	}
+	if err = s.AddTransaction(ctx, t); err != nil {
+		return
+	}
+
+	fmt.Printf("Charged fee: %v (%v), to account: %v. Balance: %v\n", r.Amount, r.Name, acc.ID, t.Balance)
+	return
+}
+
+// GetMonthlyFeeCharges lists the monthly fees of the period not charged yet.
+// It reads every account, so it does not use the default timeout.
+func (s *store) GetMonthlyFeeCharges(ctx context.Context, period time.Time) (charges []FeeCharge, err error) {
+	charges = make([]FeeCharge, 0)
+	err = sqlx.SelectContext(ctx, s.conn(ctx), &charges, getMonthlyFeeChargesQuery, period.Format(businessDateFormat))
+	for i := range charges {
+		charges[i].Period = period
+	}
+	return
+}
+
+// ChargeMonthlyFee charges a monthly fee, if it applies to the balance at the
+// end of the period. A fee is charged only once per period; the balance must
+// cover it, overdraft included, otherwise ErrInsufficientFunds is returned.
+func (s *store) ChargeMonthlyFee(ctx context.Context, c FeeCharge) (charged money.Amount, err error) {
+	period := c.Period.Format(businessDateFormat)
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		acc, err := s.lockAccount(ctx, c.AccountID)
+		if err != nil {
+			return err
+		}
+		if !allowsDebit(acc.Status) {
+			return nil
+		}
+
+		var r FeeRule
+		err = sqlx.GetContext(ctx, s.conn(ctx), &r, getFeeRuleQuery, c.RuleID)
+		if err == sql.ErrNoRows {
+			return ErrFeeRuleNotExist
+		}
+		// a rule changed to another currency since no longer applies
+		if err != nil || !r.Active || r.Currency != acc.Currency {
+			return err
+		}
+
+		var done bool
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &done, isFeeChargedQuery, r.ID, acc.ID, period); err != nil || done {
+			return err
+		}
+
+		var balance money.Amount
+		end := c.Period.AddDate(0, 1, 0).Format(businessDateFormat)
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &balance, getBalanceBeforeQuery, acc.ID, end); err != nil {
+			return err
+		}
+		if !r.applies(1, balance) {
+			return nil
+		}
+		if acc.available().Cmp(r.Amount) < 0 {
+			return ErrInsufficientFunds
+		}
+
+		t, err := s.postFee(ctx, acc, r, nil)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.conn(ctx).ExecContext(ctx, createFeeChargeQuery, r.ID, acc.ID, period, t.ID); err != nil {
+			return err
+		}
+		charged = r.Amount
+		return nil
+	})
+	if err != nil {
+		charged = money.Amount{}
+	}
+	return
+}
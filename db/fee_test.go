@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+// createFeeAccount opens a funded account of a new account type, so that the
+// fee rules of a test apply to its accounts only.
+func (sts *StoreTestSuite) createFeeAccount(balance money.Amount) (accID, userID, accType string) {
+	ctx := context.Background()
+	accType = "fee_" + uuidgen.New()[:8]
+	_, err := sts.db.ExecContext(ctx, `INSERT INTO account_types(name, description, allows_withdrawal, allows_transfer_out) VALUES ($1, 'fee test', true, true)`, accType)
+	sts.Require().NoError(err)
+
+	userID = sts.createCustomer().ID
+	accID = uuidgen.New()
+	sts.Require().NoError(sts.store.OpenAccount(ctx, userID, Account{ID: accID, Balance: money.New(0, 2), Type: accType}))
+	_, err = sts.store.DepositAmount(ctx, accID, userID, balance)
+	sts.Require().NoError(err)
+	return
+}
+
+func (sts *StoreTestSuite) Test_store_WithdrawAmount_Fees() {
+	ctx := context.Background()
+	accID, userID, accType := sts.createFeeAccount(money.New(1000, 2))
+
+	rule, err := sts.store.CreateFeeRule(ctx, FeeRule{Name: "Withdrawal", Trigger: FeeTriggerWithdrawal, AccountType: &accType, Amount: money.New(100, 2), FreePerMonth: 1, Active: true})
+	sts.Require().NoError(err)
+	sts.Equal("INR", rule.Currency)
+	// rules in another currency do not apply to the account
+	_, err = sts.store.CreateFeeRule(ctx, FeeRule{Name: "Withdrawal", Trigger: FeeTriggerWithdrawal, AccountType: &accType, Currency: "USD", Amount: money.New(100, 2), Active: true})
+	sts.Require().NoError(err)
+
+	// the first withdrawal of the month is free
+	fees, err := sts.store.WithdrawAmount(ctx, accID, userID, money.New(200, 2))
+	sts.Require().NoError(err)
+	sts.Empty(fees)
+
+	fees, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(200, 2))
+	sts.Require().NoError(err)
+	sts.Require().Len(fees, 1)
+	sts.Equal("Fee", fees[0].Type)
+	sts.Require().NotNil(fees[0].LinkedTransactionID)
+	sts.Equal("5", sts.balance(accID).Round(0).String())
+
+	// the fee must be covered as well
+	_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(450, 2))
+	sts.Equal(ErrInsufficientFunds, err)
+
+	rule.Active = false
+	_, err = sts.store.UpdateFeeRule(ctx, rule)
+	sts.Require().NoError(err)
+	fees, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(450, 2))
+	sts.Require().NoError(err)
+	sts.Empty(fees)
+
+	rule.ID = 0
+	_, err = sts.store.UpdateFeeRule(ctx, rule)
+	sts.Equal(ErrFeeRuleNotExist, err)
+
+	mismatches, err := sts.store.ReconcileLedger(ctx)
+	sts.Require().NoError(err)
+	sts.Empty(mismatches)
+}
+
+func (sts *StoreTestSuite) Test_store_ChargeMonthlyFee() {
+	ctx := context.Background()
+	accID, _, accType := sts.createFeeAccount(money.New(1000, 2))
+	minBalance := money.New(5000, 2)
+
+	_, err := sts.store.CreateFeeRule(ctx, FeeRule{Name: "Maintenance", Trigger: FeeTriggerMonthly, AccountType: &accType, Amount: money.New(300, 2), MinBalance: &minBalance, Active: true})
+	sts.Require().NoError(err)
+
+	now := time.Now()
+	period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	charges, err := sts.store.GetMonthlyFeeCharges(ctx, period)
+	sts.Require().NoError(err)
+
+	var due *FeeCharge
+	for i := range charges {
+		if charges[i].AccountID == accID {
+			due = &charges[i]
+		}
+	}
+	sts.Require().NotNil(due)
+
+	charged, err := sts.store.ChargeMonthlyFee(ctx, *due)
+	sts.Require().NoError(err)
+	sts.Equal("3", charged.Round(0).String())
+	sts.Equal("7", sts.balance(accID).Round(0).String())
+
+	// a fee is only charged once a month
+	charged, err = sts.store.ChargeMonthlyFee(ctx, *due)
+	sts.Require().NoError(err)
+	sts.True(charged.IsZero())
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"example.com/banking/money"
+)
+
+// fxRateDigits are the fraction digits of the rates customers are given
+const fxRateDigits = 8
+
+// fxPositionAccountIDs are the internal accounts holding the position of
+// the bank in every currency, created by the currencies migration.
+// Exchanges between accounts in different currencies go through them, so
+// that no account ever mixes currencies.
+var fxPositionAccountIDs = map[string]string{
+	money.INR.Code: "00000000-0000-0000-0001-000000000356",
+	money.USD.Code: "00000000-0000-0000-0001-000000000840",
+	money.EUR.Code: "00000000-0000-0000-0001-000000000978",
+	money.GBP.Code: "00000000-0000-0000-0001-000000000826",
+	money.JPY.Code: "00000000-0000-0000-0001-000000000392",
+	money.KWD.Code: "00000000-0000-0000-0001-000000000414",
+}
+
+const (
+	listFXRatesQuery  = `SELECT * FROM fx_rates ORDER BY base_currency, quote_currency, effective_from DESC, id DESC`
+	createFXRateQuery = `INSERT INTO fx_rates(base_currency, quote_currency, rate, spread, effective_from, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *`
+	// a rate of the pair quoted either way round will do
+	getFXRateQuery = `SELECT * FROM fx_rates
+		WHERE ((base_currency=$1 AND quote_currency=$2) OR (base_currency=$2 AND quote_currency=$1)) AND effective_from <= $3
+		ORDER BY effective_from DESC, id DESC LIMIT 1`
+)
+
+// FXRate is the exchange rate between two currencies from EffectiveFrom
+// until the next rate of the pair: one unit of BaseCurrency is worth Rate
+// units of QuoteCurrency. Customers get the rate less Spread, a fraction of
+// it, whichever way they exchange.
+type FXRate struct {
+	ID            int64        `json:"id" db:"id"`
+	BaseCurrency  string       `json:"base_currency" db:"base_currency"`
+	QuoteCurrency string       `json:"quote_currency" db:"quote_currency"`
+	Rate          money.Amount `json:"rate" db:"rate"`
+	Spread        money.Amount `json:"spread" db:"spread"`
+	EffectiveFrom time.Time    `json:"effective_from" db:"effective_from"`
+	CreatedBy     *string      `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+}
+
+// applied is the rate a customer exchanging from the currency gets, in units
+// of the other currency per unit of from. The spread goes to the bank both
+// ways.
+func (r FXRate) applied(from string) money.Amount {
+	one := money.New(1, 0)
+	if from == r.BaseCurrency {
+		return r.Rate.MulQuo(one.Sub(r.Spread), 1, fxRateDigits)
+	}
+	return one.Quo(r.Rate.MulQuo(one.Add(r.Spread), 1, fxRateDigits+4), fxRateDigits)
+}
+
+// currencyOrDefault is the currency of a new account, the default one when
+// none is given.
+func currencyOrDefault(code string) string {
+	if code == "" {
+		return money.DefaultCurrency.Code
+	}
+	return code
+}
+
+func (s *store) GetFXRates(ctx context.Context) (rates []FXRate, err error) {
+	rates = make([]FXRate, 0)
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.SelectContext(ctx, s.conn(ctx), &rates, listFXRatesQuery)
+	})
+	return
+}
+
+// CreateFXRate adds a rate for a pair of currencies. Rates are never
+// changed, a new rate replaces the last one from its EffectiveFrom on.
+func (s *store) CreateFXRate(ctx context.Context, r FXRate) (created FXRate, err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.GetContext(ctx, s.conn(ctx), &created, createFXRateQuery,
+			r.BaseCurrency, r.QuoteCurrency, r.Rate, r.Spread, r.EffectiveFrom, r.CreatedBy, time.Now())
+	})
+	return
+}
+
+// exchange converts amount from one currency to another at the rate in force
+// at, and returns the result and the rate applied.
+func (s *store) exchange(ctx context.Context, amount money.Amount, from, to string, at time.Time) (converted, rate money.Amount, err error) {
+	toCur, err := money.LookupCurrency(to)
+	if err != nil {
+		return
+	}
+
+	var r FXRate
+	err = sqlx.GetContext(ctx, s.conn(ctx), &r, getFXRateQuery, from, to, at)
+	if err == sql.ErrNoRows {
+		return converted, rate, ErrFXRateNotExist
+	}
+	if err != nil {
+		return
+	}
+
+	rate = r.applied(from)
+	converted = amount.MulQuo(rate, 1, toCur.Digits)
+	if converted.Sign() <= 0 {
+		err = ErrAmountTooSmall
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_TransferAmount_Exchange() {
+	ctx := context.Background()
+	inrAccID, userID := sts.createFundedAccount(money.New(1000000, 2))
+
+	u := User{
+		Email:       fmt.Sprintf("%s@test.com", uuidgen.New()[:8]),
+		PhoneNumber: "9999999999",
+		Password:    uuidgen.New(),
+		Type:        "customer",
+	}
+	usdAccID, kwdAccID := uuidgen.New(), uuidgen.New()
+	sts.Require().NoError(sts.store.CreateAccount(ctx, u, Account{ID: usdAccID, Balance: money.New(0, 2), Type: "savings", Currency: "USD"}))
+	sts.Require().NoError(sts.db.GetContext(ctx, &u.ID, `SELECT user_id FROM accounts WHERE id=$1`, usdAccID))
+	sts.Require().NoError(sts.store.OpenAccount(ctx, u.ID, Account{ID: kwdAccID, Balance: money.New(0, 3), Type: "savings", Currency: "KWD"}))
+
+	// there is no rate between rupees and dinars
+	_, err := sts.store.TransferAmount(ctx, inrAccID, kwdAccID, userID, money.New(100000, 2))
+	sts.Equal(ErrFXRateNotExist, err)
+
+	// a rate quoted the other way round is inverted, the spread going to the bank
+	_, err = sts.store.CreateFXRate(ctx, FXRate{
+		BaseCurrency: "USD", QuoteCurrency: "INR", Rate: money.New(80, 0), Spread: money.New(1, 2), EffectiveFrom: time.Now().Add(-time.Second),
+	})
+	sts.Require().NoError(err)
+
+	t, err := sts.store.TransferAmount(ctx, inrAccID, usdAccID, userID, money.New(800000, 2))
+	sts.Require().NoError(err)
+	sts.Equal("INR", t.Currency)
+	sts.Equal("USD", t.ToCurrency)
+	sts.Equal("0.01237624", t.FXRate.String())
+	sts.Equal("99.01", t.ToAmount.String())
+	sts.Equal("2000.00", sts.balance(inrAccID).String())
+	sts.Equal("99.01", sts.balance(usdAccID).String())
+
+	page, err := sts.store.GetTransactions(ctx, usdAccID, u.ID, TransactionFilter{})
+	sts.Require().NoError(err)
+	txns := page.Transactions
+	sts.Require().Len(txns, 1)
+	sts.Equal("USD", txns[0].Currency)
+	sts.Equal("99.01", txns[0].Amount.String())
+	sts.Equal("8000.00", txns[0].CounterAmount.String())
+	sts.Equal("INR", *txns[0].CounterCurrency)
+
+	mismatches, err := sts.store.ReconcileLedger(ctx)
+	sts.Require().NoError(err)
+	sts.Empty(mismatches)
+}
+
+func (sts *StoreTestSuite) Test_store_DepositAmount_Currency() {
+	ctx := context.Background()
+	u := User{
+		Email:       fmt.Sprintf("%s@test.com", uuidgen.New()[:8]),
+		PhoneNumber: "9999999999",
+		Password:    uuidgen.New(),
+		Type:        "customer",
+	}
+	usdAccID := uuidgen.New()
+	sts.Require().NoError(sts.store.CreateAccount(ctx, u, Account{ID: usdAccID, Balance: money.New(0, 2), Type: "savings", Currency: "USD"}))
+	sts.Require().NoError(sts.db.GetContext(ctx, &u.ID, `SELECT user_id FROM accounts WHERE id=$1`, usdAccID))
+
+	// the cash comes out of the cash account in dollars, the one in rupees is
+	// left alone
+	_, err := sts.store.DepositAmount(ctx, usdAccID, u.ID, money.New(10000, 2))
+	sts.Require().NoError(err)
+	sts.Equal("100.00", sts.balance(usdAccID).String())
+
+	var postings []struct {
+		AccountID string `db:"account_id"`
+		Currency  string `db:"currency"`
+	}
+	sts.Require().NoError(sts.db.SelectContext(ctx, &postings, `SELECT postings.account_id, accounts.currency FROM postings
+		INNER JOIN accounts ON accounts.id=postings.account_id
+		WHERE postings.journal_entry_id IN (SELECT journal_entry_id FROM postings WHERE account_id=$1)`, usdAccID))
+	sts.Require().Len(postings, 2)
+	for _, p := range postings {
+		sts.Equal("USD", p.Currency, p.AccountID)
+		sts.NotEqual(CashAccountID, p.AccountID)
+	}
+
+	mismatches, err := sts.store.ReconcileLedger(ctx)
+	sts.Require().NoError(err)
+	sts.Empty(mismatches)
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+// Orders of the transaction history of an account
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// TransactionFilter selects transactions of an account. Zero fields match
+// every transaction. From is inclusive and To exclusive. Transactions are
+// returned oldest first unless Sort is SortDescending, Limit at a time,
+// starting after the one the Cursor of the previous page points to.
+type TransactionFilter struct {
+	From      time.Time
+	To        time.Time
+	Types     []string
+	MinAmount *money.Amount
+	MaxAmount *money.Amount
+	Sort      string
+	Cursor    string
+	Limit     int
+}
+
+// TransactionPage is a page of the history of an account. NextCursor is set
+// when there are more transactions after it.
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
+
+// GetTransactions returns the page of the transactions of an account that the
+// filter selects. The history is read in the order of the index on the
+// account, creation time and ID of the transactions, so a page costs the same
+// however long the history is.
+func (s *store) GetTransactions(ctx context.Context, accID, userID string, f TransactionFilter) (page TransactionPage, err error) {
+	if _, err = s.GetAccountDetails(ctx, accID, userID); err != nil {
+		return
+	}
+
+	query, args, err := transactionHistoryQuery(accID, f)
+	if err != nil {
+		return
+	}
+
+	page.Transactions = make([]Transaction, 0)
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.SelectContext(ctx, s.conn(ctx), &page.Transactions, query, args...)
+	})
+	if err != nil {
+		return
+	}
+
+	// one more transaction than asked for is read to know whether there is
+	// another page
+	if f.Limit > 0 && len(page.Transactions) > f.Limit {
+		page.Transactions = page.Transactions[:f.Limit]
+		last := page.Transactions[f.Limit-1]
+		page.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+	return
+}
+
+// transactionHistoryQuery builds the query of the transactions of an account
+// the filter selects, and its arguments.
+func transactionHistoryQuery(accID string, f TransactionFilter) (query string, args []interface{}, err error) {
+	var b strings.Builder
+	b.WriteString(getTransactionsByAccIDQuery)
+	args = []interface{}{accID}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		fmt.Fprintf(&b, " AND "+condition, len(args))
+	}
+
+	if !f.From.IsZero() {
+		where("created_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		where("created_at < $%d", f.To)
+	}
+	if len(f.Types) > 0 {
+		where("type = ANY($%d)", pq.Array(f.Types))
+	}
+	if f.MinAmount != nil {
+		where("amount >= $%d", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		where("amount <= $%d", *f.MaxAmount)
+	}
+
+	order, after := "ASC", ">"
+	if f.Sort == SortDescending {
+		order, after = "DESC", "<"
+	}
+	if f.Cursor != "" {
+		createdAt, id, err := decodeCursor(f.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, createdAt, id)
+		fmt.Fprintf(&b, " AND (created_at, id) %s ($%d::TIMESTAMP, $%d::UUID)", after, len(args)-1, len(args))
+	}
+
+	fmt.Fprintf(&b, " ORDER BY created_at %s, id %s", order, order)
+	if f.Limit > 0 {
+		args = append(args, f.Limit+1)
+		fmt.Fprintf(&b, " LIMIT $%d", len(args))
+	}
+	return b.String(), args, nil
+}
+
+// A cursor is the creation time and ID of the last transaction of a page,
+// which together order the history.
+func encodeCursor(createdAt, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt + "," + id))
+}
+
+func decodeCursor(cursor string) (createdAt time.Time, id string, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return createdAt, id, ErrInvalidCursor
+	}
+	parts := strings.Split(string(b), ",")
+	if len(parts) != 2 {
+		return createdAt, id, ErrInvalidCursor
+	}
+	if createdAt, err = time.Parse(time.RFC3339Nano, parts[0]); err != nil {
+		return createdAt, id, ErrInvalidCursor
+	}
+	if uuidgen.Parse(parts[1]) == nil {
+		return createdAt, id, ErrInvalidCursor
+	}
+	return createdAt, parts[1], nil
+}
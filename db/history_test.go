@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_GetTransactions() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(100000, 2))
+	for _, amount := range []int64{1000, 2000, 3000, 4000, 5000} {
+		_, err := sts.store.WithdrawAmount(ctx, accID, userID, money.New(amount, 2))
+		sts.Require().NoError(err)
+	}
+
+	// the debits are paged through latest first, the cursor of a page
+	// leading to the next
+	f := TransactionFilter{Types: []string{"Debit"}, Sort: SortDescending, Limit: 2}
+	var amounts []string
+	for {
+		page, err := sts.store.GetTransactions(ctx, accID, userID, f)
+		sts.Require().NoError(err)
+		for _, t := range page.Transactions {
+			amounts = append(amounts, t.Amount.String())
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		f.Cursor = page.NextCursor
+	}
+	sts.Equal([]string{"50.00", "40.00", "30.00", "20.00", "10.00"}, amounts)
+
+	min, max := money.New(2000, 2), money.New(4000, 2)
+	page, err := sts.store.GetTransactions(ctx, accID, userID, TransactionFilter{Types: []string{"Debit"}, MinAmount: &min, MaxAmount: &max})
+	sts.Require().NoError(err)
+	sts.Len(page.Transactions, 3)
+	sts.Equal("20.00", page.Transactions[0].Amount.String())
+	sts.Empty(page.NextCursor)
+
+	page, err = sts.store.GetTransactions(ctx, accID, userID, TransactionFilter{From: time.Now().Add(time.Hour)})
+	sts.Require().NoError(err)
+	sts.Empty(page.Transactions)
+
+	_, err = sts.store.GetTransactions(ctx, accID, userID, TransactionFilter{Cursor: "not-a-cursor"})
+	sts.Equal(ErrInvalidCursor, err)
+}
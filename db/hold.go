@@ -0,0 +1,252 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+// SettlementAccountID is the internal account captured holds are paid to,
+// created by the holds migration.
+const SettlementAccountID = "00000000-0000-0000-0000-000000000006"
+
+// Hold statuses
+const (
+	HoldActive   = "active"
+	HoldCaptured = "captured"
+	HoldReleased = "released"
+	HoldExpired  = "expired"
+)
+
+const (
+	createHoldQuery = `INSERT INTO holds(id, account_id, amount, reference, status, expires_at, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 'active', $5, $6, $7, $7) RETURNING *`
+	holdReferenceExistsQuery = `SELECT EXISTS (SELECT 1 FROM holds WHERE account_id=$1 AND reference=$2)`
+	getAccountHoldsQuery     = `SELECT * FROM holds WHERE account_id=$1 ORDER BY created_at DESC`
+	getHoldForUpdateQuery    = `SELECT * FROM holds WHERE id=$1 AND account_id=$2 FOR UPDATE`
+	closeHoldQuery           = `UPDATE holds SET status=$2, captured_amount=$3, transaction_id=$4, updated_at=$5 WHERE id=$1 RETURNING *`
+	// the held sum of an account changes with its active holds
+	adjustHeldQuery                  = `UPDATE accounts SET held=held+$2 WHERE id=$1`
+	getAccountsWithExpiredHoldsQuery = `SELECT DISTINCT account_id FROM holds WHERE status='active' AND expires_at <= $1`
+	expireAccountHoldsQuery          = `UPDATE holds SET status='expired', updated_at=$2 WHERE account_id=$1 AND status='active' AND expires_at <= $2 RETURNING *`
+)
+
+// Hold reserves Amount of an account until ExpiresAt, for a payment that
+// settles later. While it is active the amount cannot be taken out of the
+// account by anything else. Capturing it debits the account with the final
+// amount, at most the held one.
+type Hold struct {
+	ID             string        `json:"id" db:"id"`
+	AccountID      string        `json:"account_id" db:"account_id"`
+	Amount         money.Amount  `json:"amount" db:"amount"`
+	Reference      string        `json:"reference" db:"reference"`
+	Status         string        `json:"status" db:"status"`
+	CapturedAmount *money.Amount `json:"captured_amount,omitempty" db:"captured_amount"`
+	// TransactionID is the debit a captured hold was settled with
+	TransactionID *string   `json:"transaction_id,omitempty" db:"transaction_id"`
+	ExpiresAt     time.Time `json:"expires_at" db:"expires_at"`
+	CreatedBy     *string   `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateHold places a hold on a customer account. The hold has to fit in the
+// available balance and under the debit limits of the account, as a debit
+// of its amount would.
+func (s *store) CreateHold(ctx context.Context, h Hold) (created Hold, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		acc, err := s.lockAccount(ctx, h.AccountID)
+		if err != nil {
+			return err
+		}
+		if !acc.Product.AllowsWithdrawal {
+			return ErrOperationNotAllowed
+		}
+		if !allowsDebit(acc.Status) {
+			return ErrDebitNotAllowed
+		}
+
+		var exists bool
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &exists, holdReferenceExistsQuery, acc.ID, h.Reference); err != nil {
+			return err
+		}
+		if exists {
+			return ErrHoldReferenceExists
+		}
+
+		if err := s.checkDebitLimits(ctx, acc.ID, h.Amount); err != nil {
+			return err
+		}
+		if acc.available().Cmp(h.Amount) < 0 {
+			fmt.Printf("amount %v cannot be held on account %v. insufficient funds: %v\n",
+				h.Amount, acc.ID, acc.available())
+			return ErrInsufficientFunds
+		}
+
+		err = sqlx.GetContext(ctx, s.conn(ctx), &created, createHoldQuery,
+			h.ID, acc.ID, h.Amount, h.Reference, h.ExpiresAt, h.CreatedBy, time.Now())
+		if err != nil {
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, adjustHeldQuery, acc.ID, h.Amount)
+		return err
+	})
+	if err != nil {
+		created = Hold{}
+	}
+	return
+}
+
+// GetAccountHolds lists the holds of a customer account, latest first.
+func (s *store) GetAccountHolds(ctx context.Context, accID string) (holds []Hold, err error) {
+	if _, err = s.GetAccountDetails(ctx, accID, AnyOwner); err != nil {
+		return
+	}
+
+	holds = make([]Hold, 0)
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.SelectContext(ctx, s.conn(ctx), &holds, getAccountHoldsQuery, accID)
+	})
+	return
+}
+
+// lockHold reads an active hold on the locked account and locks it.
+func (s *store) lockHold(ctx context.Context, accID, holdID string) (h Hold, err error) {
+	err = sqlx.GetContext(ctx, s.conn(ctx), &h, getHoldForUpdateQuery, holdID, accID)
+	if err == sql.ErrNoRows {
+		return h, ErrHoldNotExist
+	}
+	if err == nil && h.Status != HoldActive {
+		err = ErrHoldNotActive
+	}
+	return
+}
+
+// CaptureHold settles an active hold by debiting the account with amount,
+// or the whole hold when amount is nil. Whatever is not captured is released.
+func (s *store) CaptureHold(ctx context.Context, accID, holdID string, amount *money.Amount) (captured Hold, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		acc, err := s.lockAccount(ctx, accID)
+		if err != nil {
+			return err
+		}
+		h, err := s.lockHold(ctx, accID, holdID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if !h.ExpiresAt.After(now) {
+			return ErrHoldNotActive
+		}
+		if amount == nil {
+			amount = &h.Amount
+		}
+		if amount.Cmp(h.Amount) > 0 {
+			return ErrCaptureExceedsHold
+		}
+		if !allowsDebit(acc.Status) {
+			return ErrDebitNotAllowed
+		}
+
+		// the reserved money is debited, no further check of the balance is
+		// needed
+		if _, err := s.conn(ctx).ExecContext(ctx, adjustHeldQuery, acc.ID, h.Amount.Neg()); err != nil {
+			return err
+		}
+
+		entry := newJournalEntry("Hold capture",
+			Posting{AccountID: acc.ID, Amount: amount.Neg()},
+			Posting{AccountID: internalAccountID(SettlementAccountID, acc.Currency), Amount: *amount},
+		)
+		balances, err := s.postJournalEntry(ctx, entry)
+		if err != nil {
+			return err
+		}
+
+		t := Transaction{
+			ID:             uuidgen.New(),
+			Type:           "Debit",
+			Amount:         *amount,
+			Balance:        balances[acc.ID],
+			CreatedAt:      entry.CreatedAt,
+			AccountID:      acc.ID,
+			JournalEntryID: &entry.ID,
+		}
+		if err := s.AddTransaction(ctx, t); err != nil {
+			return err
+		}
+
+		fmt.Printf("Captured amount: %v of hold: %v on account: %v. Balance: %v\n", *amount, h.ID, acc.ID, t.Balance)
+		return sqlx.GetContext(ctx, s.conn(ctx), &captured, closeHoldQuery, h.ID, HoldCaptured, *amount, t.ID, now)
+	})
+	if err != nil {
+		captured = Hold{}
+	}
+	return
+}
+
+// ReleaseHold gives the money of an active hold back to the account without
+// debiting anything.
+func (s *store) ReleaseHold(ctx context.Context, accID, holdID string) (released Hold, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		if _, err := s.lockAccount(ctx, accID); err != nil {
+			return err
+		}
+		h, err := s.lockHold(ctx, accID, holdID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.conn(ctx).ExecContext(ctx, adjustHeldQuery, accID, h.Amount.Neg()); err != nil {
+			return err
+		}
+		return sqlx.GetContext(ctx, s.conn(ctx), &released, closeHoldQuery, h.ID, HoldReleased, nil, nil, time.Now())
+	})
+	if err != nil {
+		released = Hold{}
+	}
+	return
+}
+
+// ExpireHolds releases the active holds that expired by now, one account at
+// a time, and returns them. It reads every account, so it does not use the
+// default timeout.
+func (s *store) ExpireHolds(ctx context.Context, now time.Time) (expired []Hold, err error) {
+	var accIDs []string
+	if err = sqlx.SelectContext(ctx, s.conn(ctx), &accIDs, getAccountsWithExpiredHoldsQuery, now); err != nil {
+		return
+	}
+
+	expired = make([]Hold, 0)
+	for _, accID := range accIDs {
+		var holds []Hold
+		err = s.withTx(ctx, func(ctx context.Context) error {
+			if _, err := s.lockAccount(ctx, accID); err != nil {
+				return err
+			}
+			if err := sqlx.SelectContext(ctx, s.conn(ctx), &holds, expireAccountHoldsQuery, accID, now); err != nil {
+				return err
+			}
+
+			held := money.Amount{}
+			for _, h := range holds {
+				held = held.Add(h.Amount)
+			}
+			_, err := s.conn(ctx).ExecContext(ctx, adjustHeldQuery, accID, held.Neg())
+			return err
+		})
+		if err != nil {
+			return
+		}
+		expired = append(expired, holds...)
+	}
+	return
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_Holds() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(10000, 2))
+	expiresAt := time.Now().Add(time.Hour)
+	hold := func(amount money.Amount, reference string) Hold {
+		return Hold{ID: uuidgen.New(), AccountID: accID, Amount: amount, Reference: reference, ExpiresAt: expiresAt}
+	}
+
+	card, err := sts.store.CreateHold(ctx, hold(money.New(6000, 2), "auth-1"))
+	sts.Require().NoError(err)
+	sts.Equal(HoldActive, card.Status)
+	_, err = sts.store.CreateHold(ctx, hold(money.New(100, 2), "auth-1"))
+	sts.Equal(ErrHoldReferenceExists, err)
+
+	// the held money is no longer available, though still in the balance
+	acc, err := sts.store.GetAccountDetails(ctx, accID, userID)
+	sts.Require().NoError(err)
+	sts.Equal("100.00", acc.Balance.String())
+	sts.Equal("60.00", acc.Held.String())
+	sts.Equal("40.00", acc.AvailableBalance.String())
+	_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(5000, 2))
+	sts.Equal(ErrInsufficientFunds, err)
+	_, err = sts.store.CreateHold(ctx, hold(money.New(5000, 2), "auth-2"))
+	sts.Equal(ErrInsufficientFunds, err)
+
+	// a partial capture debits the final amount and releases the rest
+	over, partial := money.New(6001, 2), money.New(4500, 2)
+	_, err = sts.store.CaptureHold(ctx, accID, card.ID, &over)
+	sts.Equal(ErrCaptureExceedsHold, err)
+	captured, err := sts.store.CaptureHold(ctx, accID, card.ID, &partial)
+	sts.Require().NoError(err)
+	sts.Equal(HoldCaptured, captured.Status)
+	sts.Equal("45.00", captured.CapturedAmount.String())
+	sts.Require().NotNil(captured.TransactionID)
+	sts.Equal("55.00", sts.balance(accID).String())
+	_, err = sts.store.CaptureHold(ctx, accID, card.ID, nil)
+	sts.Equal(ErrHoldNotActive, err)
+
+	released, err := sts.store.CreateHold(ctx, hold(money.New(2000, 2), "auth-3"))
+	sts.Require().NoError(err)
+	released, err = sts.store.ReleaseHold(ctx, accID, released.ID)
+	sts.Require().NoError(err)
+	sts.Equal(HoldReleased, released.Status)
+	_, err = sts.store.ReleaseHold(ctx, accID, released.ID)
+	sts.Equal(ErrHoldNotActive, err)
+	_, err = sts.store.ReleaseHold(ctx, accID, uuidgen.New())
+	sts.Equal(ErrHoldNotExist, err)
+
+	// expired holds are released by the sweep and cannot be captured
+	stale, err := sts.store.CreateHold(ctx, hold(money.New(1000, 2), "auth-4"))
+	sts.Require().NoError(err)
+	expired, err := sts.store.ExpireHolds(ctx, expiresAt.Add(time.Second))
+	sts.Require().NoError(err)
+	var expiredIDs []string
+	for _, h := range expired {
+		expiredIDs = append(expiredIDs, h.ID)
+	}
+	sts.Contains(expiredIDs, stale.ID)
+	_, err = sts.store.CaptureHold(ctx, accID, stale.ID, nil)
+	sts.Equal(ErrHoldNotActive, err)
+
+	acc, err = sts.store.GetAccountDetails(ctx, accID, userID)
+	sts.Require().NoError(err)
+	sts.True(acc.Held.IsZero())
+	sts.Equal("55.00", acc.AvailableBalance.String())
+
+	holds, err := sts.store.GetAccountHolds(ctx, accID)
+	sts.Require().NoError(err)
+	sts.Len(holds, 3)
+
+	mismatches, err := sts.store.ReconcileLedger(ctx)
+	sts.Require().NoError(err)
+	sts.Empty(mismatches)
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	deleteExpiredIdempotencyKeysQuery = `DELETE FROM idempotency_keys WHERE created_at < $1`
+	reserveIdempotencyKeyQuery        = `INSERT INTO idempotency_keys(user_id, key, fingerprint, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`
+	getIdempotencyKeyQuery            = `SELECT * FROM idempotency_keys WHERE user_id=$1 AND key=$2`
+	saveIdempotentResponseQuery       = `UPDATE idempotency_keys SET status_code=$3, response_body=$4 WHERE user_id=$1 AND key=$2`
+	deleteIdempotencyKeyQuery         = `DELETE FROM idempotency_keys WHERE user_id=$1 AND key=$2`
+)
+
+// IdempotencyKey is a client supplied key together with the request it was
+// first used for and, once that request completed, its response.
+type IdempotencyKey struct {
+	UserID       string    `db:"user_id"`
+	Key          string    `db:"key"`
+	Fingerprint  string    `db:"fingerprint"`
+	StatusCode   *int      `db:"status_code"`
+	ResponseBody []byte    `db:"response_body"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// ReserveIdempotencyKey claims k for a new request. Keys older than ttl are
+// forgotten first. If the key is already in use, reserved is false and the
+// stored key is returned instead.
+func (s *store) ReserveIdempotencyKey(ctx context.Context, k IdempotencyKey, ttl time.Duration) (existing IdempotencyKey, reserved bool, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		if _, err := s.conn(ctx).ExecContext(ctx, deleteExpiredIdempotencyKeysQuery, time.Now().Add(-ttl)); err != nil {
+			return err
+		}
+
+		res, err := s.conn(ctx).ExecContext(ctx, reserveIdempotencyKeyQuery, k.UserID, k.Key, k.Fingerprint, time.Now())
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 1 {
+			reserved = true
+			return err
+		}
+
+		return sqlx.GetContext(ctx, s.conn(ctx), &existing, getIdempotencyKeyQuery, k.UserID, k.Key)
+	})
+
+	if err == sql.ErrNoRows {
+		// the key expired between the insert and the read, the caller can retry
+		err = ErrIdempotencyKeyNotExist
+	}
+	return
+}
+
+func (s *store) SaveIdempotentResponse(ctx context.Context, userID, key string, statusCode int, body []byte) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		_, err := s.conn(ctx).ExecContext(ctx, saveIdempotentResponseQuery, userID, key, statusCode, body)
+		return err
+	})
+	return
+}
+
+func (s *store) DeleteIdempotencyKey(ctx context.Context, userID, key string) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		_, err := s.conn(ctx).ExecContext(ctx, deleteIdempotencyKeyQuery, userID, key)
+		return err
+	})
+	return
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+const businessDateFormat = "2006-01-02"
+
+const (
+	// The balance is the one at the end of the business date, taken from the
+	// ledger so that past dates can be accrued again. Overdrawn accounts are
+	// charged the overdraft product of their type instead of earning interest.
+	getInterestAccountsQuery = `SELECT accounts.id AS account_id, interest_products.name AS product,
+		interest_products.annual_rate, interest_products.day_count, interest_products.compounding, interest_products.posting_frequency,
+		interest_products.kind, day_end.balance,
+		COALESCE((SELECT SUM(amount) FROM interest_accruals
+			WHERE account_id=accounts.id AND transaction_id IS NULL AND business_date < $1::DATE), 0) AS accrued,
+		EXISTS (SELECT 1 FROM interest_accruals WHERE account_id=accounts.id AND business_date=$1::DATE) AS accrued_on_date
+		FROM accounts
+		INNER JOIN account_types ON account_types.name=accounts.type
+		CROSS JOIN LATERAL (SELECT COALESCE(SUM(postings.amount), 0) AS balance
+			FROM postings INNER JOIN journal_entries ON journal_entries.id=postings.journal_entry_id
+			WHERE postings.account_id=accounts.id AND journal_entries.created_at < $1::DATE + 1) day_end
+		INNER JOIN interest_products ON interest_products.name =
+			CASE WHEN day_end.balance < 0 THEN account_types.overdraft_product ELSE account_types.interest_product END
+		WHERE accounts.user_id IS NOT NULL AND accounts.status <> 'closed'
+		ORDER BY accounts.id`
+	createInterestAccrualQuery = `INSERT INTO interest_accruals(account_id, business_date, product, balance, amount) VALUES ($1, $2::DATE, $3, $4, $5)
+		ON CONFLICT DO NOTHING`
+	getUnpostedInterestQuery = `SELECT COALESCE(SUM(amount), 0) FROM interest_accruals
+		WHERE account_id=$1 AND transaction_id IS NULL AND business_date <= $2::DATE`
+	postInterestAccrualsQuery = `UPDATE interest_accruals SET transaction_id=$3
+		WHERE account_id=$1 AND transaction_id IS NULL AND business_date <= $2::DATE`
+)
+
+// InterestAccount is an account that earns interest, as of a business date.
+type InterestAccount struct {
+	AccountID        string       `db:"account_id"`
+	Product          string       `db:"product"`
+	AnnualRate       money.Amount `db:"annual_rate"`
+	DayCount         string       `db:"day_count"`
+	Compounding      string       `db:"compounding"`
+	PostingFrequency string       `db:"posting_frequency"`
+	Kind             string       `db:"kind"`
+	// Balance at the end of the business date
+	Balance money.Amount `db:"balance"`
+	// Accrued interest not yet posted, from the days before
+	Accrued money.Amount `db:"accrued"`
+	// AccruedOnDate is set when the business date was already accrued
+	AccruedOnDate bool `db:"accrued_on_date"`
+}
+
+// InterestAccrual is the interest an account earned on one business date.
+type InterestAccrual struct {
+	AccountID    string
+	BusinessDate time.Time
+	Product      string
+	Balance      money.Amount
+	Amount       money.Amount
+}
+
+// GetInterestAccounts lists the open accounts whose type earns interest. It
+// reads every account, so it does not use the default timeout.
+func (s *store) GetInterestAccounts(ctx context.Context, businessDate time.Time) (accounts []InterestAccount, err error) {
+	accounts = make([]InterestAccount, 0)
+	err = sqlx.SelectContext(ctx, s.conn(ctx), &accounts, getInterestAccountsQuery, businessDate.Format(businessDateFormat))
+	return
+}
+
+// AddInterestAccrual records the interest of an account for a business date.
+// A business date is only accrued once, later accruals for it are ignored.
+func (s *store) AddInterestAccrual(ctx context.Context, a InterestAccrual) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		_, err := s.conn(ctx).ExecContext(ctx, createInterestAccrualQuery,
+			a.AccountID, a.BusinessDate.Format(businessDateFormat), a.Product, a.Balance, a.Amount)
+		return err
+	})
+	return
+}
+
+// PostInterest posts the interest accrued up to the business date, as a
+// transaction dated at the end of that day. Earned interest is paid into the
+// account as an Interest transaction; overdraft interest, which accrues as a
+// negative amount, is charged as an Overdraft Interest transaction. The
+// interest is rounded to the currency; while it rounds to zero it stays
+// accrued.
+func (s *store) PostInterest(ctx context.Context, accID string, businessDate time.Time) (posted money.Amount, err error) {
+	date := businessDate.Format(businessDateFormat)
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		acc, err := s.lockAccount(ctx, accID)
+		if err != nil {
+			return err
+		}
+		if acc.Status == AccountClosed {
+			return nil
+		}
+
+		var accrued money.Amount
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &accrued, getUnpostedInterestQuery, accID, date); err != nil {
+			return err
+		}
+		cur, err := money.LookupCurrency(acc.Currency)
+		if err != nil {
+			return err
+		}
+		posted = accrued.Round(cur.Digits)
+		if posted.IsZero() {
+			return nil
+		}
+
+		txType, counterpart, amount := "Interest", internalAccountID(InterestExpenseAccountID, acc.Currency), posted
+		if posted.Sign() < 0 {
+			txType, counterpart, amount = "Overdraft Interest", internalAccountID(InterestIncomeAccountID, acc.Currency), posted.Neg()
+		}
+
+		entry := newJournalEntry(txType,
+			Posting{AccountID: accID, Amount: posted},
+			Posting{AccountID: counterpart, Amount: posted.Neg()},
+		)
+		entry.CreatedAt = businessDate.AddDate(0, 0, 1).Add(-time.Millisecond).Format("2006-01-02 15:04:05.000")
+		balances, err := s.postJournalEntry(ctx, entry)
+		if err != nil {
+			return err
+		}
+
+		t := Transaction{
+			ID:             uuidgen.New(),
+			Type:           txType,
+			Amount:         amount,
+			Balance:        balances[accID],
+			CreatedAt:      entry.CreatedAt,
+			AccountID:      accID,
+			JournalEntryID: &entry.ID,
+		}
+		if err := s.AddTransaction(ctx, t); err != nil {
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, postInterestAccrualsQuery, accID, date, t.ID)
+		return err
+	})
+	if err != nil || posted.IsZero() {
+		posted = money.Amount{}
+	}
+	return
+}
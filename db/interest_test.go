@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_PostInterest() {
+	ctx := context.Background()
+	accID, _ := sts.createFundedAccount(money.New(100000, 2))
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	accounts, err := sts.store.GetInterestAccounts(ctx, yesterday)
+	sts.Require().NoError(err)
+	var acc *InterestAccount
+	for i := range accounts {
+		if accounts[i].AccountID == accID {
+			acc = &accounts[i]
+		}
+	}
+	sts.Require().NotNil(acc, "savings accounts earn interest")
+	sts.Equal("savings", acc.Product)
+	sts.False(acc.AccruedOnDate)
+
+	accrual := InterestAccrual{AccountID: accID, BusinessDate: yesterday, Product: acc.Product, Balance: acc.Balance, Amount: money.New(1234567, 6)}
+	sts.Require().NoError(sts.store.AddInterestAccrual(ctx, accrual))
+	// accruing the same date again is ignored
+	sts.Require().NoError(sts.store.AddInterestAccrual(ctx, accrual))
+
+	posted, err := sts.store.PostInterest(ctx, accID, yesterday)
+	sts.Require().NoError(err)
+	sts.Equal("1.23", posted.String())
+	sts.Equal("1001.23", sts.balance(accID).String())
+
+	// nothing is left to post
+	posted, err = sts.store.PostInterest(ctx, accID, yesterday)
+	sts.Require().NoError(err)
+	sts.True(posted.IsZero())
+
+	mismatches, err := sts.store.ReconcileLedger(ctx)
+	sts.Require().NoError(err)
+	sts.Empty(mismatches)
+}
+
+func (sts *StoreTestSuite) Test_store_PostInterest_Currency() {
+	ctx := context.Background()
+	u := User{
+		Email:       fmt.Sprintf("%s@test.com", uuidgen.New()[:8]),
+		PhoneNumber: "9999999999",
+		Password:    uuidgen.New(),
+		Type:        "customer",
+	}
+	jpyAccID := uuidgen.New()
+	sts.Require().NoError(sts.store.CreateAccount(ctx, u, Account{ID: jpyAccID, Balance: money.New(0, 0), Type: "savings", Currency: "JPY"}))
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	// interest is posted in whole yen
+	accrual := InterestAccrual{AccountID: jpyAccID, BusinessDate: yesterday, Product: "savings", Balance: money.New(0, 0), Amount: money.New(1234567, 6)}
+	sts.Require().NoError(sts.store.AddInterestAccrual(ctx, accrual))
+
+	posted, err := sts.store.PostInterest(ctx, jpyAccID, yesterday)
+	sts.Require().NoError(err)
+	sts.Equal("1", posted.String())
+	sts.Require().NoError(money.JPY.Check(sts.balance(jpyAccID)))
+}
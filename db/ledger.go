@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+// Bank internal accounts, created by the ledger migration. They hold the
+// other side of every movement into or out of customer accounts.
+const (
+	CashAccountID            = "00000000-0000-0000-0000-000000000001"
+	SuspenseAccountID        = "00000000-0000-0000-0000-000000000002"
+	InterestExpenseAccountID = "00000000-0000-0000-0000-000000000003"
+	FeeIncomeAccountID       = "00000000-0000-0000-0000-000000000004"
+	InterestIncomeAccountID  = "00000000-0000-0000-0000-000000000005"
+)
+
+// internalAccountIDs are the counterparts of the internal accounts, which hold
+// INR, in the other currencies, created by the internal accounts per currency
+// migration.
+var internalAccountIDs = map[string]map[string]string{
+	money.USD.Code: {
+		CashAccountID:            "00000000-0000-0001-0001-000000000840",
+		InterestExpenseAccountID: "00000000-0000-0003-0001-000000000840",
+		FeeIncomeAccountID:       "00000000-0000-0004-0001-000000000840",
+		InterestIncomeAccountID:  "00000000-0000-0005-0001-000000000840",
+		SettlementAccountID:      "00000000-0000-0006-0001-000000000840",
+	},
+	money.EUR.Code: {
+		CashAccountID:            "00000000-0000-0001-0001-000000000978",
+		InterestExpenseAccountID: "00000000-0000-0003-0001-000000000978",
+		FeeIncomeAccountID:       "00000000-0000-0004-0001-000000000978",
+		InterestIncomeAccountID:  "00000000-0000-0005-0001-000000000978",
+		SettlementAccountID:      "00000000-0000-0006-0001-000000000978",
+	},
+	money.GBP.Code: {
+		CashAccountID:            "00000000-0000-0001-0001-000000000826",
+		InterestExpenseAccountID: "00000000-0000-0003-0001-000000000826",
+		FeeIncomeAccountID:       "00000000-0000-0004-0001-000000000826",
+		InterestIncomeAccountID:  "00000000-0000-0005-0001-000000000826",
+		SettlementAccountID:      "00000000-0000-0006-0001-000000000826",
+	},
+	money.JPY.Code: {
+		CashAccountID:            "00000000-0000-0001-0001-000000000392",
+		InterestExpenseAccountID: "00000000-0000-0003-0001-000000000392",
+		FeeIncomeAccountID:       "00000000-0000-0004-0001-000000000392",
+		InterestIncomeAccountID:  "00000000-0000-0005-0001-000000000392",
+		SettlementAccountID:      "00000000-0000-0006-0001-000000000392",
+	},
+	money.KWD.Code: {
+		CashAccountID:            "00000000-0000-0001-0001-000000000414",
+		InterestExpenseAccountID: "00000000-0000-0003-0001-000000000414",
+		FeeIncomeAccountID:       "00000000-0000-0004-0001-000000000414",
+		InterestIncomeAccountID:  "00000000-0000-0005-0001-000000000414",
+		SettlementAccountID:      "00000000-0000-0006-0001-000000000414",
+	},
+}
+
+// internalAccountID returns the internal account of the kind of id, one of the
+// INR internal accounts, that holds the currency. Movements into or out of an
+// account are posted against the one of its currency, so that no account ever
+// mixes currencies.
+func internalAccountID(id, currency string) string {
+	if ids, ok := internalAccountIDs[currency]; ok {
+		return ids[id]
+	}
+	return id
+}
+
+const (
+	createJournalEntryQuery = `INSERT INTO journal_entries(id, description, created_at) VALUES ($1, $2, $3)`
+	createPostingQuery      = `INSERT INTO postings(id, journal_entry_id, account_id, amount) VALUES ($1, $2, $3, $4)`
+	applyPostingQuery       = `UPDATE accounts SET balance=balance+$1 WHERE id=$2 RETURNING balance`
+	reconcileLedgerQuery    = `SELECT accounts.id, accounts.balance, COALESCE(SUM(postings.amount), 0) AS ledger_balance
+		FROM accounts LEFT JOIN postings ON postings.account_id=accounts.id
+		GROUP BY accounts.id, accounts.balance
+		HAVING accounts.balance <> COALESCE(SUM(postings.amount), 0)`
+)
+
+// JournalEntry is a single balanced movement of money between accounts.
+type JournalEntry struct {
+	ID          string    `db:"id"`
+	Description string    `db:"description"`
+	CreatedAt   string    `db:"created_at"`
+	Postings    []Posting `db:"-"`
+}
+
+// Posting changes the balance of one account by a signed amount.
+type Posting struct {
+	ID             string       `db:"id"`
+	JournalEntryID string       `db:"journal_entry_id"`
+	AccountID      string       `db:"account_id"`
+	Amount         money.Amount `db:"amount"`
+}
+
+// BalanceMismatch is an account whose stored balance differs from the sum of
+// its postings.
+type BalanceMismatch struct {
+	AccountID     string       `json:"account_id" db:"id"`
+	Balance       money.Amount `json:"balance" db:"balance"`
+	LedgerBalance money.Amount `json:"ledger_balance" db:"ledger_balance"`
+}
+
+func newJournalEntry(description string, postings ...Posting) JournalEntry {
+	return JournalEntry{
+		ID:          uuidgen.New(),
+		Description: description,
+		CreatedAt:   time.Now().Format("2006-01-02 15:04:05.000"),
+		Postings:    postings,
+	}
+}
+
+// postJournalEntry records e and applies its postings to the account balances.
+// It must run inside the transaction of the movement it records, and returns
+// the resulting balance of every account it touched.
+func (s *store) postJournalEntry(ctx context.Context, e JournalEntry) (balances map[string]money.Amount, err error) {
+	total := money.Amount{}
+	for _, p := range e.Postings {
+		total = total.Add(p.Amount)
+	}
+	if len(e.Postings) < 2 || !total.IsZero() {
+		return nil, fmt.Errorf("%w: %v", ErrUnbalancedEntry, e.Description)
+	}
+
+	conn := s.conn(ctx)
+	if _, err = conn.ExecContext(ctx, createJournalEntryQuery, e.ID, e.Description, e.CreatedAt); err != nil {
+		return
+	}
+
+	balances = make(map[string]money.Amount, len(e.Postings))
+	for _, p := range e.Postings {
+		if _, err = conn.ExecContext(ctx, createPostingQuery, uuidgen.New(), e.ID, p.AccountID, p.Amount); err != nil {
+			return
+		}
+
+		var balance money.Amount
+		if err = sqlx.GetContext(ctx, conn, &balance, applyPostingQuery, p.Amount, p.AccountID); err != nil {
+			return
+		}
+		balances[p.AccountID] = balance
+	}
+	return
+}
+
+func (s *store) ReconcileLedger(ctx context.Context) (mismatches []BalanceMismatch, err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return s.db.SelectContext(ctx, &mismatches, reconcileLedgerQuery)
+	})
+	return
+}
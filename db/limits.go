@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"example.com/banking/money"
+)
+
+const (
+	// limits set on the account replace the ones of its type, which only cap
+	// the accounts in the currency they are set in
+	getDebitLimitsQuery = `SELECT COALESCE(accounts.single_debit_limit, account_types.single_debit_limit) AS single_debit_limit,
+		COALESCE(accounts.daily_debit_limit, account_types.daily_debit_limit) AS daily_debit_limit,
+		COALESCE(accounts.monthly_debit_limit, account_types.monthly_debit_limit) AS monthly_debit_limit
+		FROM accounts LEFT JOIN account_types ON account_types.name=accounts.type AND account_types.limit_currency=accounts.currency
+		WHERE accounts.id=$1`
+	sumDebitsSinceQuery        = `SELECT COALESCE(SUM(amount), 0) FROM transactions WHERE account_id=$1 AND type='Debit' AND created_at >= $2::TIMESTAMP`
+	setAccountDebitLimitsQuery = `UPDATE accounts SET single_debit_limit=$2, daily_debit_limit=$3, monthly_debit_limit=$4 WHERE id=$1 AND user_id IS NOT NULL`
+)
+
+// DebitLimits cap the money taken out of an account by withdrawals and
+// transfers: per transaction, and summed over a calendar day or month. A nil
+// limit does not cap anything.
+type DebitLimits struct {
+	Single  *money.Amount `json:"single_debit_limit" db:"single_debit_limit"`
+	Daily   *money.Amount `json:"daily_debit_limit" db:"daily_debit_limit"`
+	Monthly *money.Amount `json:"monthly_debit_limit" db:"monthly_debit_limit"`
+}
+
+// AccountLimits are the debit limits of an account and what is left of them.
+type AccountLimits struct {
+	AccountID string `json:"account_id"`
+	DebitLimits
+	DebitedToday     money.Amount  `json:"debited_today"`
+	DebitedThisMonth money.Amount  `json:"debited_this_month"`
+	DailyRemaining   *money.Amount `json:"daily_remaining"`
+	MonthlyRemaining *money.Amount `json:"monthly_remaining"`
+	// MaxDebit is the most a single debit can take out now, as far as the
+	// limits go
+	MaxDebit *money.Amount `json:"max_debit"`
+}
+
+// accountLimits reads the debit limits of an account and the debits of the
+// day and month of now.
+func (s *store) accountLimits(ctx context.Context, accID string, now time.Time) (limits AccountLimits, err error) {
+	limits.AccountID = accID
+	err = sqlx.GetContext(ctx, s.conn(ctx), &limits.DebitLimits, getDebitLimitsQuery, accID)
+	if err == sql.ErrNoRows {
+		return limits, ErrAccountNotExist
+	}
+	if err != nil {
+		return
+	}
+
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if err = sqlx.GetContext(ctx, s.conn(ctx), &limits.DebitedToday, sumDebitsSinceQuery, accID, dayStart.Format("2006-01-02 15:04:05.000")); err != nil {
+		return
+	}
+	if err = sqlx.GetContext(ctx, s.conn(ctx), &limits.DebitedThisMonth, sumDebitsSinceQuery, accID, monthStart.Format("2006-01-02 15:04:05.000")); err != nil {
+		return
+	}
+
+	limits.DailyRemaining = remaining(limits.Daily, limits.DebitedToday)
+	limits.MonthlyRemaining = remaining(limits.Monthly, limits.DebitedThisMonth)
+	for _, l := range []*money.Amount{limits.Single, limits.DailyRemaining, limits.MonthlyRemaining} {
+		if l != nil && (limits.MaxDebit == nil || l.Cmp(*limits.MaxDebit) < 0) {
+			limits.MaxDebit = l
+		}
+	}
+	return
+}
+
+// remaining is what is left of limit after used, never below zero.
+func remaining(limit *money.Amount, used money.Amount) *money.Amount {
+	if limit == nil {
+		return nil
+	}
+	left := limit.Sub(used)
+	if left.Sign() < 0 {
+		left = money.New(0, left.Scale())
+	}
+	return &left
+}
+
+// checkDebitLimits returns ErrLimitExceeded if debiting amount from the
+// account breaks one of its limits. The account must be locked, so that
+// concurrent debits are counted one after the other.
+func (s *store) checkDebitLimits(ctx context.Context, accID string, amount money.Amount) (err error) {
+	limits, err := s.accountLimits(ctx, accID, time.Now())
+	if err != nil {
+		return
+	}
+
+	switch {
+	case limits.Single != nil && amount.Cmp(*limits.Single) > 0:
+		err = fmt.Errorf("%w: a single debit may not exceed %v", ErrLimitExceeded, *limits.Single)
+	case limits.DailyRemaining != nil && amount.Cmp(*limits.DailyRemaining) > 0:
+		err = fmt.Errorf("%w: daily debit limit of %v, %v remaining today", ErrLimitExceeded, *limits.Daily, *limits.DailyRemaining)
+	case limits.MonthlyRemaining != nil && amount.Cmp(*limits.MonthlyRemaining) > 0:
+		err = fmt.Errorf("%w: monthly debit limit of %v, %v remaining this month", ErrLimitExceeded, *limits.Monthly, *limits.MonthlyRemaining)
+	}
+	return
+}
+
+// GetAccountLimits returns the debit limits of the user's account and what
+// is left of them.
+func (s *store) GetAccountLimits(ctx context.Context, accID, userID string) (limits AccountLimits, err error) {
+	if _, err = s.GetAccountDetails(ctx, accID, userID); err != nil {
+		return
+	}
+
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) (err error) {
+		limits, err = s.accountLimits(ctx, accID, time.Now())
+		return
+	})
+	return
+}
+
+// SetAccountDebitLimits sets limits of the account that replace the ones of
+// its type. A nil limit falls back to the limit of the type.
+func (s *store) SetAccountDebitLimits(ctx context.Context, accID string, limits DebitLimits) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		res, err := s.conn(ctx).ExecContext(ctx, setAccountDebitLimitsQuery, accID, limits.Single, limits.Daily, limits.Monthly)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			err = ErrAccountNotExist
+		}
+		return err
+	})
+	return
+}
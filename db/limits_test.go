@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_DebitLimits() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(100000, 2))
+
+	single, daily := money.New(40000, 2), money.New(60000, 2)
+	sts.Require().NoError(sts.store.SetAccountDebitLimits(ctx, accID, DebitLimits{Single: &single, Daily: &daily}))
+
+	_, err := sts.store.WithdrawAmount(ctx, accID, userID, money.New(50000, 2))
+	sts.True(errors.Is(err, ErrLimitExceeded))
+
+	_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(40000, 2))
+	sts.Require().NoError(err)
+
+	limits, err := sts.store.GetAccountLimits(ctx, accID, userID)
+	sts.Require().NoError(err)
+	sts.Equal("400.00", limits.DebitedToday.String())
+	sts.Equal("200.00", limits.DailyRemaining.String())
+	sts.Equal("200.00", limits.MaxDebit.String())
+
+	// the rest of the day's limit can still be taken out, but no more
+	_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(30000, 2))
+	sts.True(errors.Is(err, ErrLimitExceeded))
+	_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(20000, 2))
+	sts.Require().NoError(err)
+	sts.Equal("400", sts.balance(accID).Round(0).String())
+
+	// internal accounts of the bank are not customer accounts
+	sts.Equal(ErrAccountNotExist, sts.store.SetAccountDebitLimits(ctx, CashAccountID, DebitLimits{}))
+
+	// the limits of the savings type are in rupees, they do not cap an
+	// account in yen
+	u := sts.createCustomer()
+	jpyAccID := uuidgen.New()
+	sts.Require().NoError(sts.store.OpenAccount(ctx, u.ID, Account{ID: jpyAccID, Balance: money.New(0, 0), Type: "savings", Currency: "JPY"}))
+	limits, err = sts.store.GetAccountLimits(ctx, jpyAccID, u.ID)
+	sts.Require().NoError(err)
+	sts.Nil(limits.Single)
+	sts.Nil(limits.MaxDebit)
+}
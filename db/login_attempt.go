@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	LoginAttemptEmail = "email"
+	LoginAttemptIP    = "ip"
+)
+
+const (
+	getLoginAttemptsQuery = `SELECT * FROM login_attempts WHERE (kind='email' AND key=$1) OR (kind='ip' AND key=$2)`
+
+	// Failures older than resetBefore ($4) no longer count
+	recordLoginFailureQuery = `INSERT INTO login_attempts(kind, key, failures, last_failure_at) VALUES ($1, $2, 1, $3)
+		ON CONFLICT (kind, key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < $4 THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = $3
+		RETURNING *`
+	deleteLoginAttemptQuery = `DELETE FROM login_attempts WHERE kind=$1 AND key=$2`
+	unlockUserQuery         = `DELETE FROM login_attempts WHERE kind='email' AND key=(SELECT email FROM users WHERE id=$1)`
+	userExistsQuery         = `SELECT EXISTS (SELECT 1 FROM users WHERE id=$1)`
+)
+
+// LoginAttempt counts consecutive failed logins for an email address or a
+// client IP.
+type LoginAttempt struct {
+	Kind          string    `db:"kind"`
+	Key           string    `db:"key"`
+	Failures      int       `db:"failures"`
+	LastFailureAt time.Time `db:"last_failure_at"`
+}
+
+// GetLoginAttempts returns the failed login counters of the email and ip.
+func (s *store) GetLoginAttempts(ctx context.Context, email, ip string) (attempts []LoginAttempt, err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.SelectContext(ctx, s.conn(ctx), &attempts, getLoginAttemptsQuery, email, ip)
+	})
+	return
+}
+
+// RecordLoginFailure counts a failed login for the key. Earlier failures are
+// forgotten if the last one was before resetBefore.
+func (s *store) RecordLoginFailure(ctx context.Context, kind, key string, resetBefore time.Time) (attempt LoginAttempt, err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.GetContext(ctx, s.conn(ctx), &attempt, recordLoginFailureQuery, kind, key, time.Now(), resetBefore)
+	})
+	return
+}
+
+// ResetLoginFailures forgets the failed logins for the key.
+func (s *store) ResetLoginFailures(ctx context.Context, kind, key string) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		_, err := s.conn(ctx).ExecContext(ctx, deleteLoginAttemptQuery, kind, key)
+		return err
+	})
+	return
+}
+
+// UnlockUser forgets the failed logins for the user's email, which lifts a
+// lockout.
+func (s *store) UnlockUser(ctx context.Context, userID string) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		var exists bool
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &exists, userExistsQuery, userID); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrUserNotExist
+		}
+
+		_, err := s.conn(ctx).ExecContext(ctx, unlockUserQuery, userID)
+		return err
+	})
+	return
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+)
+
+func (sts *StoreTestSuite) Test_store_RecordLoginFailure() {
+	ctx := context.Background()
+	u := sts.createCustomer()
+	ip := "10." + uuidgen.New()[:8]
+
+	for i := 1; i <= 3; i++ {
+		attempt, err := sts.store.RecordLoginFailure(ctx, LoginAttemptEmail, u.Email, time.Now().Add(-time.Minute))
+		sts.Require().NoError(err)
+		sts.Equal(i, attempt.Failures)
+	}
+	_, err := sts.store.RecordLoginFailure(ctx, LoginAttemptIP, ip, time.Now().Add(-time.Minute))
+	sts.Require().NoError(err)
+
+	attempts, err := sts.store.GetLoginAttempts(ctx, u.Email, ip)
+	sts.Require().NoError(err)
+	sts.Len(attempts, 2)
+
+	// failures before resetBefore are forgotten
+	attempt, err := sts.store.RecordLoginFailure(ctx, LoginAttemptEmail, u.Email, time.Now().Add(time.Minute))
+	sts.Require().NoError(err)
+	sts.Equal(1, attempt.Failures)
+
+	sts.Require().NoError(sts.store.UnlockUser(ctx, u.ID))
+	attempts, err = sts.store.GetLoginAttempts(ctx, u.Email, "")
+	sts.Require().NoError(err)
+	sts.Empty(attempts)
+
+	sts.Equal(ErrUserNotExist, sts.store.UnlockUser(ctx, "0"))
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	getUserByIDQuery    = `SELECT * FROM users WHERE id=$1`
+	getUserByEmailQuery = `SELECT * FROM users WHERE email=$1`
+
+	getMFAQuery = `SELECT * FROM user_mfa WHERE user_id=$1`
+	// A confirmed enrolment is never replaced
+	saveMFASecretQuery = `INSERT INTO user_mfa(user_id, secret, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET secret=$2, created_at=$3, last_used_counter=0 WHERE user_mfa.confirmed_at IS NULL`
+	confirmMFAQuery          = `UPDATE user_mfa SET confirmed_at=$2, last_used_counter=$3 WHERE user_id=$1 AND confirmed_at IS NULL`
+	useMFACodeQuery          = `UPDATE user_mfa SET last_used_counter=$2 WHERE user_id=$1 AND confirmed_at IS NOT NULL AND last_used_counter < $2`
+	deleteRecoveryCodesQuery = `DELETE FROM mfa_recovery_codes WHERE user_id=$1`
+	createRecoveryCodeQuery  = `INSERT INTO mfa_recovery_codes(code_hash, user_id) VALUES ($1, $2)`
+	useRecoveryCodeQuery     = `UPDATE mfa_recovery_codes SET used_at=$3 WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL`
+)
+
+// MFA is the TOTP enrolment of a user.
+type MFA struct {
+	UserID          string     `db:"user_id"`
+	Secret          string     `db:"secret"`
+	CreatedAt       time.Time  `db:"created_at"`
+	ConfirmedAt     *time.Time `db:"confirmed_at"`
+	LastUsedCounter int64      `db:"last_used_counter"`
+}
+
+func (s *store) GetUserByID(ctx context.Context, userID string) (u User, err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.GetContext(ctx, s.conn(ctx), &u, getUserByIDQuery, userID)
+	})
+	if err == sql.ErrNoRows {
+		return u, ErrUserNotExist
+	}
+	return
+}
+
+func (s *store) GetUserByEmail(ctx context.Context, email string) (u User, err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.GetContext(ctx, s.conn(ctx), &u, getUserByEmailQuery, email)
+	})
+	if err == sql.ErrNoRows {
+		return u, ErrUserNotExist
+	}
+	return
+}
+
+// GetMFA returns the user's enrolment, or ErrMFANotEnrolled if there is none.
+func (s *store) GetMFA(ctx context.Context, userID string) (m MFA, err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.GetContext(ctx, s.conn(ctx), &m, getMFAQuery, userID)
+	})
+	if err == sql.ErrNoRows {
+		return m, ErrMFANotEnrolled
+	}
+	return
+}
+
+// SaveMFASecret starts an enrolment with the secret, replacing a pending one.
+// It returns ErrMFAAlreadyEnrolled if the user has a confirmed enrolment.
+func (s *store) SaveMFASecret(ctx context.Context, userID, secret string) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		res, err := s.conn(ctx).ExecContext(ctx, saveMFASecretQuery, userID, secret, time.Now())
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			if err == nil {
+				err = ErrMFAAlreadyEnrolled
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+// ConfirmMFA completes a pending enrolment with the counter of the code that
+// confirmed it, and replaces the user's recovery codes.
+func (s *store) ConfirmMFA(ctx context.Context, userID string, counter int64, recoveryCodeHashes []string) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		res, err := s.conn(ctx).ExecContext(ctx, confirmMFAQuery, userID, time.Now(), counter)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			if err == nil {
+				err = ErrMFAAlreadyEnrolled
+			}
+			return err
+		}
+
+		if _, err = s.conn(ctx).ExecContext(ctx, deleteRecoveryCodesQuery, userID); err != nil {
+			return err
+		}
+		for _, h := range recoveryCodeHashes {
+			if _, err = s.conn(ctx).ExecContext(ctx, createRecoveryCodeQuery, h, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// UseMFACode records that the code with the given counter was used. Codes
+// with the same or an earlier counter are refused with ErrMFACodeUsed.
+func (s *store) UseMFACode(ctx context.Context, userID string, counter int64) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		res, err := s.conn(ctx).ExecContext(ctx, useMFACodeQuery, userID, counter)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			if err == nil {
+				err = ErrMFACodeUsed
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+// UseRecoveryCode uses up the recovery code with the given hash, or returns
+// ErrRecoveryCodeNotExist if the user has no such unused code.
+func (s *store) UseRecoveryCode(ctx context.Context, userID, codeHash string) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		res, err := s.conn(ctx).ExecContext(ctx, useRecoveryCodeQuery, userID, codeHash, time.Now())
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			if err == nil {
+				err = ErrRecoveryCodeNotExist
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"strings"
+)
+
+func (sts *StoreTestSuite) Test_store_MFA() {
+	ctx := context.Background()
+	u := sts.createCustomer()
+
+	_, err := sts.store.GetMFA(ctx, u.ID)
+	sts.Equal(ErrMFANotEnrolled, err)
+
+	// a pending enrolment can be restarted
+	sts.Require().NoError(sts.store.SaveMFASecret(ctx, u.ID, "AAAA"))
+	sts.Require().NoError(sts.store.SaveMFASecret(ctx, u.ID, "BBBB"))
+
+	m, err := sts.store.GetMFA(ctx, u.ID)
+	sts.Require().NoError(err)
+	sts.Equal("BBBB", m.Secret)
+	sts.Nil(m.ConfirmedAt)
+
+	hash := strings.Repeat("c", 64)
+	sts.Require().NoError(sts.store.ConfirmMFA(ctx, u.ID, 100, []string{hash}))
+	sts.Equal(ErrMFAAlreadyEnrolled, sts.store.ConfirmMFA(ctx, u.ID, 101, nil))
+	sts.Equal(ErrMFAAlreadyEnrolled, sts.store.SaveMFASecret(ctx, u.ID, "CCCC"))
+
+	// codes are used once and in order
+	sts.Equal(ErrMFACodeUsed, sts.store.UseMFACode(ctx, u.ID, 100))
+	sts.NoError(sts.store.UseMFACode(ctx, u.ID, 101))
+	sts.Equal(ErrMFACodeUsed, sts.store.UseMFACode(ctx, u.ID, 101))
+
+	sts.NoError(sts.store.UseRecoveryCode(ctx, u.ID, hash))
+	sts.Equal(ErrRecoveryCodeNotExist, sts.store.UseRecoveryCode(ctx, u.ID, hash))
+}
@@ -8,6 +8,10 @@ import (
 	db "example.com/banking/db"
 
 	mock "github.com/stretchr/testify/mock"
+
+	money "example.com/banking/money"
+
+	time "time"
 )
 
 // Storer is an autogenerated mock type for the Storer type
@@ -23,6 +27,82 @@ func (_m *Storer) EXPECT() *Storer_Expecter {
 	return &Storer_Expecter{mock: &_m.Mock}
 }
 
+// AddAuditEntry provides a mock function with given fields: ctx, e
+func (_m *Storer) AddAuditEntry(ctx context.Context, e db.AuditEntry) error {
+	ret := _m.Called(ctx, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.AuditEntry) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_AddAuditEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditEntry'
+type Storer_AddAuditEntry_Call struct {
+	*mock.Call
+}
+
+// AddAuditEntry is a helper method to define mock.On call
+//  - ctx context.Context
+//  - e db.AuditEntry
+func (_e *Storer_Expecter) AddAuditEntry(ctx interface{}, e interface{}) *Storer_AddAuditEntry_Call {
+	return &Storer_AddAuditEntry_Call{Call: _e.mock.On("AddAuditEntry", ctx, e)}
+}
+
+func (_c *Storer_AddAuditEntry_Call) Run(run func(ctx context.Context, e db.AuditEntry)) *Storer_AddAuditEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *Storer_AddAuditEntry_Call) Return(err error) *Storer_AddAuditEntry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// AddInterestAccrual provides a mock function with given fields: ctx, a
+func (_m *Storer) AddInterestAccrual(ctx context.Context, a db.InterestAccrual) error {
+	ret := _m.Called(ctx, a)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InterestAccrual) error); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_AddInterestAccrual_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddInterestAccrual'
+type Storer_AddInterestAccrual_Call struct {
+	*mock.Call
+}
+
+// AddInterestAccrual is a helper method to define mock.On call
+//  - ctx context.Context
+//  - a db.InterestAccrual
+func (_e *Storer_Expecter) AddInterestAccrual(ctx interface{}, a interface{}) *Storer_AddInterestAccrual_Call {
+	return &Storer_AddInterestAccrual_Call{Call: _e.mock.On("AddInterestAccrual", ctx, a)}
+}
+
+func (_c *Storer_AddInterestAccrual_Call) Run(run func(ctx context.Context, a db.InterestAccrual)) *Storer_AddInterestAccrual_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InterestAccrual))
+	})
+	return _c
+}
+
+func (_c *Storer_AddInterestAccrual_Call) Return(err error) *Storer_AddInterestAccrual_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
 // AddTransaction provides a mock function with given fields: ctx, t
 func (_m *Storer) AddTransaction(ctx context.Context, t db.Transaction) error {
 	ret := _m.Called(ctx, t)
@@ -61,13 +141,13 @@ func (_c *Storer_AddTransaction_Call) Return(err error) *Storer_AddTransaction_C
 	return _c
 }
 
-// CreateAccount provides a mock function with given fields: ctx, u, acc
-func (_m *Storer) CreateAccount(ctx context.Context, u db.User, acc db.Account) error {
-	ret := _m.Called(ctx, u, acc)
+// CancelStandingOrder provides a mock function with given fields: ctx, accID, orderID, userID
+func (_m *Storer) CancelStandingOrder(ctx context.Context, accID string, orderID string, userID string) error {
+	ret := _m.Called(ctx, accID, orderID, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.User, db.Account) error); ok {
-		r0 = rf(ctx, u, acc)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, accID, orderID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -75,38 +155,86 @@ func (_m *Storer) CreateAccount(ctx context.Context, u db.User, acc db.Account)
 	return r0
 }
 
-// Storer_CreateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccount'
-type Storer_CreateAccount_Call struct {
+// Storer_CancelStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelStandingOrder'
+type Storer_CancelStandingOrder_Call struct {
 	*mock.Call
 }
 
-// CreateAccount is a helper method to define mock.On call
+// CancelStandingOrder is a helper method to define mock.On call
 //  - ctx context.Context
-//  - u db.User
-//  - acc db.Account
-func (_e *Storer_Expecter) CreateAccount(ctx interface{}, u interface{}, acc interface{}) *Storer_CreateAccount_Call {
-	return &Storer_CreateAccount_Call{Call: _e.mock.On("CreateAccount", ctx, u, acc)}
+//  - accID string
+//  - orderID string
+//  - userID string
+func (_e *Storer_Expecter) CancelStandingOrder(ctx interface{}, accID interface{}, orderID interface{}, userID interface{}) *Storer_CancelStandingOrder_Call {
+	return &Storer_CancelStandingOrder_Call{Call: _e.mock.On("CancelStandingOrder", ctx, accID, orderID, userID)}
 }
 
-func (_c *Storer_CreateAccount_Call) Run(run func(ctx context.Context, u db.User, acc db.Account)) *Storer_CreateAccount_Call {
+func (_c *Storer_CancelStandingOrder_Call) Run(run func(ctx context.Context, accID string, orderID string, userID string)) *Storer_CancelStandingOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.User), args[2].(db.Account))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *Storer_CreateAccount_Call) Return(err error) *Storer_CreateAccount_Call {
+func (_c *Storer_CancelStandingOrder_Call) Return(err error) *Storer_CancelStandingOrder_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-// DepositAmount provides a mock function with given fields: ctx, accID, userID, amount
-func (_m *Storer) DepositAmount(ctx context.Context, accID string, userID string, amount float32) error {
-	ret := _m.Called(ctx, accID, userID, amount)
+// CaptureHold provides a mock function with given fields: ctx, accID, holdID, amount
+func (_m *Storer) CaptureHold(ctx context.Context, accID string, holdID string, amount *money.Amount) (db.Hold, error) {
+	ret := _m.Called(ctx, accID, holdID, amount)
+
+	var r0 db.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *money.Amount) db.Hold); ok {
+		r0 = rf(ctx, accID, holdID, amount)
+	} else {
+		r0 = ret.Get(0).(db.Hold)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *money.Amount) error); ok {
+		r1 = rf(ctx, accID, holdID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_CaptureHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CaptureHold'
+type Storer_CaptureHold_Call struct {
+	*mock.Call
+}
+
+// CaptureHold is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - holdID string
+//  - amount *money.Amount
+func (_e *Storer_Expecter) CaptureHold(ctx interface{}, accID interface{}, holdID interface{}, amount interface{}) *Storer_CaptureHold_Call {
+	return &Storer_CaptureHold_Call{Call: _e.mock.On("CaptureHold", ctx, accID, holdID, amount)}
+}
+
+func (_c *Storer_CaptureHold_Call) Run(run func(ctx context.Context, accID string, holdID string, amount *money.Amount)) *Storer_CaptureHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*money.Amount))
+	})
+	return _c
+}
+
+func (_c *Storer_CaptureHold_Call) Return(captured db.Hold, err error) *Storer_CaptureHold_Call {
+	_c.Call.Return(captured, err)
+	return _c
+}
+
+// ChangePassword provides a mock function with given fields: ctx, userID, sessionID, oldPassword, newPassword
+func (_m *Storer) ChangePassword(ctx context.Context, userID string, sessionID string, oldPassword string, newPassword string) error {
+	ret := _m.Called(ctx, userID, sessionID, oldPassword, newPassword)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) error); ok {
-		r0 = rf(ctx, accID, userID, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, userID, sessionID, oldPassword, newPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -114,46 +242,47 @@ func (_m *Storer) DepositAmount(ctx context.Context, accID string, userID string
 	return r0
 }
 
-// Storer_DepositAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DepositAmount'
-type Storer_DepositAmount_Call struct {
+// Storer_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type Storer_ChangePassword_Call struct {
 	*mock.Call
 }
 
-// DepositAmount is a helper method to define mock.On call
+// ChangePassword is a helper method to define mock.On call
 //  - ctx context.Context
-//  - accID string
 //  - userID string
-//  - amount float32
-func (_e *Storer_Expecter) DepositAmount(ctx interface{}, accID interface{}, userID interface{}, amount interface{}) *Storer_DepositAmount_Call {
-	return &Storer_DepositAmount_Call{Call: _e.mock.On("DepositAmount", ctx, accID, userID, amount)}
+//  - sessionID string
+//  - oldPassword string
+//  - newPassword string
+func (_e *Storer_Expecter) ChangePassword(ctx interface{}, userID interface{}, sessionID interface{}, oldPassword interface{}, newPassword interface{}) *Storer_ChangePassword_Call {
+	return &Storer_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, userID, sessionID, oldPassword, newPassword)}
 }
 
-func (_c *Storer_DepositAmount_Call) Run(run func(ctx context.Context, accID string, userID string, amount float32)) *Storer_DepositAmount_Call {
+func (_c *Storer_ChangePassword_Call) Run(run func(ctx context.Context, userID string, sessionID string, oldPassword string, newPassword string)) *Storer_ChangePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(float32))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
 
-func (_c *Storer_DepositAmount_Call) Return(err error) *Storer_DepositAmount_Call {
+func (_c *Storer_ChangePassword_Call) Return(err error) *Storer_ChangePassword_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-// GetAccountDetails provides a mock function with given fields: ctx, accID, userID
-func (_m *Storer) GetAccountDetails(ctx context.Context, accID string, userID string) (db.UserAccountDetails, error) {
-	ret := _m.Called(ctx, accID, userID)
+// ChargeMonthlyFee provides a mock function with given fields: ctx, c
+func (_m *Storer) ChargeMonthlyFee(ctx context.Context, c db.FeeCharge) (money.Amount, error) {
+	ret := _m.Called(ctx, c)
 
-	var r0 db.UserAccountDetails
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) db.UserAccountDetails); ok {
-		r0 = rf(ctx, accID, userID)
+	var r0 money.Amount
+	if rf, ok := ret.Get(0).(func(context.Context, db.FeeCharge) money.Amount); ok {
+		r0 = rf(ctx, c)
 	} else {
-		r0 = ret.Get(0).(db.UserAccountDetails)
+		r0 = ret.Get(0).(money.Amount)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, accID, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, db.FeeCharge) error); ok {
+		r1 = rf(ctx, c)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -161,47 +290,44 @@ func (_m *Storer) GetAccountDetails(ctx context.Context, accID string, userID st
 	return r0, r1
 }
 
-// Storer_GetAccountDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountDetails'
-type Storer_GetAccountDetails_Call struct {
+// Storer_ChargeMonthlyFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChargeMonthlyFee'
+type Storer_ChargeMonthlyFee_Call struct {
 	*mock.Call
 }
 
-// GetAccountDetails is a helper method to define mock.On call
+// ChargeMonthlyFee is a helper method to define mock.On call
 //  - ctx context.Context
-//  - accID string
-//  - userID string
-func (_e *Storer_Expecter) GetAccountDetails(ctx interface{}, accID interface{}, userID interface{}) *Storer_GetAccountDetails_Call {
-	return &Storer_GetAccountDetails_Call{Call: _e.mock.On("GetAccountDetails", ctx, accID, userID)}
+//  - c db.FeeCharge
+func (_e *Storer_Expecter) ChargeMonthlyFee(ctx interface{}, c interface{}) *Storer_ChargeMonthlyFee_Call {
+	return &Storer_ChargeMonthlyFee_Call{Call: _e.mock.On("ChargeMonthlyFee", ctx, c)}
 }
 
-func (_c *Storer_GetAccountDetails_Call) Run(run func(ctx context.Context, accID string, userID string)) *Storer_GetAccountDetails_Call {
+func (_c *Storer_ChargeMonthlyFee_Call) Run(run func(ctx context.Context, c db.FeeCharge)) *Storer_ChargeMonthlyFee_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(db.FeeCharge))
 	})
 	return _c
 }
 
-func (_c *Storer_GetAccountDetails_Call) Return(acc db.UserAccountDetails, err error) *Storer_GetAccountDetails_Call {
-	_c.Call.Return(acc, err)
+func (_c *Storer_ChargeMonthlyFee_Call) Return(charged money.Amount, err error) *Storer_ChargeMonthlyFee_Call {
+	_c.Call.Return(charged, err)
 	return _c
 }
 
-// GetAccountList provides a mock function with given fields: ctx
-func (_m *Storer) GetAccountList(ctx context.Context) ([]db.UserAccountDetails, error) {
-	ret := _m.Called(ctx)
+// ClaimDueStandingOrder provides a mock function with given fields: ctx, now, skip
+func (_m *Storer) ClaimDueStandingOrder(ctx context.Context, now time.Time, skip []string) (db.StandingOrder, error) {
+	ret := _m.Called(ctx, now, skip)
 
-	var r0 []db.UserAccountDetails
-	if rf, ok := ret.Get(0).(func(context.Context) []db.UserAccountDetails); ok {
-		r0 = rf(ctx)
+	var r0 db.StandingOrder
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, []string) db.StandingOrder); ok {
+		r0 = rf(ctx, now, skip)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.UserAccountDetails)
-		}
+		r0 = ret.Get(0).(db.StandingOrder)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, []string) error); ok {
+		r1 = rf(ctx, now, skip)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -209,45 +335,45 @@ func (_m *Storer) GetAccountList(ctx context.Context) ([]db.UserAccountDetails,
 	return r0, r1
 }
 
-// Storer_GetAccountList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountList'
-type Storer_GetAccountList_Call struct {
+// Storer_ClaimDueStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueStandingOrder'
+type Storer_ClaimDueStandingOrder_Call struct {
 	*mock.Call
 }
 
-// GetAccountList is a helper method to define mock.On call
+// ClaimDueStandingOrder is a helper method to define mock.On call
 //  - ctx context.Context
-func (_e *Storer_Expecter) GetAccountList(ctx interface{}) *Storer_GetAccountList_Call {
-	return &Storer_GetAccountList_Call{Call: _e.mock.On("GetAccountList", ctx)}
+//  - now time.Time
+//  - skip []string
+func (_e *Storer_Expecter) ClaimDueStandingOrder(ctx interface{}, now interface{}, skip interface{}) *Storer_ClaimDueStandingOrder_Call {
+	return &Storer_ClaimDueStandingOrder_Call{Call: _e.mock.On("ClaimDueStandingOrder", ctx, now, skip)}
 }
 
-func (_c *Storer_GetAccountList_Call) Run(run func(ctx context.Context)) *Storer_GetAccountList_Call {
+func (_c *Storer_ClaimDueStandingOrder_Call) Run(run func(ctx context.Context, now time.Time, skip []string)) *Storer_ClaimDueStandingOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(time.Time), args[2].([]string))
 	})
 	return _c
 }
 
-func (_c *Storer_GetAccountList_Call) Return(accounts []db.UserAccountDetails, err error) *Storer_GetAccountList_Call {
-	_c.Call.Return(accounts, err)
+func (_c *Storer_ClaimDueStandingOrder_Call) Return(o db.StandingOrder, err error) *Storer_ClaimDueStandingOrder_Call {
+	_c.Call.Return(o, err)
 	return _c
 }
 
-// GetTransactions provides a mock function with given fields: ctx, accID, userID
-func (_m *Storer) GetTransactions(ctx context.Context, accID string, userID string) ([]db.Transaction, error) {
-	ret := _m.Called(ctx, accID, userID)
+// CloseAccount provides a mock function with given fields: ctx, accID, from, payoutAccID, payOut, reason
+func (_m *Storer) CloseAccount(ctx context.Context, accID string, from []string, payoutAccID string, payOut bool, reason string) (db.AccountClosure, error) {
+	ret := _m.Called(ctx, accID, from, payoutAccID, payOut, reason)
 
-	var r0 []db.Transaction
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []db.Transaction); ok {
-		r0 = rf(ctx, accID, userID)
+	var r0 db.AccountClosure
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string, bool, string) db.AccountClosure); ok {
+		r0 = rf(ctx, accID, from, payoutAccID, payOut, reason)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Transaction)
-		}
+		r0 = ret.Get(0).(db.AccountClosure)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, accID, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, string, bool, string) error); ok {
+		r1 = rf(ctx, accID, from, payoutAccID, payOut, reason)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -255,84 +381,81 @@ func (_m *Storer) GetTransactions(ctx context.Context, accID string, userID stri
 	return r0, r1
 }
 
-// Storer_GetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactions'
-type Storer_GetTransactions_Call struct {
+// Storer_CloseAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseAccount'
+type Storer_CloseAccount_Call struct {
 	*mock.Call
 }
 
-// GetTransactions is a helper method to define mock.On call
+// CloseAccount is a helper method to define mock.On call
 //  - ctx context.Context
 //  - accID string
-//  - userID string
-func (_e *Storer_Expecter) GetTransactions(ctx interface{}, accID interface{}, userID interface{}) *Storer_GetTransactions_Call {
-	return &Storer_GetTransactions_Call{Call: _e.mock.On("GetTransactions", ctx, accID, userID)}
+//  - from []string
+//  - payoutAccID string
+//  - payOut bool
+//  - reason string
+func (_e *Storer_Expecter) CloseAccount(ctx interface{}, accID interface{}, from interface{}, payoutAccID interface{}, payOut interface{}, reason interface{}) *Storer_CloseAccount_Call {
+	return &Storer_CloseAccount_Call{Call: _e.mock.On("CloseAccount", ctx, accID, from, payoutAccID, payOut, reason)}
 }
 
-func (_c *Storer_GetTransactions_Call) Run(run func(ctx context.Context, accID string, userID string)) *Storer_GetTransactions_Call {
+func (_c *Storer_CloseAccount_Call) Run(run func(ctx context.Context, accID string, from []string, payoutAccID string, payOut bool, reason string)) *Storer_CloseAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(string), args[4].(bool), args[5].(string))
 	})
 	return _c
 }
 
-func (_c *Storer_GetTransactions_Call) Return(transactions []db.Transaction, err error) *Storer_GetTransactions_Call {
-	_c.Call.Return(transactions, err)
+func (_c *Storer_CloseAccount_Call) Return(closure db.AccountClosure, err error) *Storer_CloseAccount_Call {
+	_c.Call.Return(closure, err)
 	return _c
 }
 
-// GetUserByEmailAndPassword provides a mock function with given fields: ctx, email, password
-func (_m *Storer) GetUserByEmailAndPassword(ctx context.Context, email string, password string) (db.User, error) {
-	ret := _m.Called(ctx, email, password)
-
-	var r0 db.User
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) db.User); ok {
-		r0 = rf(ctx, email, password)
-	} else {
-		r0 = ret.Get(0).(db.User)
-	}
+// ConfirmMFA provides a mock function with given fields: ctx, userID, counter, recoveryCodeHashes
+func (_m *Storer) ConfirmMFA(ctx context.Context, userID string, counter int64, recoveryCodeHashes []string) error {
+	ret := _m.Called(ctx, userID, counter, recoveryCodeHashes)
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, email, password)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, []string) error); ok {
+		r0 = rf(ctx, userID, counter, recoveryCodeHashes)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Storer_GetUserByEmailAndPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmailAndPassword'
-type Storer_GetUserByEmailAndPassword_Call struct {
+// Storer_ConfirmMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmMFA'
+type Storer_ConfirmMFA_Call struct {
 	*mock.Call
 }
 
-// GetUserByEmailAndPassword is a helper method to define mock.On call
+// ConfirmMFA is a helper method to define mock.On call
 //  - ctx context.Context
-//  - email string
-//  - password string
-func (_e *Storer_Expecter) GetUserByEmailAndPassword(ctx interface{}, email interface{}, password interface{}) *Storer_GetUserByEmailAndPassword_Call {
-	return &Storer_GetUserByEmailAndPassword_Call{Call: _e.mock.On("GetUserByEmailAndPassword", ctx, email, password)}
+//  - userID string
+//  - counter int64
+//  - recoveryCodeHashes []string
+func (_e *Storer_Expecter) ConfirmMFA(ctx interface{}, userID interface{}, counter interface{}, recoveryCodeHashes interface{}) *Storer_ConfirmMFA_Call {
+	return &Storer_ConfirmMFA_Call{Call: _e.mock.On("ConfirmMFA", ctx, userID, counter, recoveryCodeHashes)}
 }
 
-func (_c *Storer_GetUserByEmailAndPassword_Call) Run(run func(ctx context.Context, email string, password string)) *Storer_GetUserByEmailAndPassword_Call {
+func (_c *Storer_ConfirmMFA_Call) Run(run func(ctx context.Context, userID string, counter int64, recoveryCodeHashes []string)) *Storer_ConfirmMFA_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].([]string))
 	})
 	return _c
 }
 
-func (_c *Storer_GetUserByEmailAndPassword_Call) Return(u db.User, err error) *Storer_GetUserByEmailAndPassword_Call {
-	_c.Call.Return(u, err)
+func (_c *Storer_ConfirmMFA_Call) Return(err error) *Storer_ConfirmMFA_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-// WithdrawAmount provides a mock function with given fields: ctx, accID, userID, amount
-func (_m *Storer) WithdrawAmount(ctx context.Context, accID string, userID string, amount float32) error {
-	ret := _m.Called(ctx, accID, userID, amount)
+// CreateAccount provides a mock function with given fields: ctx, u, acc
+func (_m *Storer) CreateAccount(ctx context.Context, u db.User, acc db.Account) error {
+	ret := _m.Called(ctx, u, acc)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, float32) error); ok {
-		r0 = rf(ctx, accID, userID, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, db.User, db.Account) error); ok {
+		r0 = rf(ctx, u, acc)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -340,32 +463,2499 @@ func (_m *Storer) WithdrawAmount(ctx context.Context, accID string, userID strin
 	return r0
 }
 
-// Storer_WithdrawAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithdrawAmount'
-type Storer_WithdrawAmount_Call struct {
+// Storer_CreateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccount'
+type Storer_CreateAccount_Call struct {
 	*mock.Call
 }
 
-// WithdrawAmount is a helper method to define mock.On call
+// CreateAccount is a helper method to define mock.On call
 //  - ctx context.Context
-//  - accID string
-//  - userID string
-//  - amount float32
-func (_e *Storer_Expecter) WithdrawAmount(ctx interface{}, accID interface{}, userID interface{}, amount interface{}) *Storer_WithdrawAmount_Call {
-	return &Storer_WithdrawAmount_Call{Call: _e.mock.On("WithdrawAmount", ctx, accID, userID, amount)}
+//  - u db.User
+//  - acc db.Account
+func (_e *Storer_Expecter) CreateAccount(ctx interface{}, u interface{}, acc interface{}) *Storer_CreateAccount_Call {
+	return &Storer_CreateAccount_Call{Call: _e.mock.On("CreateAccount", ctx, u, acc)}
 }
 
-func (_c *Storer_WithdrawAmount_Call) Run(run func(ctx context.Context, accID string, userID string, amount float32)) *Storer_WithdrawAmount_Call {
+func (_c *Storer_CreateAccount_Call) Run(run func(ctx context.Context, u db.User, acc db.Account)) *Storer_CreateAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(float32))
+		run(args[0].(context.Context), args[1].(db.User), args[2].(db.Account))
 	})
 	return _c
 }
 
-func (_c *Storer_WithdrawAmount_Call) Return(err error) *Storer_WithdrawAmount_Call {
+func (_c *Storer_CreateAccount_Call) Return(err error) *Storer_CreateAccount_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
+// CreateFXRate provides a mock function with given fields: ctx, r
+func (_m *Storer) CreateFXRate(ctx context.Context, r db.FXRate) (db.FXRate, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 db.FXRate
+	if rf, ok := ret.Get(0).(func(context.Context, db.FXRate) db.FXRate); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Get(0).(db.FXRate)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, db.FXRate) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_CreateFXRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFXRate'
+type Storer_CreateFXRate_Call struct {
+	*mock.Call
+}
+
+// CreateFXRate is a helper method to define mock.On call
+//  - ctx context.Context
+//  - r db.FXRate
+func (_e *Storer_Expecter) CreateFXRate(ctx interface{}, r interface{}) *Storer_CreateFXRate_Call {
+	return &Storer_CreateFXRate_Call{Call: _e.mock.On("CreateFXRate", ctx, r)}
+}
+
+func (_c *Storer_CreateFXRate_Call) Run(run func(ctx context.Context, r db.FXRate)) *Storer_CreateFXRate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.FXRate))
+	})
+	return _c
+}
+
+func (_c *Storer_CreateFXRate_Call) Return(created db.FXRate, err error) *Storer_CreateFXRate_Call {
+	_c.Call.Return(created, err)
+	return _c
+}
+
+// CreateFeeRule provides a mock function with given fields: ctx, r
+func (_m *Storer) CreateFeeRule(ctx context.Context, r db.FeeRule) (db.FeeRule, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 db.FeeRule
+	if rf, ok := ret.Get(0).(func(context.Context, db.FeeRule) db.FeeRule); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Get(0).(db.FeeRule)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, db.FeeRule) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_CreateFeeRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFeeRule'
+type Storer_CreateFeeRule_Call struct {
+	*mock.Call
+}
+
+// CreateFeeRule is a helper method to define mock.On call
+//  - ctx context.Context
+//  - r db.FeeRule
+func (_e *Storer_Expecter) CreateFeeRule(ctx interface{}, r interface{}) *Storer_CreateFeeRule_Call {
+	return &Storer_CreateFeeRule_Call{Call: _e.mock.On("CreateFeeRule", ctx, r)}
+}
+
+func (_c *Storer_CreateFeeRule_Call) Run(run func(ctx context.Context, r db.FeeRule)) *Storer_CreateFeeRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.FeeRule))
+	})
+	return _c
+}
+
+func (_c *Storer_CreateFeeRule_Call) Return(created db.FeeRule, err error) *Storer_CreateFeeRule_Call {
+	_c.Call.Return(created, err)
+	return _c
+}
+
+// CreateHold provides a mock function with given fields: ctx, h
+func (_m *Storer) CreateHold(ctx context.Context, h db.Hold) (db.Hold, error) {
+	ret := _m.Called(ctx, h)
+
+	var r0 db.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, db.Hold) db.Hold); ok {
+		r0 = rf(ctx, h)
+	} else {
+		r0 = ret.Get(0).(db.Hold)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, db.Hold) error); ok {
+		r1 = rf(ctx, h)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_CreateHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHold'
+type Storer_CreateHold_Call struct {
+	*mock.Call
+}
+
+// CreateHold is a helper method to define mock.On call
+//  - ctx context.Context
+//  - h db.Hold
+func (_e *Storer_Expecter) CreateHold(ctx interface{}, h interface{}) *Storer_CreateHold_Call {
+	return &Storer_CreateHold_Call{Call: _e.mock.On("CreateHold", ctx, h)}
+}
+
+func (_c *Storer_CreateHold_Call) Run(run func(ctx context.Context, h db.Hold)) *Storer_CreateHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.Hold))
+	})
+	return _c
+}
+
+func (_c *Storer_CreateHold_Call) Return(created db.Hold, err error) *Storer_CreateHold_Call {
+	_c.Call.Return(created, err)
+	return _c
+}
+
+// CreatePasswordResetToken provides a mock function with given fields: ctx, email, t
+func (_m *Storer) CreatePasswordResetToken(ctx context.Context, email string, t db.PasswordResetToken) error {
+	ret := _m.Called(ctx, email, t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, db.PasswordResetToken) error); ok {
+		r0 = rf(ctx, email, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_CreatePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePasswordResetToken'
+type Storer_CreatePasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// CreatePasswordResetToken is a helper method to define mock.On call
+//  - ctx context.Context
+//  - email string
+//  - t db.PasswordResetToken
+func (_e *Storer_Expecter) CreatePasswordResetToken(ctx interface{}, email interface{}, t interface{}) *Storer_CreatePasswordResetToken_Call {
+	return &Storer_CreatePasswordResetToken_Call{Call: _e.mock.On("CreatePasswordResetToken", ctx, email, t)}
+}
+
+func (_c *Storer_CreatePasswordResetToken_Call) Run(run func(ctx context.Context, email string, t db.PasswordResetToken)) *Storer_CreatePasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(db.PasswordResetToken))
+	})
+	return _c
+}
+
+func (_c *Storer_CreatePasswordResetToken_Call) Return(err error) *Storer_CreatePasswordResetToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// CreateSession provides a mock function with given fields: ctx, sess, rt
+func (_m *Storer) CreateSession(ctx context.Context, sess db.Session, rt db.RefreshToken) error {
+	ret := _m.Called(ctx, sess, rt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Session, db.RefreshToken) error); ok {
+		r0 = rf(ctx, sess, rt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_CreateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSession'
+type Storer_CreateSession_Call struct {
+	*mock.Call
+}
+
+// CreateSession is a helper method to define mock.On call
+//  - ctx context.Context
+//  - sess db.Session
+//  - rt db.RefreshToken
+func (_e *Storer_Expecter) CreateSession(ctx interface{}, sess interface{}, rt interface{}) *Storer_CreateSession_Call {
+	return &Storer_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, sess, rt)}
+}
+
+func (_c *Storer_CreateSession_Call) Run(run func(ctx context.Context, sess db.Session, rt db.RefreshToken)) *Storer_CreateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.Session), args[2].(db.RefreshToken))
+	})
+	return _c
+}
+
+func (_c *Storer_CreateSession_Call) Return(err error) *Storer_CreateSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// CreateStandingOrder provides a mock function with given fields: ctx, o
+func (_m *Storer) CreateStandingOrder(ctx context.Context, o db.StandingOrder) (db.StandingOrder, error) {
+	ret := _m.Called(ctx, o)
+
+	var r0 db.StandingOrder
+	if rf, ok := ret.Get(0).(func(context.Context, db.StandingOrder) db.StandingOrder); ok {
+		r0 = rf(ctx, o)
+	} else {
+		r0 = ret.Get(0).(db.StandingOrder)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, db.StandingOrder) error); ok {
+		r1 = rf(ctx, o)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_CreateStandingOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStandingOrder'
+type Storer_CreateStandingOrder_Call struct {
+	*mock.Call
+}
+
+// CreateStandingOrder is a helper method to define mock.On call
+//  - ctx context.Context
+//  - o db.StandingOrder
+func (_e *Storer_Expecter) CreateStandingOrder(ctx interface{}, o interface{}) *Storer_CreateStandingOrder_Call {
+	return &Storer_CreateStandingOrder_Call{Call: _e.mock.On("CreateStandingOrder", ctx, o)}
+}
+
+func (_c *Storer_CreateStandingOrder_Call) Run(run func(ctx context.Context, o db.StandingOrder)) *Storer_CreateStandingOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.StandingOrder))
+	})
+	return _c
+}
+
+func (_c *Storer_CreateStandingOrder_Call) Return(created db.StandingOrder, err error) *Storer_CreateStandingOrder_Call {
+	_c.Call.Return(created, err)
+	return _c
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: ctx, userID, key
+func (_m *Storer) DeleteIdempotencyKey(ctx context.Context, userID string, key string) error {
+	ret := _m.Called(ctx, userID, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_DeleteIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdempotencyKey'
+type Storer_DeleteIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// DeleteIdempotencyKey is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+//  - key string
+func (_e *Storer_Expecter) DeleteIdempotencyKey(ctx interface{}, userID interface{}, key interface{}) *Storer_DeleteIdempotencyKey_Call {
+	return &Storer_DeleteIdempotencyKey_Call{Call: _e.mock.On("DeleteIdempotencyKey", ctx, userID, key)}
+}
+
+func (_c *Storer_DeleteIdempotencyKey_Call) Run(run func(ctx context.Context, userID string, key string)) *Storer_DeleteIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_DeleteIdempotencyKey_Call) Return(err error) *Storer_DeleteIdempotencyKey_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// DepositAmount provides a mock function with given fields: ctx, accID, userID, amount
+func (_m *Storer) DepositAmount(ctx context.Context, accID string, userID string, amount money.Amount) ([]db.Transaction, error) {
+	ret := _m.Called(ctx, accID, userID, amount)
+
+	var r0 []db.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Amount) []db.Transaction); ok {
+		r0 = rf(ctx, accID, userID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, money.Amount) error); ok {
+		r1 = rf(ctx, accID, userID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_DepositAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DepositAmount'
+type Storer_DepositAmount_Call struct {
+	*mock.Call
+}
+
+// DepositAmount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - userID string
+//  - amount money.Amount
+func (_e *Storer_Expecter) DepositAmount(ctx interface{}, accID interface{}, userID interface{}, amount interface{}) *Storer_DepositAmount_Call {
+	return &Storer_DepositAmount_Call{Call: _e.mock.On("DepositAmount", ctx, accID, userID, amount)}
+}
+
+func (_c *Storer_DepositAmount_Call) Run(run func(ctx context.Context, accID string, userID string, amount money.Amount)) *Storer_DepositAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(money.Amount))
+	})
+	return _c
+}
+
+func (_c *Storer_DepositAmount_Call) Return(fees []db.Transaction, err error) *Storer_DepositAmount_Call {
+	_c.Call.Return(fees, err)
+	return _c
+}
+
+// ExpireHolds provides a mock function with given fields: ctx, now
+func (_m *Storer) ExpireHolds(ctx context.Context, now time.Time) ([]db.Hold, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 []db.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.Hold); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_ExpireHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireHolds'
+type Storer_ExpireHolds_Call struct {
+	*mock.Call
+}
+
+// ExpireHolds is a helper method to define mock.On call
+//  - ctx context.Context
+//  - now time.Time
+func (_e *Storer_Expecter) ExpireHolds(ctx interface{}, now interface{}) *Storer_ExpireHolds_Call {
+	return &Storer_ExpireHolds_Call{Call: _e.mock.On("ExpireHolds", ctx, now)}
+}
+
+func (_c *Storer_ExpireHolds_Call) Run(run func(ctx context.Context, now time.Time)) *Storer_ExpireHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storer_ExpireHolds_Call) Return(expired []db.Hold, err error) *Storer_ExpireHolds_Call {
+	_c.Call.Return(expired, err)
+	return _c
+}
+
+// GetAccountDetails provides a mock function with given fields: ctx, accID, userID
+func (_m *Storer) GetAccountDetails(ctx context.Context, accID string, userID string) (db.UserAccountDetails, error) {
+	ret := _m.Called(ctx, accID, userID)
+
+	var r0 db.UserAccountDetails
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) db.UserAccountDetails); ok {
+		r0 = rf(ctx, accID, userID)
+	} else {
+		r0 = ret.Get(0).(db.UserAccountDetails)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetAccountDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountDetails'
+type Storer_GetAccountDetails_Call struct {
+	*mock.Call
+}
+
+// GetAccountDetails is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - userID string
+func (_e *Storer_Expecter) GetAccountDetails(ctx interface{}, accID interface{}, userID interface{}) *Storer_GetAccountDetails_Call {
+	return &Storer_GetAccountDetails_Call{Call: _e.mock.On("GetAccountDetails", ctx, accID, userID)}
+}
+
+func (_c *Storer_GetAccountDetails_Call) Run(run func(ctx context.Context, accID string, userID string)) *Storer_GetAccountDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetAccountDetails_Call) Return(acc db.UserAccountDetails, err error) *Storer_GetAccountDetails_Call {
+	_c.Call.Return(acc, err)
+	return _c
+}
+
+// GetAccountHolds provides a mock function with given fields: ctx, accID
+func (_m *Storer) GetAccountHolds(ctx context.Context, accID string) ([]db.Hold, error) {
+	ret := _m.Called(ctx, accID)
+
+	var r0 []db.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.Hold); ok {
+		r0 = rf(ctx, accID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetAccountHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountHolds'
+type Storer_GetAccountHolds_Call struct {
+	*mock.Call
+}
+
+// GetAccountHolds is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+func (_e *Storer_Expecter) GetAccountHolds(ctx interface{}, accID interface{}) *Storer_GetAccountHolds_Call {
+	return &Storer_GetAccountHolds_Call{Call: _e.mock.On("GetAccountHolds", ctx, accID)}
+}
+
+func (_c *Storer_GetAccountHolds_Call) Run(run func(ctx context.Context, accID string)) *Storer_GetAccountHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetAccountHolds_Call) Return(holds []db.Hold, err error) *Storer_GetAccountHolds_Call {
+	_c.Call.Return(holds, err)
+	return _c
+}
+
+// GetAccountLimits provides a mock function with given fields: ctx, accID, userID
+func (_m *Storer) GetAccountLimits(ctx context.Context, accID string, userID string) (db.AccountLimits, error) {
+	ret := _m.Called(ctx, accID, userID)
+
+	var r0 db.AccountLimits
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) db.AccountLimits); ok {
+		r0 = rf(ctx, accID, userID)
+	} else {
+		r0 = ret.Get(0).(db.AccountLimits)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetAccountLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountLimits'
+type Storer_GetAccountLimits_Call struct {
+	*mock.Call
+}
+
+// GetAccountLimits is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - userID string
+func (_e *Storer_Expecter) GetAccountLimits(ctx interface{}, accID interface{}, userID interface{}) *Storer_GetAccountLimits_Call {
+	return &Storer_GetAccountLimits_Call{Call: _e.mock.On("GetAccountLimits", ctx, accID, userID)}
+}
+
+func (_c *Storer_GetAccountLimits_Call) Run(run func(ctx context.Context, accID string, userID string)) *Storer_GetAccountLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetAccountLimits_Call) Return(limits db.AccountLimits, err error) *Storer_GetAccountLimits_Call {
+	_c.Call.Return(limits, err)
+	return _c
+}
+
+// GetAccountList provides a mock function with given fields: ctx
+func (_m *Storer) GetAccountList(ctx context.Context) ([]db.UserAccountDetails, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []db.UserAccountDetails
+	if rf, ok := ret.Get(0).(func(context.Context) []db.UserAccountDetails); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.UserAccountDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetAccountList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountList'
+type Storer_GetAccountList_Call struct {
+	*mock.Call
+}
+
+// GetAccountList is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *Storer_Expecter) GetAccountList(ctx interface{}) *Storer_GetAccountList_Call {
+	return &Storer_GetAccountList_Call{Call: _e.mock.On("GetAccountList", ctx)}
+}
+
+func (_c *Storer_GetAccountList_Call) Run(run func(ctx context.Context)) *Storer_GetAccountList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Storer_GetAccountList_Call) Return(accounts []db.UserAccountDetails, err error) *Storer_GetAccountList_Call {
+	_c.Call.Return(accounts, err)
+	return _c
+}
+
+// GetAccountStandingOrders provides a mock function with given fields: ctx, accID, userID
+func (_m *Storer) GetAccountStandingOrders(ctx context.Context, accID string, userID string) ([]db.StandingOrder, error) {
+	ret := _m.Called(ctx, accID, userID)
+
+	var r0 []db.StandingOrder
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []db.StandingOrder); ok {
+		r0 = rf(ctx, accID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StandingOrder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetAccountStandingOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountStandingOrders'
+type Storer_GetAccountStandingOrders_Call struct {
+	*mock.Call
+}
+
+// GetAccountStandingOrders is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - userID string
+func (_e *Storer_Expecter) GetAccountStandingOrders(ctx interface{}, accID interface{}, userID interface{}) *Storer_GetAccountStandingOrders_Call {
+	return &Storer_GetAccountStandingOrders_Call{Call: _e.mock.On("GetAccountStandingOrders", ctx, accID, userID)}
+}
+
+func (_c *Storer_GetAccountStandingOrders_Call) Run(run func(ctx context.Context, accID string, userID string)) *Storer_GetAccountStandingOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetAccountStandingOrders_Call) Return(orders []db.StandingOrder, err error) *Storer_GetAccountStandingOrders_Call {
+	_c.Call.Return(orders, err)
+	return _c
+}
+
+// GetAuditEntries provides a mock function with given fields: ctx, f
+func (_m *Storer) GetAuditEntries(ctx context.Context, f db.AuditFilter) ([]db.AuditEntry, error) {
+	ret := _m.Called(ctx, f)
+
+	var r0 []db.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, db.AuditFilter) []db.AuditEntry); ok {
+		r0 = rf(ctx, f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, db.AuditFilter) error); ok {
+		r1 = rf(ctx, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetAuditEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditEntries'
+type Storer_GetAuditEntries_Call struct {
+	*mock.Call
+}
+
+// GetAuditEntries is a helper method to define mock.On call
+//  - ctx context.Context
+//  - f db.AuditFilter
+func (_e *Storer_Expecter) GetAuditEntries(ctx interface{}, f interface{}) *Storer_GetAuditEntries_Call {
+	return &Storer_GetAuditEntries_Call{Call: _e.mock.On("GetAuditEntries", ctx, f)}
+}
+
+func (_c *Storer_GetAuditEntries_Call) Run(run func(ctx context.Context, f db.AuditFilter)) *Storer_GetAuditEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *Storer_GetAuditEntries_Call) Return(entries []db.AuditEntry, err error) *Storer_GetAuditEntries_Call {
+	_c.Call.Return(entries, err)
+	return _c
+}
+
+// GetFXRates provides a mock function with given fields: ctx
+func (_m *Storer) GetFXRates(ctx context.Context) ([]db.FXRate, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []db.FXRate
+	if rf, ok := ret.Get(0).(func(context.Context) []db.FXRate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FXRate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetFXRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFXRates'
+type Storer_GetFXRates_Call struct {
+	*mock.Call
+}
+
+// GetFXRates is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *Storer_Expecter) GetFXRates(ctx interface{}) *Storer_GetFXRates_Call {
+	return &Storer_GetFXRates_Call{Call: _e.mock.On("GetFXRates", ctx)}
+}
+
+func (_c *Storer_GetFXRates_Call) Run(run func(ctx context.Context)) *Storer_GetFXRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Storer_GetFXRates_Call) Return(rates []db.FXRate, err error) *Storer_GetFXRates_Call {
+	_c.Call.Return(rates, err)
+	return _c
+}
+
+// GetFeeRules provides a mock function with given fields: ctx
+func (_m *Storer) GetFeeRules(ctx context.Context) ([]db.FeeRule, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []db.FeeRule
+	if rf, ok := ret.Get(0).(func(context.Context) []db.FeeRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeeRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetFeeRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeeRules'
+type Storer_GetFeeRules_Call struct {
+	*mock.Call
+}
+
+// GetFeeRules is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *Storer_Expecter) GetFeeRules(ctx interface{}) *Storer_GetFeeRules_Call {
+	return &Storer_GetFeeRules_Call{Call: _e.mock.On("GetFeeRules", ctx)}
+}
+
+func (_c *Storer_GetFeeRules_Call) Run(run func(ctx context.Context)) *Storer_GetFeeRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Storer_GetFeeRules_Call) Return(rules []db.FeeRule, err error) *Storer_GetFeeRules_Call {
+	_c.Call.Return(rules, err)
+	return _c
+}
+
+// GetInterestAccounts provides a mock function with given fields: ctx, businessDate
+func (_m *Storer) GetInterestAccounts(ctx context.Context, businessDate time.Time) ([]db.InterestAccount, error) {
+	ret := _m.Called(ctx, businessDate)
+
+	var r0 []db.InterestAccount
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.InterestAccount); ok {
+		r0 = rf(ctx, businessDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.InterestAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, businessDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetInterestAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInterestAccounts'
+type Storer_GetInterestAccounts_Call struct {
+	*mock.Call
+}
+
+// GetInterestAccounts is a helper method to define mock.On call
+//  - ctx context.Context
+//  - businessDate time.Time
+func (_e *Storer_Expecter) GetInterestAccounts(ctx interface{}, businessDate interface{}) *Storer_GetInterestAccounts_Call {
+	return &Storer_GetInterestAccounts_Call{Call: _e.mock.On("GetInterestAccounts", ctx, businessDate)}
+}
+
+func (_c *Storer_GetInterestAccounts_Call) Run(run func(ctx context.Context, businessDate time.Time)) *Storer_GetInterestAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storer_GetInterestAccounts_Call) Return(accounts []db.InterestAccount, err error) *Storer_GetInterestAccounts_Call {
+	_c.Call.Return(accounts, err)
+	return _c
+}
+
+// GetLoginAttempts provides a mock function with given fields: ctx, email, ip
+func (_m *Storer) GetLoginAttempts(ctx context.Context, email string, ip string) ([]db.LoginAttempt, error) {
+	ret := _m.Called(ctx, email, ip)
+
+	var r0 []db.LoginAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []db.LoginAttempt); ok {
+		r0 = rf(ctx, email, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.LoginAttempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetLoginAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoginAttempts'
+type Storer_GetLoginAttempts_Call struct {
+	*mock.Call
+}
+
+// GetLoginAttempts is a helper method to define mock.On call
+//  - ctx context.Context
+//  - email string
+//  - ip string
+func (_e *Storer_Expecter) GetLoginAttempts(ctx interface{}, email interface{}, ip interface{}) *Storer_GetLoginAttempts_Call {
+	return &Storer_GetLoginAttempts_Call{Call: _e.mock.On("GetLoginAttempts", ctx, email, ip)}
+}
+
+func (_c *Storer_GetLoginAttempts_Call) Run(run func(ctx context.Context, email string, ip string)) *Storer_GetLoginAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetLoginAttempts_Call) Return(attempts []db.LoginAttempt, err error) *Storer_GetLoginAttempts_Call {
+	_c.Call.Return(attempts, err)
+	return _c
+}
+
+// GetMFA provides a mock function with given fields: ctx, userID
+func (_m *Storer) GetMFA(ctx context.Context, userID string) (db.MFA, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 db.MFA
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.MFA); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(db.MFA)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMFA'
+type Storer_GetMFA_Call struct {
+	*mock.Call
+}
+
+// GetMFA is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+func (_e *Storer_Expecter) GetMFA(ctx interface{}, userID interface{}) *Storer_GetMFA_Call {
+	return &Storer_GetMFA_Call{Call: _e.mock.On("GetMFA", ctx, userID)}
+}
+
+func (_c *Storer_GetMFA_Call) Run(run func(ctx context.Context, userID string)) *Storer_GetMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetMFA_Call) Return(m db.MFA, err error) *Storer_GetMFA_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+// GetMonthlyFeeCharges provides a mock function with given fields: ctx, period
+func (_m *Storer) GetMonthlyFeeCharges(ctx context.Context, period time.Time) ([]db.FeeCharge, error) {
+	ret := _m.Called(ctx, period)
+
+	var r0 []db.FeeCharge
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.FeeCharge); ok {
+		r0 = rf(ctx, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.FeeCharge)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetMonthlyFeeCharges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMonthlyFeeCharges'
+type Storer_GetMonthlyFeeCharges_Call struct {
+	*mock.Call
+}
+
+// GetMonthlyFeeCharges is a helper method to define mock.On call
+//  - ctx context.Context
+//  - period time.Time
+func (_e *Storer_Expecter) GetMonthlyFeeCharges(ctx interface{}, period interface{}) *Storer_GetMonthlyFeeCharges_Call {
+	return &Storer_GetMonthlyFeeCharges_Call{Call: _e.mock.On("GetMonthlyFeeCharges", ctx, period)}
+}
+
+func (_c *Storer_GetMonthlyFeeCharges_Call) Run(run func(ctx context.Context, period time.Time)) *Storer_GetMonthlyFeeCharges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storer_GetMonthlyFeeCharges_Call) Return(charges []db.FeeCharge, err error) *Storer_GetMonthlyFeeCharges_Call {
+	_c.Call.Return(charges, err)
+	return _c
+}
+
+// GetStandingOrderExecutions provides a mock function with given fields: ctx, accID, orderID, userID
+func (_m *Storer) GetStandingOrderExecutions(ctx context.Context, accID string, orderID string, userID string) ([]db.StandingOrderExecution, error) {
+	ret := _m.Called(ctx, accID, orderID, userID)
+
+	var r0 []db.StandingOrderExecution
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []db.StandingOrderExecution); ok {
+		r0 = rf(ctx, accID, orderID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StandingOrderExecution)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, accID, orderID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetStandingOrderExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStandingOrderExecutions'
+type Storer_GetStandingOrderExecutions_Call struct {
+	*mock.Call
+}
+
+// GetStandingOrderExecutions is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - orderID string
+//  - userID string
+func (_e *Storer_Expecter) GetStandingOrderExecutions(ctx interface{}, accID interface{}, orderID interface{}, userID interface{}) *Storer_GetStandingOrderExecutions_Call {
+	return &Storer_GetStandingOrderExecutions_Call{Call: _e.mock.On("GetStandingOrderExecutions", ctx, accID, orderID, userID)}
+}
+
+func (_c *Storer_GetStandingOrderExecutions_Call) Run(run func(ctx context.Context, accID string, orderID string, userID string)) *Storer_GetStandingOrderExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetStandingOrderExecutions_Call) Return(executions []db.StandingOrderExecution, err error) *Storer_GetStandingOrderExecutions_Call {
+	_c.Call.Return(executions, err)
+	return _c
+}
+
+// GetStatement provides a mock function with given fields: ctx, accID, userID, from, to
+func (_m *Storer) GetStatement(ctx context.Context, accID string, userID string, from time.Time, to time.Time) (db.Statement, error) {
+	ret := _m.Called(ctx, accID, userID, from, to)
+
+	var r0 db.Statement
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) db.Statement); ok {
+		r0 = rf(ctx, accID, userID, from, to)
+	} else {
+		r0 = ret.Get(0).(db.Statement)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, accID, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatement'
+type Storer_GetStatement_Call struct {
+	*mock.Call
+}
+
+// GetStatement is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - userID string
+//  - from time.Time
+//  - to time.Time
+func (_e *Storer_Expecter) GetStatement(ctx interface{}, accID interface{}, userID interface{}, from interface{}, to interface{}) *Storer_GetStatement_Call {
+	return &Storer_GetStatement_Call{Call: _e.mock.On("GetStatement", ctx, accID, userID, from, to)}
+}
+
+func (_c *Storer_GetStatement_Call) Run(run func(ctx context.Context, accID string, userID string, from time.Time, to time.Time)) *Storer_GetStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storer_GetStatement_Call) Return(st db.Statement, err error) *Storer_GetStatement_Call {
+	_c.Call.Return(st, err)
+	return _c
+}
+
+// GetTransactions provides a mock function with given fields: ctx, accID, userID, f
+func (_m *Storer) GetTransactions(ctx context.Context, accID string, userID string, f db.TransactionFilter) (db.TransactionPage, error) {
+	ret := _m.Called(ctx, accID, userID, f)
+
+	var r0 db.TransactionPage
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, db.TransactionFilter) db.TransactionPage); ok {
+		r0 = rf(ctx, accID, userID, f)
+	} else {
+		r0 = ret.Get(0).(db.TransactionPage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, db.TransactionFilter) error); ok {
+		r1 = rf(ctx, accID, userID, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactions'
+type Storer_GetTransactions_Call struct {
+	*mock.Call
+}
+
+// GetTransactions is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - userID string
+//  - f db.TransactionFilter
+func (_e *Storer_Expecter) GetTransactions(ctx interface{}, accID interface{}, userID interface{}, f interface{}) *Storer_GetTransactions_Call {
+	return &Storer_GetTransactions_Call{Call: _e.mock.On("GetTransactions", ctx, accID, userID, f)}
+}
+
+func (_c *Storer_GetTransactions_Call) Run(run func(ctx context.Context, accID string, userID string, f db.TransactionFilter)) *Storer_GetTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(db.TransactionFilter))
+	})
+	return _c
+}
+
+func (_c *Storer_GetTransactions_Call) Return(page db.TransactionPage, err error) *Storer_GetTransactions_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+// GetUserAccounts provides a mock function with given fields: ctx, userID
+func (_m *Storer) GetUserAccounts(ctx context.Context, userID string) ([]db.Account, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []db.Account
+	if rf, ok := ret.Get(0).(func(context.Context, string) []db.Account); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetUserAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAccounts'
+type Storer_GetUserAccounts_Call struct {
+	*mock.Call
+}
+
+// GetUserAccounts is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+func (_e *Storer_Expecter) GetUserAccounts(ctx interface{}, userID interface{}) *Storer_GetUserAccounts_Call {
+	return &Storer_GetUserAccounts_Call{Call: _e.mock.On("GetUserAccounts", ctx, userID)}
+}
+
+func (_c *Storer_GetUserAccounts_Call) Run(run func(ctx context.Context, userID string)) *Storer_GetUserAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetUserAccounts_Call) Return(accounts []db.Account, err error) *Storer_GetUserAccounts_Call {
+	_c.Call.Return(accounts, err)
+	return _c
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *Storer) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 db.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type Storer_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//  - ctx context.Context
+//  - email string
+func (_e *Storer_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *Storer_GetUserByEmail_Call {
+	return &Storer_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+}
+
+func (_c *Storer_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *Storer_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetUserByEmail_Call) Return(u db.User, err error) *Storer_GetUserByEmail_Call {
+	_c.Call.Return(u, err)
+	return _c
+}
+
+// GetUserByEmailAndPassword provides a mock function with given fields: ctx, email, password
+func (_m *Storer) GetUserByEmailAndPassword(ctx context.Context, email string, password string) (db.User, error) {
+	ret := _m.Called(ctx, email, password)
+
+	var r0 db.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) db.User); ok {
+		r0 = rf(ctx, email, password)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetUserByEmailAndPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmailAndPassword'
+type Storer_GetUserByEmailAndPassword_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmailAndPassword is a helper method to define mock.On call
+//  - ctx context.Context
+//  - email string
+//  - password string
+func (_e *Storer_Expecter) GetUserByEmailAndPassword(ctx interface{}, email interface{}, password interface{}) *Storer_GetUserByEmailAndPassword_Call {
+	return &Storer_GetUserByEmailAndPassword_Call{Call: _e.mock.On("GetUserByEmailAndPassword", ctx, email, password)}
+}
+
+func (_c *Storer_GetUserByEmailAndPassword_Call) Run(run func(ctx context.Context, email string, password string)) *Storer_GetUserByEmailAndPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetUserByEmailAndPassword_Call) Return(u db.User, err error) *Storer_GetUserByEmailAndPassword_Call {
+	_c.Call.Return(u, err)
+	return _c
+}
+
+// GetUserByID provides a mock function with given fields: ctx, userID
+func (_m *Storer) GetUserByID(ctx context.Context, userID string) (db.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 db.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) db.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(db.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type Storer_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+func (_e *Storer_Expecter) GetUserByID(ctx interface{}, userID interface{}) *Storer_GetUserByID_Call {
+	return &Storer_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, userID)}
+}
+
+func (_c *Storer_GetUserByID_Call) Run(run func(ctx context.Context, userID string)) *Storer_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_GetUserByID_Call) Return(u db.User, err error) *Storer_GetUserByID_Call {
+	_c.Call.Return(u, err)
+	return _c
+}
+
+// IsTokenRevoked provides a mock function with given fields: ctx, jti, sessionID
+func (_m *Storer) IsTokenRevoked(ctx context.Context, jti string, sessionID string) (bool, error) {
+	ret := _m.Called(ctx, jti, sessionID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, jti, sessionID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, jti, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_IsTokenRevoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsTokenRevoked'
+type Storer_IsTokenRevoked_Call struct {
+	*mock.Call
+}
+
+// IsTokenRevoked is a helper method to define mock.On call
+//  - ctx context.Context
+//  - jti string
+//  - sessionID string
+func (_e *Storer_Expecter) IsTokenRevoked(ctx interface{}, jti interface{}, sessionID interface{}) *Storer_IsTokenRevoked_Call {
+	return &Storer_IsTokenRevoked_Call{Call: _e.mock.On("IsTokenRevoked", ctx, jti, sessionID)}
+}
+
+func (_c *Storer_IsTokenRevoked_Call) Run(run func(ctx context.Context, jti string, sessionID string)) *Storer_IsTokenRevoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_IsTokenRevoked_Call) Return(revoked bool, err error) *Storer_IsTokenRevoked_Call {
+	_c.Call.Return(revoked, err)
+	return _c
+}
+
+// OpenAccount provides a mock function with given fields: ctx, userID, acc
+func (_m *Storer) OpenAccount(ctx context.Context, userID string, acc db.Account) error {
+	ret := _m.Called(ctx, userID, acc)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, db.Account) error); ok {
+		r0 = rf(ctx, userID, acc)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_OpenAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenAccount'
+type Storer_OpenAccount_Call struct {
+	*mock.Call
+}
+
+// OpenAccount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+//  - acc db.Account
+func (_e *Storer_Expecter) OpenAccount(ctx interface{}, userID interface{}, acc interface{}) *Storer_OpenAccount_Call {
+	return &Storer_OpenAccount_Call{Call: _e.mock.On("OpenAccount", ctx, userID, acc)}
+}
+
+func (_c *Storer_OpenAccount_Call) Run(run func(ctx context.Context, userID string, acc db.Account)) *Storer_OpenAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(db.Account))
+	})
+	return _c
+}
+
+func (_c *Storer_OpenAccount_Call) Return(err error) *Storer_OpenAccount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// PostInterest provides a mock function with given fields: ctx, accID, businessDate
+func (_m *Storer) PostInterest(ctx context.Context, accID string, businessDate time.Time) (money.Amount, error) {
+	ret := _m.Called(ctx, accID, businessDate)
+
+	var r0 money.Amount
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) money.Amount); ok {
+		r0 = rf(ctx, accID, businessDate)
+	} else {
+		r0 = ret.Get(0).(money.Amount)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, accID, businessDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_PostInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostInterest'
+type Storer_PostInterest_Call struct {
+	*mock.Call
+}
+
+// PostInterest is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - businessDate time.Time
+func (_e *Storer_Expecter) PostInterest(ctx interface{}, accID interface{}, businessDate interface{}) *Storer_PostInterest_Call {
+	return &Storer_PostInterest_Call{Call: _e.mock.On("PostInterest", ctx, accID, businessDate)}
+}
+
+func (_c *Storer_PostInterest_Call) Run(run func(ctx context.Context, accID string, businessDate time.Time)) *Storer_PostInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storer_PostInterest_Call) Return(posted money.Amount, err error) *Storer_PostInterest_Call {
+	_c.Call.Return(posted, err)
+	return _c
+}
+
+// ReconcileLedger provides a mock function with given fields: ctx
+func (_m *Storer) ReconcileLedger(ctx context.Context) ([]db.BalanceMismatch, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []db.BalanceMismatch
+	if rf, ok := ret.Get(0).(func(context.Context) []db.BalanceMismatch); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.BalanceMismatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_ReconcileLedger_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileLedger'
+type Storer_ReconcileLedger_Call struct {
+	*mock.Call
+}
+
+// ReconcileLedger is a helper method to define mock.On call
+//  - ctx context.Context
+func (_e *Storer_Expecter) ReconcileLedger(ctx interface{}) *Storer_ReconcileLedger_Call {
+	return &Storer_ReconcileLedger_Call{Call: _e.mock.On("ReconcileLedger", ctx)}
+}
+
+func (_c *Storer_ReconcileLedger_Call) Run(run func(ctx context.Context)) *Storer_ReconcileLedger_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Storer_ReconcileLedger_Call) Return(mismatches []db.BalanceMismatch, err error) *Storer_ReconcileLedger_Call {
+	_c.Call.Return(mismatches, err)
+	return _c
+}
+
+// RecordLoginFailure provides a mock function with given fields: ctx, kind, key, resetBefore
+func (_m *Storer) RecordLoginFailure(ctx context.Context, kind string, key string, resetBefore time.Time) (db.LoginAttempt, error) {
+	ret := _m.Called(ctx, kind, key, resetBefore)
+
+	var r0 db.LoginAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) db.LoginAttempt); ok {
+		r0 = rf(ctx, kind, key, resetBefore)
+	} else {
+		r0 = ret.Get(0).(db.LoginAttempt)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, kind, key, resetBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_RecordLoginFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordLoginFailure'
+type Storer_RecordLoginFailure_Call struct {
+	*mock.Call
+}
+
+// RecordLoginFailure is a helper method to define mock.On call
+//  - ctx context.Context
+//  - kind string
+//  - key string
+//  - resetBefore time.Time
+func (_e *Storer_Expecter) RecordLoginFailure(ctx interface{}, kind interface{}, key interface{}, resetBefore interface{}) *Storer_RecordLoginFailure_Call {
+	return &Storer_RecordLoginFailure_Call{Call: _e.mock.On("RecordLoginFailure", ctx, kind, key, resetBefore)}
+}
+
+func (_c *Storer_RecordLoginFailure_Call) Run(run func(ctx context.Context, kind string, key string, resetBefore time.Time)) *Storer_RecordLoginFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storer_RecordLoginFailure_Call) Return(attempt db.LoginAttempt, err error) *Storer_RecordLoginFailure_Call {
+	_c.Call.Return(attempt, err)
+	return _c
+}
+
+// RecordStandingOrderExecution provides a mock function with given fields: ctx, o, e
+func (_m *Storer) RecordStandingOrderExecution(ctx context.Context, o db.StandingOrder, e db.StandingOrderExecution) error {
+	ret := _m.Called(ctx, o, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.StandingOrder, db.StandingOrderExecution) error); ok {
+		r0 = rf(ctx, o, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_RecordStandingOrderExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordStandingOrderExecution'
+type Storer_RecordStandingOrderExecution_Call struct {
+	*mock.Call
+}
+
+// RecordStandingOrderExecution is a helper method to define mock.On call
+//  - ctx context.Context
+//  - o db.StandingOrder
+//  - e db.StandingOrderExecution
+func (_e *Storer_Expecter) RecordStandingOrderExecution(ctx interface{}, o interface{}, e interface{}) *Storer_RecordStandingOrderExecution_Call {
+	return &Storer_RecordStandingOrderExecution_Call{Call: _e.mock.On("RecordStandingOrderExecution", ctx, o, e)}
+}
+
+func (_c *Storer_RecordStandingOrderExecution_Call) Run(run func(ctx context.Context, o db.StandingOrder, e db.StandingOrderExecution)) *Storer_RecordStandingOrderExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.StandingOrder), args[2].(db.StandingOrderExecution))
+	})
+	return _c
+}
+
+func (_c *Storer_RecordStandingOrderExecution_Call) Return(err error) *Storer_RecordStandingOrderExecution_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// ReleaseHold provides a mock function with given fields: ctx, accID, holdID
+func (_m *Storer) ReleaseHold(ctx context.Context, accID string, holdID string) (db.Hold, error) {
+	ret := _m.Called(ctx, accID, holdID)
+
+	var r0 db.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) db.Hold); ok {
+		r0 = rf(ctx, accID, holdID)
+	} else {
+		r0 = ret.Get(0).(db.Hold)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accID, holdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_ReleaseHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseHold'
+type Storer_ReleaseHold_Call struct {
+	*mock.Call
+}
+
+// ReleaseHold is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - holdID string
+func (_e *Storer_Expecter) ReleaseHold(ctx interface{}, accID interface{}, holdID interface{}) *Storer_ReleaseHold_Call {
+	return &Storer_ReleaseHold_Call{Call: _e.mock.On("ReleaseHold", ctx, accID, holdID)}
+}
+
+func (_c *Storer_ReleaseHold_Call) Run(run func(ctx context.Context, accID string, holdID string)) *Storer_ReleaseHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_ReleaseHold_Call) Return(released db.Hold, err error) *Storer_ReleaseHold_Call {
+	_c.Call.Return(released, err)
+	return _c
+}
+
+// ReserveIdempotencyKey provides a mock function with given fields: ctx, k, ttl
+func (_m *Storer) ReserveIdempotencyKey(ctx context.Context, k db.IdempotencyKey, ttl time.Duration) (db.IdempotencyKey, bool, error) {
+	ret := _m.Called(ctx, k, ttl)
+
+	var r0 db.IdempotencyKey
+	if rf, ok := ret.Get(0).(func(context.Context, db.IdempotencyKey, time.Duration) db.IdempotencyKey); ok {
+		r0 = rf(ctx, k, ttl)
+	} else {
+		r0 = ret.Get(0).(db.IdempotencyKey)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, db.IdempotencyKey, time.Duration) bool); ok {
+		r1 = rf(ctx, k, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, db.IdempotencyKey, time.Duration) error); ok {
+		r2 = rf(ctx, k, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Storer_ReserveIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveIdempotencyKey'
+type Storer_ReserveIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// ReserveIdempotencyKey is a helper method to define mock.On call
+//  - ctx context.Context
+//  - k db.IdempotencyKey
+//  - ttl time.Duration
+func (_e *Storer_Expecter) ReserveIdempotencyKey(ctx interface{}, k interface{}, ttl interface{}) *Storer_ReserveIdempotencyKey_Call {
+	return &Storer_ReserveIdempotencyKey_Call{Call: _e.mock.On("ReserveIdempotencyKey", ctx, k, ttl)}
+}
+
+func (_c *Storer_ReserveIdempotencyKey_Call) Run(run func(ctx context.Context, k db.IdempotencyKey, ttl time.Duration)) *Storer_ReserveIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.IdempotencyKey), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Storer_ReserveIdempotencyKey_Call) Return(existing db.IdempotencyKey, reserved bool, err error) *Storer_ReserveIdempotencyKey_Call {
+	_c.Call.Return(existing, reserved, err)
+	return _c
+}
+
+// ResetLoginFailures provides a mock function with given fields: ctx, kind, key
+func (_m *Storer) ResetLoginFailures(ctx context.Context, kind string, key string) error {
+	ret := _m.Called(ctx, kind, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, kind, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_ResetLoginFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetLoginFailures'
+type Storer_ResetLoginFailures_Call struct {
+	*mock.Call
+}
+
+// ResetLoginFailures is a helper method to define mock.On call
+//  - ctx context.Context
+//  - kind string
+//  - key string
+func (_e *Storer_Expecter) ResetLoginFailures(ctx interface{}, kind interface{}, key interface{}) *Storer_ResetLoginFailures_Call {
+	return &Storer_ResetLoginFailures_Call{Call: _e.mock.On("ResetLoginFailures", ctx, kind, key)}
+}
+
+func (_c *Storer_ResetLoginFailures_Call) Run(run func(ctx context.Context, kind string, key string)) *Storer_ResetLoginFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_ResetLoginFailures_Call) Return(err error) *Storer_ResetLoginFailures_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: ctx, tokenHash, newPassword
+func (_m *Storer) ResetPassword(ctx context.Context, tokenHash string, newPassword string) error {
+	ret := _m.Called(ctx, tokenHash, newPassword)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tokenHash, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type Storer_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//  - ctx context.Context
+//  - tokenHash string
+//  - newPassword string
+func (_e *Storer_Expecter) ResetPassword(ctx interface{}, tokenHash interface{}, newPassword interface{}) *Storer_ResetPassword_Call {
+	return &Storer_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, tokenHash, newPassword)}
+}
+
+func (_c *Storer_ResetPassword_Call) Run(run func(ctx context.Context, tokenHash string, newPassword string)) *Storer_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_ResetPassword_Call) Return(err error) *Storer_ResetPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// ReverseTransaction provides a mock function with given fields: ctx, r
+func (_m *Storer) ReverseTransaction(ctx context.Context, r db.Reversal) (db.Transaction, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 db.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, db.Reversal) db.Transaction); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Get(0).(db.Transaction)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, db.Reversal) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_ReverseTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReverseTransaction'
+type Storer_ReverseTransaction_Call struct {
+	*mock.Call
+}
+
+// ReverseTransaction is a helper method to define mock.On call
+//  - ctx context.Context
+//  - r db.Reversal
+func (_e *Storer_Expecter) ReverseTransaction(ctx interface{}, r interface{}) *Storer_ReverseTransaction_Call {
+	return &Storer_ReverseTransaction_Call{Call: _e.mock.On("ReverseTransaction", ctx, r)}
+}
+
+func (_c *Storer_ReverseTransaction_Call) Run(run func(ctx context.Context, r db.Reversal)) *Storer_ReverseTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.Reversal))
+	})
+	return _c
+}
+
+func (_c *Storer_ReverseTransaction_Call) Return(reversal db.Transaction, err error) *Storer_ReverseTransaction_Call {
+	_c.Call.Return(reversal, err)
+	return _c
+}
+
+// RevokeAccessToken provides a mock function with given fields: ctx, jti, expiresAt
+func (_m *Storer) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, jti, expiresAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, jti, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_RevokeAccessToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAccessToken'
+type Storer_RevokeAccessToken_Call struct {
+	*mock.Call
+}
+
+// RevokeAccessToken is a helper method to define mock.On call
+//  - ctx context.Context
+//  - jti string
+//  - expiresAt time.Time
+func (_e *Storer_Expecter) RevokeAccessToken(ctx interface{}, jti interface{}, expiresAt interface{}) *Storer_RevokeAccessToken_Call {
+	return &Storer_RevokeAccessToken_Call{Call: _e.mock.On("RevokeAccessToken", ctx, jti, expiresAt)}
+}
+
+func (_c *Storer_RevokeAccessToken_Call) Run(run func(ctx context.Context, jti string, expiresAt time.Time)) *Storer_RevokeAccessToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Storer_RevokeAccessToken_Call) Return(err error) *Storer_RevokeAccessToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: ctx, sessionID, userID
+func (_m *Storer) RevokeSession(ctx context.Context, sessionID string, userID string) error {
+	ret := _m.Called(ctx, sessionID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, sessionID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type Storer_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//  - ctx context.Context
+//  - sessionID string
+//  - userID string
+func (_e *Storer_Expecter) RevokeSession(ctx interface{}, sessionID interface{}, userID interface{}) *Storer_RevokeSession_Call {
+	return &Storer_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, sessionID, userID)}
+}
+
+func (_c *Storer_RevokeSession_Call) Run(run func(ctx context.Context, sessionID string, userID string)) *Storer_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_RevokeSession_Call) Return(err error) *Storer_RevokeSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// RoleHasPermission provides a mock function with given fields: ctx, role, permission
+func (_m *Storer) RoleHasPermission(ctx context.Context, role string, permission string) (bool, error) {
+	ret := _m.Called(ctx, role, permission)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, role, permission)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, role, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_RoleHasPermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RoleHasPermission'
+type Storer_RoleHasPermission_Call struct {
+	*mock.Call
+}
+
+// RoleHasPermission is a helper method to define mock.On call
+//  - ctx context.Context
+//  - role string
+//  - permission string
+func (_e *Storer_Expecter) RoleHasPermission(ctx interface{}, role interface{}, permission interface{}) *Storer_RoleHasPermission_Call {
+	return &Storer_RoleHasPermission_Call{Call: _e.mock.On("RoleHasPermission", ctx, role, permission)}
+}
+
+func (_c *Storer_RoleHasPermission_Call) Run(run func(ctx context.Context, role string, permission string)) *Storer_RoleHasPermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_RoleHasPermission_Call) Return(allowed bool, err error) *Storer_RoleHasPermission_Call {
+	_c.Call.Return(allowed, err)
+	return _c
+}
+
+// RotateRefreshToken provides a mock function with given fields: ctx, tokenHash, next
+func (_m *Storer) RotateRefreshToken(ctx context.Context, tokenHash string, next db.RefreshToken) (db.Session, error) {
+	ret := _m.Called(ctx, tokenHash, next)
+
+	var r0 db.Session
+	if rf, ok := ret.Get(0).(func(context.Context, string, db.RefreshToken) db.Session); ok {
+		r0 = rf(ctx, tokenHash, next)
+	} else {
+		r0 = ret.Get(0).(db.Session)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, db.RefreshToken) error); ok {
+		r1 = rf(ctx, tokenHash, next)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_RotateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateRefreshToken'
+type Storer_RotateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// RotateRefreshToken is a helper method to define mock.On call
+//  - ctx context.Context
+//  - tokenHash string
+//  - next db.RefreshToken
+func (_e *Storer_Expecter) RotateRefreshToken(ctx interface{}, tokenHash interface{}, next interface{}) *Storer_RotateRefreshToken_Call {
+	return &Storer_RotateRefreshToken_Call{Call: _e.mock.On("RotateRefreshToken", ctx, tokenHash, next)}
+}
+
+func (_c *Storer_RotateRefreshToken_Call) Run(run func(ctx context.Context, tokenHash string, next db.RefreshToken)) *Storer_RotateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(db.RefreshToken))
+	})
+	return _c
+}
+
+func (_c *Storer_RotateRefreshToken_Call) Return(sess db.Session, err error) *Storer_RotateRefreshToken_Call {
+	_c.Call.Return(sess, err)
+	return _c
+}
+
+// SaveIdempotentResponse provides a mock function with given fields: ctx, userID, key, statusCode, body
+func (_m *Storer) SaveIdempotentResponse(ctx context.Context, userID string, key string, statusCode int, body []byte) error {
+	ret := _m.Called(ctx, userID, key, statusCode, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, []byte) error); ok {
+		r0 = rf(ctx, userID, key, statusCode, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_SaveIdempotentResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveIdempotentResponse'
+type Storer_SaveIdempotentResponse_Call struct {
+	*mock.Call
+}
+
+// SaveIdempotentResponse is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+//  - key string
+//  - statusCode int
+//  - body []byte
+func (_e *Storer_Expecter) SaveIdempotentResponse(ctx interface{}, userID interface{}, key interface{}, statusCode interface{}, body interface{}) *Storer_SaveIdempotentResponse_Call {
+	return &Storer_SaveIdempotentResponse_Call{Call: _e.mock.On("SaveIdempotentResponse", ctx, userID, key, statusCode, body)}
+}
+
+func (_c *Storer_SaveIdempotentResponse_Call) Run(run func(ctx context.Context, userID string, key string, statusCode int, body []byte)) *Storer_SaveIdempotentResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int), args[4].([]byte))
+	})
+	return _c
+}
+
+func (_c *Storer_SaveIdempotentResponse_Call) Return(err error) *Storer_SaveIdempotentResponse_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// SaveMFASecret provides a mock function with given fields: ctx, userID, secret
+func (_m *Storer) SaveMFASecret(ctx context.Context, userID string, secret string) error {
+	ret := _m.Called(ctx, userID, secret)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_SaveMFASecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveMFASecret'
+type Storer_SaveMFASecret_Call struct {
+	*mock.Call
+}
+
+// SaveMFASecret is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+//  - secret string
+func (_e *Storer_Expecter) SaveMFASecret(ctx interface{}, userID interface{}, secret interface{}) *Storer_SaveMFASecret_Call {
+	return &Storer_SaveMFASecret_Call{Call: _e.mock.On("SaveMFASecret", ctx, userID, secret)}
+}
+
+func (_c *Storer_SaveMFASecret_Call) Run(run func(ctx context.Context, userID string, secret string)) *Storer_SaveMFASecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_SaveMFASecret_Call) Return(err error) *Storer_SaveMFASecret_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// SetAccountDebitLimits provides a mock function with given fields: ctx, accID, limits
+func (_m *Storer) SetAccountDebitLimits(ctx context.Context, accID string, limits db.DebitLimits) error {
+	ret := _m.Called(ctx, accID, limits)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, db.DebitLimits) error); ok {
+		r0 = rf(ctx, accID, limits)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_SetAccountDebitLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountDebitLimits'
+type Storer_SetAccountDebitLimits_Call struct {
+	*mock.Call
+}
+
+// SetAccountDebitLimits is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - limits db.DebitLimits
+func (_e *Storer_Expecter) SetAccountDebitLimits(ctx interface{}, accID interface{}, limits interface{}) *Storer_SetAccountDebitLimits_Call {
+	return &Storer_SetAccountDebitLimits_Call{Call: _e.mock.On("SetAccountDebitLimits", ctx, accID, limits)}
+}
+
+func (_c *Storer_SetAccountDebitLimits_Call) Run(run func(ctx context.Context, accID string, limits db.DebitLimits)) *Storer_SetAccountDebitLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(db.DebitLimits))
+	})
+	return _c
+}
+
+func (_c *Storer_SetAccountDebitLimits_Call) Return(err error) *Storer_SetAccountDebitLimits_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// SetAccountStatus provides a mock function with given fields: ctx, accID, from, status, reason
+func (_m *Storer) SetAccountStatus(ctx context.Context, accID string, from []string, status string, reason string) error {
+	ret := _m.Called(ctx, accID, from, status, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string, string) error); ok {
+		r0 = rf(ctx, accID, from, status, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_SetAccountStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountStatus'
+type Storer_SetAccountStatus_Call struct {
+	*mock.Call
+}
+
+// SetAccountStatus is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - from []string
+//  - status string
+//  - reason string
+func (_e *Storer_Expecter) SetAccountStatus(ctx interface{}, accID interface{}, from interface{}, status interface{}, reason interface{}) *Storer_SetAccountStatus_Call {
+	return &Storer_SetAccountStatus_Call{Call: _e.mock.On("SetAccountStatus", ctx, accID, from, status, reason)}
+}
+
+func (_c *Storer_SetAccountStatus_Call) Run(run func(ctx context.Context, accID string, from []string, status string, reason string)) *Storer_SetAccountStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_SetAccountStatus_Call) Return(err error) *Storer_SetAccountStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// SetOverdraftLimit provides a mock function with given fields: ctx, accID, limit
+func (_m *Storer) SetOverdraftLimit(ctx context.Context, accID string, limit money.Amount) error {
+	ret := _m.Called(ctx, accID, limit)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, money.Amount) error); ok {
+		r0 = rf(ctx, accID, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_SetOverdraftLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOverdraftLimit'
+type Storer_SetOverdraftLimit_Call struct {
+	*mock.Call
+}
+
+// SetOverdraftLimit is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - limit money.Amount
+func (_e *Storer_Expecter) SetOverdraftLimit(ctx interface{}, accID interface{}, limit interface{}) *Storer_SetOverdraftLimit_Call {
+	return &Storer_SetOverdraftLimit_Call{Call: _e.mock.On("SetOverdraftLimit", ctx, accID, limit)}
+}
+
+func (_c *Storer_SetOverdraftLimit_Call) Run(run func(ctx context.Context, accID string, limit money.Amount)) *Storer_SetOverdraftLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(money.Amount))
+	})
+	return _c
+}
+
+func (_c *Storer_SetOverdraftLimit_Call) Return(err error) *Storer_SetOverdraftLimit_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// SetUserRole provides a mock function with given fields: ctx, userID, role
+func (_m *Storer) SetUserRole(ctx context.Context, userID string, role string) error {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_SetUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserRole'
+type Storer_SetUserRole_Call struct {
+	*mock.Call
+}
+
+// SetUserRole is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+//  - role string
+func (_e *Storer_Expecter) SetUserRole(ctx interface{}, userID interface{}, role interface{}) *Storer_SetUserRole_Call {
+	return &Storer_SetUserRole_Call{Call: _e.mock.On("SetUserRole", ctx, userID, role)}
+}
+
+func (_c *Storer_SetUserRole_Call) Run(run func(ctx context.Context, userID string, role string)) *Storer_SetUserRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_SetUserRole_Call) Return(err error) *Storer_SetUserRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// TransferAmount provides a mock function with given fields: ctx, fromAccID, toAccID, userID, amount
+func (_m *Storer) TransferAmount(ctx context.Context, fromAccID string, toAccID string, userID string, amount money.Amount) (db.Transfer, error) {
+	ret := _m.Called(ctx, fromAccID, toAccID, userID, amount)
+
+	var r0 db.Transfer
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, money.Amount) db.Transfer); ok {
+		r0 = rf(ctx, fromAccID, toAccID, userID, amount)
+	} else {
+		r0 = ret.Get(0).(db.Transfer)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, money.Amount) error); ok {
+		r1 = rf(ctx, fromAccID, toAccID, userID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_TransferAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferAmount'
+type Storer_TransferAmount_Call struct {
+	*mock.Call
+}
+
+// TransferAmount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - fromAccID string
+//  - toAccID string
+//  - userID string
+//  - amount money.Amount
+func (_e *Storer_Expecter) TransferAmount(ctx interface{}, fromAccID interface{}, toAccID interface{}, userID interface{}, amount interface{}) *Storer_TransferAmount_Call {
+	return &Storer_TransferAmount_Call{Call: _e.mock.On("TransferAmount", ctx, fromAccID, toAccID, userID, amount)}
+}
+
+func (_c *Storer_TransferAmount_Call) Run(run func(ctx context.Context, fromAccID string, toAccID string, userID string, amount money.Amount)) *Storer_TransferAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(money.Amount))
+	})
+	return _c
+}
+
+func (_c *Storer_TransferAmount_Call) Return(t db.Transfer, err error) *Storer_TransferAmount_Call {
+	_c.Call.Return(t, err)
+	return _c
+}
+
+// UnlockUser provides a mock function with given fields: ctx, userID
+func (_m *Storer) UnlockUser(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_UnlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockUser'
+type Storer_UnlockUser_Call struct {
+	*mock.Call
+}
+
+// UnlockUser is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+func (_e *Storer_Expecter) UnlockUser(ctx interface{}, userID interface{}) *Storer_UnlockUser_Call {
+	return &Storer_UnlockUser_Call{Call: _e.mock.On("UnlockUser", ctx, userID)}
+}
+
+func (_c *Storer_UnlockUser_Call) Run(run func(ctx context.Context, userID string)) *Storer_UnlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_UnlockUser_Call) Return(err error) *Storer_UnlockUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// UpdateFeeRule provides a mock function with given fields: ctx, r
+func (_m *Storer) UpdateFeeRule(ctx context.Context, r db.FeeRule) (db.FeeRule, error) {
+	ret := _m.Called(ctx, r)
+
+	var r0 db.FeeRule
+	if rf, ok := ret.Get(0).(func(context.Context, db.FeeRule) db.FeeRule); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Get(0).(db.FeeRule)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, db.FeeRule) error); ok {
+		r1 = rf(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_UpdateFeeRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFeeRule'
+type Storer_UpdateFeeRule_Call struct {
+	*mock.Call
+}
+
+// UpdateFeeRule is a helper method to define mock.On call
+//  - ctx context.Context
+//  - r db.FeeRule
+func (_e *Storer_Expecter) UpdateFeeRule(ctx interface{}, r interface{}) *Storer_UpdateFeeRule_Call {
+	return &Storer_UpdateFeeRule_Call{Call: _e.mock.On("UpdateFeeRule", ctx, r)}
+}
+
+func (_c *Storer_UpdateFeeRule_Call) Run(run func(ctx context.Context, r db.FeeRule)) *Storer_UpdateFeeRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.FeeRule))
+	})
+	return _c
+}
+
+func (_c *Storer_UpdateFeeRule_Call) Return(updated db.FeeRule, err error) *Storer_UpdateFeeRule_Call {
+	_c.Call.Return(updated, err)
+	return _c
+}
+
+// UseMFACode provides a mock function with given fields: ctx, userID, counter
+func (_m *Storer) UseMFACode(ctx context.Context, userID string, counter int64) error {
+	ret := _m.Called(ctx, userID, counter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, userID, counter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_UseMFACode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseMFACode'
+type Storer_UseMFACode_Call struct {
+	*mock.Call
+}
+
+// UseMFACode is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+//  - counter int64
+func (_e *Storer_Expecter) UseMFACode(ctx interface{}, userID interface{}, counter interface{}) *Storer_UseMFACode_Call {
+	return &Storer_UseMFACode_Call{Call: _e.mock.On("UseMFACode", ctx, userID, counter)}
+}
+
+func (_c *Storer_UseMFACode_Call) Run(run func(ctx context.Context, userID string, counter int64)) *Storer_UseMFACode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *Storer_UseMFACode_Call) Return(err error) *Storer_UseMFACode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *Storer) UseRecoveryCode(ctx context.Context, userID string, codeHash string) error {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type Storer_UseRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// UseRecoveryCode is a helper method to define mock.On call
+//  - ctx context.Context
+//  - userID string
+//  - codeHash string
+func (_e *Storer_Expecter) UseRecoveryCode(ctx interface{}, userID interface{}, codeHash interface{}) *Storer_UseRecoveryCode_Call {
+	return &Storer_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, userID, codeHash)}
+}
+
+func (_c *Storer_UseRecoveryCode_Call) Run(run func(ctx context.Context, userID string, codeHash string)) *Storer_UseRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Storer_UseRecoveryCode_Call) Return(err error) *Storer_UseRecoveryCode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// WithTx provides a mock function with given fields: ctx, op
+func (_m *Storer) WithTx(ctx context.Context, op func(context.Context) error) error {
+	ret := _m.Called(ctx, op)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, op)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Storer_WithTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTx'
+type Storer_WithTx_Call struct {
+	*mock.Call
+}
+
+// WithTx is a helper method to define mock.On call
+//  - ctx context.Context
+//  - op func(context.Context) error
+func (_e *Storer_Expecter) WithTx(ctx interface{}, op interface{}) *Storer_WithTx_Call {
+	return &Storer_WithTx_Call{Call: _e.mock.On("WithTx", ctx, op)}
+}
+
+func (_c *Storer_WithTx_Call) Run(run func(ctx context.Context, op func(context.Context) error)) *Storer_WithTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *Storer_WithTx_Call) Return(err error) *Storer_WithTx_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+// WithdrawAmount provides a mock function with given fields: ctx, accID, userID, amount
+func (_m *Storer) WithdrawAmount(ctx context.Context, accID string, userID string, amount money.Amount) ([]db.Transaction, error) {
+	ret := _m.Called(ctx, accID, userID, amount)
+
+	var r0 []db.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, money.Amount) []db.Transaction); ok {
+		r0 = rf(ctx, accID, userID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, money.Amount) error); ok {
+		r1 = rf(ctx, accID, userID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Storer_WithdrawAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithdrawAmount'
+type Storer_WithdrawAmount_Call struct {
+	*mock.Call
+}
+
+// WithdrawAmount is a helper method to define mock.On call
+//  - ctx context.Context
+//  - accID string
+//  - userID string
+//  - amount money.Amount
+func (_e *Storer_Expecter) WithdrawAmount(ctx interface{}, accID interface{}, userID interface{}, amount interface{}) *Storer_WithdrawAmount_Call {
+	return &Storer_WithdrawAmount_Call{Call: _e.mock.On("WithdrawAmount", ctx, accID, userID, amount)}
+}
+
+func (_c *Storer_WithdrawAmount_Call) Run(run func(ctx context.Context, accID string, userID string, amount money.Amount)) *Storer_WithdrawAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(money.Amount))
+	})
+	return _c
+}
+
+func (_c *Storer_WithdrawAmount_Call) Return(fees []db.Transaction, err error) *Storer_WithdrawAmount_Call {
+	_c.Call.Return(fees, err)
+	return _c
+}
+
 type mockConstructorTestingTNewStorer interface {
 	mock.TestingT
 	Cleanup(func())
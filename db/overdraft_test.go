@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_Overdraft() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(2000, 2))
+
+	_, err := sts.store.WithdrawAmount(ctx, accID, userID, money.New(5000, 2))
+	sts.Equal(ErrInsufficientFunds, err)
+
+	sts.Require().NoError(sts.store.SetOverdraftLimit(ctx, accID, money.New(5000, 2)))
+	_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(5000, 2))
+	sts.Require().NoError(err)
+	sts.Equal("-30", sts.balance(accID).Round(0).String())
+
+	acc, err := sts.store.GetAccountDetails(ctx, accID, userID)
+	sts.Require().NoError(err)
+	sts.Equal("-30", acc.Balance.Round(0).String())
+	sts.Equal("20", acc.AvailableBalance.Round(0).String())
+
+	// the balance cannot go below the limit
+	_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(2001, 2))
+	sts.Equal(ErrInsufficientFunds, err)
+
+	// overdrawn accounts are charged overdraft interest instead of earning it
+	today := time.Now()
+	accounts, err := sts.store.GetInterestAccounts(ctx, today)
+	sts.Require().NoError(err)
+	var overdrawn *InterestAccount
+	for i := range accounts {
+		if accounts[i].AccountID == accID {
+			overdrawn = &accounts[i]
+		}
+	}
+	sts.Require().NotNil(overdrawn)
+	sts.Equal("overdraft", overdrawn.Product)
+	sts.Equal("overdraft", overdrawn.Kind)
+
+	accrual := InterestAccrual{AccountID: accID, BusinessDate: today, Product: overdrawn.Product, Balance: overdrawn.Balance, Amount: money.New(-1500000, 6)}
+	sts.Require().NoError(sts.store.AddInterestAccrual(ctx, accrual))
+	charged, err := sts.store.PostInterest(ctx, accID, today)
+	sts.Require().NoError(err)
+	sts.Equal("-1.50", charged.String())
+	sts.Equal("-31.50", sts.balance(accID).String())
+
+	mismatches, err := sts.store.ReconcileLedger(ctx)
+	sts.Require().NoError(err)
+	sts.Empty(mismatches)
+
+	// internal accounts of the bank are not customer accounts
+	sts.Equal(ErrAccountNotExist, sts.store.SetOverdraftLimit(ctx, CashAccountID, money.New(100, 2)))
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	changePasswordQuery            = `UPDATE users SET password=crypt($3, gen_salt('bf')) WHERE id=$1 AND password=crypt($2, password)`
+	setPasswordQuery               = `UPDATE users SET password=crypt($2, gen_salt('bf')) WHERE id=$1`
+	getUserIDByEmailQuery          = `SELECT id FROM users WHERE email=$1`
+	createPasswordResetTokenQuery  = `INSERT INTO password_reset_tokens(token_hash, user_id, created_at, expires_at) VALUES ($1, $2, $3, $4)`
+	getPasswordResetForUpdateQuery = `SELECT * FROM password_reset_tokens WHERE token_hash=$1 FOR UPDATE`
+	usePasswordResetTokensQuery    = `UPDATE password_reset_tokens SET used_at=$2 WHERE user_id=$1 AND used_at IS NULL`
+	revokeAllUserSessionsQuery     = `UPDATE sessions SET revoked_at=$2 WHERE user_id=$1 AND revoked_at IS NULL`
+	revokeOtherUserSessionsQuery   = `UPDATE sessions SET revoked_at=$3 WHERE user_id=$1 AND id<>$2 AND revoked_at IS NULL`
+)
+
+// PasswordResetToken is stored by the hash of the token, never the token itself.
+type PasswordResetToken struct {
+	TokenHash string     `db:"token_hash"`
+	UserID    string     `db:"user_id"`
+	CreatedAt time.Time  `db:"created_at"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+}
+
+// ChangePassword sets a new password for the user if oldPassword is the
+// current one, and returns ErrUserNotExist otherwise. All other sessions of
+// the user are revoked.
+func (s *store) ChangePassword(ctx context.Context, userID, sessionID, oldPassword, newPassword string) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		res, err := s.conn(ctx).ExecContext(ctx, changePasswordQuery, userID, oldPassword, newPassword)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			if err == nil {
+				err = ErrUserNotExist
+			}
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, revokeOtherUserSessionsQuery, userID, sessionID, time.Now())
+		return err
+	})
+	return
+}
+
+// CreatePasswordResetToken saves t for the user with the given email, and
+// returns ErrUserNotExist if there is none.
+func (s *store) CreatePasswordResetToken(ctx context.Context, email string, t PasswordResetToken) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		var userID string
+		err := sqlx.GetContext(ctx, s.conn(ctx), &userID, getUserIDByEmailQuery, email)
+		if err == sql.ErrNoRows {
+			return ErrUserNotExist
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, createPasswordResetTokenQuery, t.TokenHash, userID, t.CreatedAt, t.ExpiresAt)
+		return err
+	})
+	return
+}
+
+// ResetPassword sets a new password for the owner of the reset token with the
+// given hash. This uses up every outstanding reset token of the user and
+// revokes all of their sessions.
+func (s *store) ResetPassword(ctx context.Context, tokenHash, newPassword string) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		var t PasswordResetToken
+		err := sqlx.GetContext(ctx, s.conn(ctx), &t, getPasswordResetForUpdateQuery, tokenHash)
+		if err == sql.ErrNoRows || (err == nil && t.UsedAt != nil) {
+			return ErrPasswordResetTokenNotExist
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if now.After(t.ExpiresAt) {
+			return ErrPasswordResetTokenExpired
+		}
+
+		if _, err = s.conn(ctx).ExecContext(ctx, setPasswordQuery, t.UserID, newPassword); err != nil {
+			return err
+		}
+		if _, err = s.conn(ctx).ExecContext(ctx, usePasswordResetTokensQuery, t.UserID, now); err != nil {
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, revokeAllUserSessionsQuery, t.UserID, now)
+		return err
+	})
+	return
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) createCustomer() (u User) {
+	ctx := context.Background()
+	u = User{
+		Email:       fmt.Sprintf("%s@test.com", uuidgen.New()[:8]),
+		PhoneNumber: "9999999999",
+		Password:    uuidgen.New(),
+		Type:        "customer",
+	}
+	accID := uuidgen.New()
+
+	sts.Require().NoError(sts.store.CreateAccount(ctx, u, Account{ID: accID, Balance: money.New(0, 2), Type: "savings"}))
+	sts.Require().NoError(sts.db.GetContext(ctx, &u.ID, `SELECT user_id FROM accounts WHERE id=$1`, accID))
+	return
+}
+
+func (sts *StoreTestSuite) Test_store_ChangePassword() {
+	ctx := context.Background()
+	u := sts.createCustomer()
+
+	current := Session{ID: uuidgen.New(), UserID: u.ID, CreatedAt: time.Now()}
+	other := Session{ID: uuidgen.New(), UserID: u.ID, CreatedAt: time.Now()}
+	sts.Require().NoError(sts.store.CreateSession(ctx, current, newTestRefreshToken(time.Hour)))
+	sts.Require().NoError(sts.store.CreateSession(ctx, other, newTestRefreshToken(time.Hour)))
+
+	err := sts.store.ChangePassword(ctx, u.ID, current.ID, "wrong", "N3w-Passw0rd")
+	sts.Equal(ErrUserNotExist, err)
+
+	sts.Require().NoError(sts.store.ChangePassword(ctx, u.ID, current.ID, u.Password, "N3w-Passw0rd"))
+
+	_, err = sts.store.GetUserByEmailAndPassword(ctx, u.Email, "N3w-Passw0rd")
+	sts.NoError(err)
+	_, err = sts.store.GetUserByEmailAndPassword(ctx, u.Email, u.Password)
+	sts.Equal(ErrUserNotExist, err)
+
+	revoked, err := sts.store.IsTokenRevoked(ctx, uuidgen.New(), current.ID)
+	sts.Require().NoError(err)
+	sts.False(revoked)
+	revoked, err = sts.store.IsTokenRevoked(ctx, uuidgen.New(), other.ID)
+	sts.Require().NoError(err)
+	sts.True(revoked)
+}
+
+func (sts *StoreTestSuite) Test_store_ResetPassword() {
+	ctx := context.Background()
+	u := sts.createCustomer()
+
+	t := PasswordResetToken{TokenHash: uuidgen.New() + uuidgen.New()[:28], CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	sts.Require().NoError(sts.store.CreatePasswordResetToken(ctx, u.Email, t))
+	sts.Equal(ErrUserNotExist, sts.store.CreatePasswordResetToken(ctx, "nobody@test.com", t))
+
+	sts.Require().NoError(sts.store.ResetPassword(ctx, t.TokenHash, "N3w-Passw0rd"))
+	_, err := sts.store.GetUserByEmailAndPassword(ctx, u.Email, "N3w-Passw0rd")
+	sts.NoError(err)
+
+	// tokens are single use
+	sts.Equal(ErrPasswordResetTokenNotExist, sts.store.ResetPassword(ctx, t.TokenHash, "0ther-Passw0rd"))
+
+	expired := PasswordResetToken{TokenHash: uuidgen.New() + uuidgen.New()[:28], CreatedAt: time.Now(), ExpiresAt: time.Now().Add(-time.Minute)}
+	sts.Require().NoError(sts.store.CreatePasswordResetToken(ctx, u.Email, expired))
+	sts.Equal(ErrPasswordResetTokenExpired, sts.store.ResetPassword(ctx, expired.TokenHash, "0ther-Passw0rd"))
+}
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+// Types of the transactions that reverse a credit or a debit
+const (
+	TransactionCreditReversal = "Credit Reversal"
+	TransactionDebitReversal  = "Debit Reversal"
+)
+
+const (
+	getAccountTransactionQuery = `SELECT * FROM transactions WHERE id=$1 AND account_id=$2`
+	sumReversalsQuery          = `SELECT COALESCE(SUM(amount), 0) FROM transactions
+		WHERE linked_transaction_id=$1 AND type IN ('Credit Reversal', 'Debit Reversal')`
+	getJournalEntryPostingsQuery = `SELECT * FROM postings WHERE journal_entry_id=$1 ORDER BY id`
+	getCustomerAccountIDsQuery   = `SELECT id FROM accounts WHERE id = ANY($1) AND user_id IS NOT NULL ORDER BY id`
+	getEntryTransactionQuery     = `SELECT * FROM transactions WHERE journal_entry_id=$1 AND account_id=$2 AND type IN ('Credit', 'Debit')`
+)
+
+// Reversal gives back Amount of a transaction of an account, all that is
+// left of it when Amount is nil.
+type Reversal struct {
+	TransactionID string
+	AccountID     string
+	Amount        *money.Amount
+	ReasonCode    string
+}
+
+// ReverseTransaction posts the opposite of a credit or debit, in whole or in
+// part. The journal entry of the transaction is reversed in proportion, so
+// a transfer is reversed on both of its accounts, each getting a reversal
+// linked to its own transaction. The reversal of the given account is
+// returned. Balances may go negative, the reversal takes back money that
+// should not have been there.
+func (s *store) ReverseTransaction(ctx context.Context, r Reversal) (reversal Transaction, err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		var original Transaction
+		err := sqlx.GetContext(ctx, s.conn(ctx), &original, getAccountTransactionQuery, r.TransactionID, r.AccountID)
+		if err == sql.ErrNoRows {
+			return ErrTransactionNotExist
+		}
+		if err != nil {
+			return err
+		}
+		if (original.Type != "Credit" && original.Type != "Debit") || original.JournalEntryID == nil {
+			return ErrTransactionNotReversible
+		}
+
+		var postings []Posting
+		if err := sqlx.SelectContext(ctx, s.conn(ctx), &postings, getJournalEntryPostingsQuery, *original.JournalEntryID); err != nil {
+			return err
+		}
+		accIDs := make([]string, 0, len(postings))
+		for _, p := range postings {
+			accIDs = append(accIDs, p.AccountID)
+		}
+
+		// the accounts are locked in the order of their IDs, as transfers
+		// lock them, so that the two cannot deadlock
+		var customerAccIDs []string
+		if err := sqlx.SelectContext(ctx, s.conn(ctx), &customerAccIDs, getCustomerAccountIDsQuery, pq.Array(accIDs)); err != nil {
+			return err
+		}
+		for _, id := range customerAccIDs {
+			acc, err := s.lockAccount(ctx, id)
+			if err != nil {
+				return err
+			}
+			if acc.Status == AccountClosed {
+				return ErrAccountStatusConflict
+			}
+		}
+
+		var reversed money.Amount
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &reversed, sumReversalsQuery, original.ID); err != nil {
+			return err
+		}
+		left := original.Amount.Sub(reversed)
+		amount := left
+		if r.Amount != nil {
+			amount = *r.Amount
+		}
+		switch {
+		case left.Sign() <= 0:
+			return ErrTransactionReversed
+		case amount.Cmp(left) > 0:
+			return fmt.Errorf("%w: %v is left to reverse", ErrReversalExceedsTransaction, left)
+		}
+
+		// every posting is reversed by the same fraction of the transaction
+		reversing := make([]Posting, 0, len(postings))
+		for _, p := range postings {
+			a := p.Amount.Neg()
+			if amount.Cmp(original.Amount) != 0 {
+				a = a.MulQuo(amount, 1, a.Scale()+amount.Scale()).Quo(original.Amount, a.Scale())
+			}
+			if a.IsZero() {
+				return ErrAmountTooSmall
+			}
+			reversing = append(reversing, Posting{AccountID: p.AccountID, Amount: a})
+		}
+
+		entry := newJournalEntry("Reversal", reversing...)
+		balances, err := s.postJournalEntry(ctx, entry)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range entry.Postings {
+			if !contains(customerAccIDs, p.AccountID) {
+				continue
+			}
+
+			t := Transaction{
+				ID:             uuidgen.New(),
+				Type:           TransactionCreditReversal,
+				Amount:         p.Amount.Neg(),
+				Balance:        balances[p.AccountID],
+				CreatedAt:      entry.CreatedAt,
+				AccountID:      p.AccountID,
+				JournalEntryID: &entry.ID,
+				ReasonCode:     &r.ReasonCode,
+			}
+			if p.Amount.Sign() > 0 {
+				t.Type, t.Amount = TransactionDebitReversal, p.Amount
+			}
+
+			if p.AccountID == original.AccountID {
+				t.LinkedTransactionID = &original.ID
+			} else {
+				var linked Transaction
+				err := sqlx.GetContext(ctx, s.conn(ctx), &linked, getEntryTransactionQuery, *original.JournalEntryID, p.AccountID)
+				if err != nil && err != sql.ErrNoRows {
+					return err
+				}
+				if err == nil {
+					t.LinkedTransactionID = &linked.ID
+				}
+			}
+
+			if err := s.AddTransaction(ctx, t); err != nil {
+				return err
+			}
+			if p.AccountID == original.AccountID {
+				reversal = t
+			}
+		}
+
+		fmt.Printf("Reversed amount: %v of transaction: %v on account: %v. Balance: %v\n",
+			amount, original.ID, original.AccountID, reversal.Balance)
+		return nil
+	})
+	if err != nil {
+		reversal = Transaction{}
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_ReverseTransaction() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(10000, 2))
+	payeeID, payeeUserID := sts.createFundedAccount(money.New(0, 2))
+
+	t, err := sts.store.TransferAmount(ctx, accID, payeeID, userID, money.New(6000, 2))
+	sts.Require().NoError(err)
+	transactionOf := func(accID, userID, typ string) (found Transaction) {
+		page, err := sts.store.GetTransactions(ctx, accID, userID, TransactionFilter{})
+		sts.Require().NoError(err)
+		for _, txn := range page.Transactions {
+			if txn.Type == typ && (txn.TransferRef == nil || *txn.TransferRef == t.Reference) {
+				found = txn
+			}
+		}
+		return
+	}
+	debit := transactionOf(accID, userID, "Debit")
+	credit := transactionOf(payeeID, payeeUserID, "Credit")
+
+	// a partial refund of the transfer takes the money back from the payee
+	partial := money.New(2500, 2)
+	reversal, err := sts.store.ReverseTransaction(ctx, Reversal{TransactionID: debit.ID, AccountID: accID, Amount: &partial, ReasonCode: "refund"})
+	sts.Require().NoError(err)
+	sts.Equal(TransactionDebitReversal, reversal.Type)
+	sts.Equal(debit.ID, *reversal.LinkedTransactionID)
+	sts.Equal("65.00", sts.balance(accID).String())
+	sts.Equal("35.00", sts.balance(payeeID).String())
+
+	payeeReversal := transactionOf(payeeID, payeeUserID, TransactionCreditReversal)
+	sts.Equal("25.00", payeeReversal.Amount.String())
+	sts.Equal(credit.ID, *payeeReversal.LinkedTransactionID)
+	sts.Equal("refund", *payeeReversal.ReasonCode)
+	sts.Equal("25.00", transactionOf(accID, userID, "Debit").ReversedAmount.String())
+
+	tooMuch := money.New(3600, 2)
+	_, err = sts.store.ReverseTransaction(ctx, Reversal{TransactionID: credit.ID, AccountID: payeeID, Amount: &tooMuch, ReasonCode: "refund"})
+	sts.True(errors.Is(err, ErrReversalExceedsTransaction))
+
+	// the rest is reversed from either side, but only once
+	_, err = sts.store.ReverseTransaction(ctx, Reversal{TransactionID: credit.ID, AccountID: payeeID, ReasonCode: "duplicate"})
+	sts.Require().NoError(err)
+	sts.Equal("100.00", sts.balance(accID).String())
+	sts.True(sts.balance(payeeID).IsZero())
+	_, err = sts.store.ReverseTransaction(ctx, Reversal{TransactionID: debit.ID, AccountID: accID, ReasonCode: "duplicate"})
+	sts.Equal(ErrTransactionReversed, err)
+
+	_, err = sts.store.ReverseTransaction(ctx, Reversal{TransactionID: reversal.ID, AccountID: accID, ReasonCode: "duplicate"})
+	sts.Equal(ErrTransactionNotReversible, err)
+	_, err = sts.store.ReverseTransaction(ctx, Reversal{TransactionID: debit.ID, AccountID: payeeID, ReasonCode: "duplicate"})
+	sts.Equal(ErrTransactionNotExist, err)
+
+	mismatches, err := sts.store.ReconcileLedger(ctx)
+	sts.Require().NoError(err)
+	sts.Empty(mismatches)
+}
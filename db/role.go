@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	roleHasPermissionQuery = `SELECT EXISTS (SELECT 1 FROM role_permissions WHERE role=$1 AND permission=$2)`
+	setUserRoleQuery       = `UPDATE users SET type=$2 WHERE id=$1`
+)
+
+// RoleHasPermission reports whether the role is granted the permission in the
+// role_permissions table.
+func (s *store) RoleHasPermission(ctx context.Context, role, permission string) (allowed bool, err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.GetContext(ctx, s.conn(ctx), &allowed, roleHasPermissionQuery, role, permission)
+	})
+	return
+}
+
+// SetUserRole gives the user the role and revokes the user's sessions, so
+// that tokens carrying the previous role stop working. It returns
+// ErrUserNotExist if there is no such user.
+func (s *store) SetUserRole(ctx context.Context, userID, role string) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		res, err := s.conn(ctx).ExecContext(ctx, setUserRoleQuery, userID, role)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrUserNotExist
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, revokeAllUserSessionsQuery, userID, time.Now())
+		return err
+	})
+	return
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+)
+
+func (sts *StoreTestSuite) Test_store_RoleHasPermission() {
+	ctx := context.Background()
+
+	tests := []struct {
+		role       string
+		permission string
+		want       bool
+	}{
+		{role: "customer", permission: "accounts:read:own", want: true},
+		{role: "customer", permission: "accounts:list", want: false},
+		{role: "teller", permission: "accounts:create", want: true},
+		{role: "teller", permission: "transactions:read:any", want: false},
+		{role: "auditor", permission: "audit:read", want: true},
+		{role: "auditor", permission: "accounts:create", want: false},
+		{role: "unknown", permission: "accounts:list", want: false},
+	}
+
+	for _, tt := range tests {
+		allowed, err := sts.store.RoleHasPermission(ctx, tt.role, tt.permission)
+		sts.Require().NoError(err)
+		sts.Equal(tt.want, allowed, "%s %s", tt.role, tt.permission)
+	}
+}
+
+func (sts *StoreTestSuite) Test_store_SetUserRole() {
+	ctx := context.Background()
+	u := sts.createCustomer()
+	sess := Session{ID: uuidgen.New(), UserID: u.ID, CreatedAt: time.Now()}
+	sts.Require().NoError(sts.store.CreateSession(ctx, sess, RefreshToken{TokenHash: uuidgen.New(), SessionID: sess.ID, ExpiresAt: time.Now().Add(time.Hour)}))
+
+	sts.Require().NoError(sts.store.SetUserRole(ctx, u.ID, "teller"))
+
+	got, err := sts.store.GetUserByID(ctx, u.ID)
+	sts.Require().NoError(err)
+	sts.Equal("teller", got.Type)
+	allowed, err := sts.store.RoleHasPermission(ctx, got.Type, "accounts:create")
+	sts.Require().NoError(err)
+	sts.True(allowed)
+
+	// sessions carrying the previous role are revoked
+	revoked, err := sts.store.IsTokenRevoked(ctx, uuidgen.New(), sess.ID)
+	sts.Require().NoError(err)
+	sts.True(revoked)
+
+	sts.Equal(ErrUserNotExist, sts.store.SetUserRole(ctx, "0", "teller"))
+}
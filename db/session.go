@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	createSessionQuery              = `INSERT INTO sessions(id, user_id, created_at) VALUES ($1, $2, $3)`
+	getSessionForUpdateQuery        = `SELECT s.*, u.type AS role FROM sessions s INNER JOIN users u ON u.id = s.user_id WHERE s.id=$1 FOR UPDATE OF s`
+	revokeSessionQuery              = `UPDATE sessions SET revoked_at=$2 WHERE id=$1 AND revoked_at IS NULL`
+	revokeUserSessionQuery          = `UPDATE sessions SET revoked_at=$3 WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL`
+	createRefreshTokenQuery         = `INSERT INTO refresh_tokens(token_hash, session_id, created_at, expires_at) VALUES ($1, $2, $3, $4)`
+	getRefreshTokenForUpdateQuery   = `SELECT * FROM refresh_tokens WHERE token_hash=$1 FOR UPDATE`
+	useRefreshTokenQuery            = `UPDATE refresh_tokens SET used_at=$2 WHERE token_hash=$1`
+	deleteExpiredRevokedTokensQuery = `DELETE FROM revoked_tokens WHERE expires_at < $1`
+	revokeAccessTokenQuery          = `INSERT INTO revoked_tokens(jti, expires_at) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	isTokenRevokedQuery             = `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti=$1) OR EXISTS (SELECT 1 FROM sessions WHERE id=$2 AND revoked_at IS NOT NULL)`
+)
+
+// Session is a login of a user. Every refresh token and access token issued
+// for the login belongs to it, so revoking the session logs them all out.
+type Session struct {
+	ID        string     `db:"id"`
+	UserID    string     `db:"user_id"`
+	Role      string     `db:"role"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+// RefreshToken is stored by the hash of the token, never the token itself.
+type RefreshToken struct {
+	TokenHash string     `db:"token_hash"`
+	SessionID string     `db:"session_id"`
+	CreatedAt time.Time  `db:"created_at"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+}
+
+// CreateSession saves a new session together with its first refresh token.
+func (s *store) CreateSession(ctx context.Context, sess Session, rt RefreshToken) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		_, err := s.conn(ctx).ExecContext(ctx, createSessionQuery, sess.ID, sess.UserID, sess.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, createRefreshTokenQuery, rt.TokenHash, sess.ID, rt.CreatedAt, rt.ExpiresAt)
+		return err
+	})
+	return
+}
+
+// RotateRefreshToken exchanges the refresh token with the given hash for next,
+// which joins the same session. A token can only be exchanged once: presenting
+// it again means it was stolen, so the session is revoked and
+// ErrRefreshTokenReused is returned.
+func (s *store) RotateRefreshToken(ctx context.Context, tokenHash string, next RefreshToken) (sess Session, err error) {
+	reused := false
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		var rt RefreshToken
+		err := sqlx.GetContext(ctx, s.conn(ctx), &rt, getRefreshTokenForUpdateQuery, tokenHash)
+		if err == sql.ErrNoRows {
+			return ErrRefreshTokenNotExist
+		}
+		if err != nil {
+			return err
+		}
+
+		err = sqlx.GetContext(ctx, s.conn(ctx), &sess, getSessionForUpdateQuery, rt.SessionID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if sess.RevokedAt != nil {
+			return ErrSessionRevoked
+		}
+		if rt.UsedAt != nil {
+			// commit the revocation, the error is returned after the transaction
+			reused = true
+			_, err = s.conn(ctx).ExecContext(ctx, revokeSessionQuery, sess.ID, now)
+			return err
+		}
+		if now.After(rt.ExpiresAt) {
+			return ErrRefreshTokenExpired
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, useRefreshTokenQuery, tokenHash, now)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, createRefreshTokenQuery, next.TokenHash, sess.ID, next.CreatedAt, next.ExpiresAt)
+		return err
+	})
+
+	if err == nil && reused {
+		err = ErrRefreshTokenReused
+	}
+	if err != nil {
+		sess = Session{}
+	}
+	return
+}
+
+// RevokeSession ends the session of the given user. Its refresh tokens can no
+// longer be exchanged and its access tokens are rejected.
+func (s *store) RevokeSession(ctx context.Context, sessionID, userID string) (err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		_, err := s.conn(ctx).ExecContext(ctx, revokeUserSessionQuery, sessionID, userID, time.Now())
+		return err
+	})
+	return
+}
+
+// RevokeAccessToken rejects the access token with the given jti until it
+// expires. Revocations of tokens that have expired are cleaned up.
+func (s *store) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		if _, err := s.conn(ctx).ExecContext(ctx, deleteExpiredRevokedTokensQuery, time.Now()); err != nil {
+			return err
+		}
+
+		_, err := s.conn(ctx).ExecContext(ctx, revokeAccessTokenQuery, jti, expiresAt)
+		return err
+	})
+	return
+}
+
+// IsTokenRevoked reports whether the access token with the given jti, or the
+// session it belongs to, has been revoked.
+func (s *store) IsTokenRevoked(ctx context.Context, jti, sessionID string) (revoked bool, err error) {
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.GetContext(ctx, s.conn(ctx), &revoked, isTokenRevokedQuery, jti, sessionID)
+	})
+	return
+}
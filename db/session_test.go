@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+func newTestRefreshToken(ttl time.Duration) RefreshToken {
+	return RefreshToken{TokenHash: uuidgen.New() + uuidgen.New()[:28], CreatedAt: time.Now(), ExpiresAt: time.Now().Add(ttl)}
+}
+
+func (sts *StoreTestSuite) Test_store_RotateRefreshToken_ReuseRevokesSession() {
+	ctx := context.Background()
+	_, userID := sts.createFundedAccount(money.New(0, 2))
+
+	sess := Session{ID: uuidgen.New(), UserID: userID, CreatedAt: time.Now()}
+	first := newTestRefreshToken(time.Hour)
+	sts.Require().NoError(sts.store.CreateSession(ctx, sess, first))
+
+	second := newTestRefreshToken(time.Hour)
+	got, err := sts.store.RotateRefreshToken(ctx, first.TokenHash, second)
+	sts.Require().NoError(err)
+	sts.Equal(sess.ID, got.ID)
+	sts.Equal("customer", got.Role)
+
+	// presenting the first token again revokes the session
+	_, err = sts.store.RotateRefreshToken(ctx, first.TokenHash, newTestRefreshToken(time.Hour))
+	sts.Equal(ErrRefreshTokenReused, err)
+
+	_, err = sts.store.RotateRefreshToken(ctx, second.TokenHash, newTestRefreshToken(time.Hour))
+	sts.Equal(ErrSessionRevoked, err)
+
+	revoked, err := sts.store.IsTokenRevoked(ctx, uuidgen.New(), sess.ID)
+	sts.Require().NoError(err)
+	sts.True(revoked)
+}
+
+func (sts *StoreTestSuite) Test_store_RotateRefreshToken_Expired() {
+	ctx := context.Background()
+	_, userID := sts.createFundedAccount(money.New(0, 2))
+
+	sess := Session{ID: uuidgen.New(), UserID: userID, CreatedAt: time.Now()}
+	expired := newTestRefreshToken(-time.Minute)
+	sts.Require().NoError(sts.store.CreateSession(ctx, sess, expired))
+
+	_, err := sts.store.RotateRefreshToken(ctx, expired.TokenHash, newTestRefreshToken(time.Hour))
+	sts.Equal(ErrRefreshTokenExpired, err)
+
+	_, err = sts.store.RotateRefreshToken(ctx, uuidgen.New(), newTestRefreshToken(time.Hour))
+	sts.Equal(ErrRefreshTokenNotExist, err)
+}
+
+func (sts *StoreTestSuite) Test_store_RevokeAccessToken() {
+	ctx := context.Background()
+	_, userID := sts.createFundedAccount(money.New(0, 2))
+
+	sess := Session{ID: uuidgen.New(), UserID: userID, CreatedAt: time.Now()}
+	sts.Require().NoError(sts.store.CreateSession(ctx, sess, newTestRefreshToken(time.Hour)))
+
+	jti := uuidgen.New()
+	revoked, err := sts.store.IsTokenRevoked(ctx, jti, sess.ID)
+	sts.Require().NoError(err)
+	sts.False(revoked)
+
+	sts.Require().NoError(sts.store.RevokeAccessToken(ctx, jti, time.Now().Add(time.Minute)))
+	revoked, err = sts.store.IsTokenRevoked(ctx, jti, sess.ID)
+	sts.Require().NoError(err)
+	sts.True(revoked)
+}
@@ -0,0 +1,217 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"example.com/banking/money"
+)
+
+// How often a standing order pays
+const (
+	FrequencyOnce    = "once"
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+// Standing order statuses
+const (
+	StandingOrderActive    = "active"
+	StandingOrderCompleted = "completed"
+	StandingOrderCancelled = "cancelled"
+)
+
+// Outcomes of a standing order execution
+const (
+	ExecutionPaid     = "paid"
+	ExecutionRetrying = "retrying"
+	ExecutionFailed   = "failed"
+)
+
+const (
+	createStandingOrderQuery = `INSERT INTO standing_orders(id, user_id, from_account_id, to_account_id, amount, frequency, interval,
+		start_date, end_date, max_occurrences, next_run_date, next_attempt_at, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $14) RETURNING *`
+	payeeExistsQuery              = `SELECT EXISTS (SELECT 1 FROM accounts WHERE id=$1 AND user_id IS NOT NULL)`
+	getAccountStandingOrdersQuery = `SELECT * FROM standing_orders WHERE from_account_id=$1 ORDER BY created_at`
+	getStandingOrderQuery         = `SELECT * FROM standing_orders WHERE id=$1 AND from_account_id=$2`
+	cancelStandingOrderQuery      = `UPDATE standing_orders SET status='cancelled', next_run_date=NULL, next_attempt_at=NULL, updated_at=$3
+		WHERE id=$1 AND from_account_id=$2 AND status='active'`
+	// orders from and to a closed account cannot be paid any more
+	cancelAccountStandingOrdersQuery = `UPDATE standing_orders SET status='cancelled', next_run_date=NULL, next_attempt_at=NULL, updated_at=$2
+		WHERE (from_account_id=$1 OR to_account_id=$1) AND status='active'`
+	// orders locked by another run are being paid by it
+	claimDueStandingOrderQuery = `SELECT * FROM standing_orders WHERE status='active' AND next_attempt_at <= $1 AND NOT (id = ANY($2::uuid[]))
+		ORDER BY next_attempt_at LIMIT 1 FOR UPDATE SKIP LOCKED`
+	updateStandingOrderQuery = `UPDATE standing_orders SET occurrences=$2, attempts=$3, next_run_date=$4, next_attempt_at=$5, status=$6, updated_at=$7
+		WHERE id=$1 AND status='active'`
+	getStandingOrderExecutionsQuery   = `SELECT * FROM standing_order_executions WHERE standing_order_id=$1 ORDER BY id`
+	createStandingOrderExecutionQuery = `INSERT INTO standing_order_executions(standing_order_id, run_date, attempt, outcome, transfer_ref, error, executed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+)
+
+// StandingOrder transfers Amount from one account to another, once on
+// StartDate or every Interval days, weeks, months or years from it. A
+// recurring order ends after EndDate or MaxOccurrences, whichever comes
+// first.
+type StandingOrder struct {
+	ID             string       `json:"id" db:"id"`
+	UserID         string       `json:"-" db:"user_id"`
+	FromAccountID  string       `json:"from_account_id" db:"from_account_id"`
+	ToAccountID    string       `json:"to_account_id" db:"to_account_id"`
+	Amount         money.Amount `json:"amount" db:"amount"`
+	Frequency      string       `json:"frequency" db:"frequency"`
+	Interval       int          `json:"interval" db:"interval"`
+	StartDate      time.Time    `json:"start_date" db:"start_date"`
+	EndDate        *time.Time   `json:"end_date,omitempty" db:"end_date"`
+	MaxOccurrences *int         `json:"max_occurrences,omitempty" db:"max_occurrences"`
+	// Occurrences that are done, paid or given up
+	Occurrences int `json:"occurrences" db:"occurrences"`
+	// Attempts that failed for the next occurrence
+	Attempts      int        `json:"attempts" db:"attempts"`
+	NextRunDate   *time.Time `json:"next_run_date,omitempty" db:"next_run_date"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	Status        string     `json:"status" db:"status"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// StandingOrderExecution records an attempt to pay the occurrence of a
+// standing order due on RunDate.
+type StandingOrderExecution struct {
+	ID              int64     `json:"id" db:"id"`
+	StandingOrderID string    `json:"standing_order_id" db:"standing_order_id"`
+	RunDate         time.Time `json:"run_date" db:"run_date"`
+	Attempt         int       `json:"attempt" db:"attempt"`
+	Outcome         string    `json:"outcome" db:"outcome"`
+	TransferRef     *string   `json:"transfer_ref,omitempty" db:"transfer_ref"`
+	Error           *string   `json:"error,omitempty" db:"error"`
+	ExecutedAt      time.Time `json:"executed_at" db:"executed_at"`
+}
+
+// CreateStandingOrder saves a standing order from an account of its user.
+func (s *store) CreateStandingOrder(ctx context.Context, o StandingOrder) (created StandingOrder, err error) {
+	if _, err = s.GetAccountDetails(ctx, o.FromAccountID, o.UserID); err != nil {
+		return
+	}
+
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		var exists bool
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &exists, payeeExistsQuery, o.ToAccountID); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrPayeeNotExist
+		}
+
+		return sqlx.GetContext(ctx, s.conn(ctx), &created, createStandingOrderQuery,
+			o.ID, o.UserID, o.FromAccountID, o.ToAccountID, o.Amount, o.Frequency, o.Interval,
+			o.StartDate, o.EndDate, o.MaxOccurrences, o.NextRunDate, o.NextAttemptAt, o.Status, o.CreatedAt)
+	})
+	return
+}
+
+// GetAccountStandingOrders lists the standing orders from the user's account.
+func (s *store) GetAccountStandingOrders(ctx context.Context, accID, userID string) (orders []StandingOrder, err error) {
+	if _, err = s.GetAccountDetails(ctx, accID, userID); err != nil {
+		return
+	}
+
+	orders = make([]StandingOrder, 0)
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		return sqlx.SelectContext(ctx, s.conn(ctx), &orders, getAccountStandingOrdersQuery, accID)
+	})
+	return
+}
+
+// GetStandingOrderExecutions lists the executions of a standing order from
+// the user's account, oldest first.
+func (s *store) GetStandingOrderExecutions(ctx context.Context, accID, orderID, userID string) (executions []StandingOrderExecution, err error) {
+	if _, err = s.GetAccountDetails(ctx, accID, userID); err != nil {
+		return
+	}
+
+	executions = make([]StandingOrderExecution, 0)
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		var o StandingOrder
+		err := sqlx.GetContext(ctx, s.conn(ctx), &o, getStandingOrderQuery, orderID, accID)
+		if err == sql.ErrNoRows {
+			return ErrStandingOrderNotExist
+		}
+		if err != nil {
+			return err
+		}
+
+		return sqlx.SelectContext(ctx, s.conn(ctx), &executions, getStandingOrderExecutionsQuery, orderID)
+	})
+	return
+}
+
+// CancelStandingOrder stops an active standing order from the user's account.
+func (s *store) CancelStandingOrder(ctx context.Context, accID, orderID, userID string) (err error) {
+	if _, err = s.GetAccountDetails(ctx, accID, userID); err != nil {
+		return
+	}
+
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		var o StandingOrder
+		err := sqlx.GetContext(ctx, s.conn(ctx), &o, getStandingOrderQuery+" FOR UPDATE", orderID, accID)
+		if err == sql.ErrNoRows {
+			return ErrStandingOrderNotExist
+		}
+		if err != nil {
+			return err
+		}
+		if o.Status != StandingOrderActive {
+			return ErrStandingOrderNotActive
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, cancelStandingOrderQuery, orderID, accID, time.Now())
+		return err
+	})
+	return
+}
+
+// ClaimDueStandingOrder locks the next active standing order to be tried at
+// now, other than those in skip. Orders locked by another run are skipped as
+// well, so it is called in a transaction, WithTx, which holds the claim until
+// the order is paid and its execution recorded.
+func (s *store) ClaimDueStandingOrder(ctx context.Context, now time.Time, skip []string) (o StandingOrder, err error) {
+	// a nil array would be NULL and match no order
+	if skip == nil {
+		skip = []string{}
+	}
+
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		err := sqlx.GetContext(ctx, s.conn(ctx), &o, claimDueStandingOrderQuery, now, pq.Array(skip))
+		if err == sql.ErrNoRows {
+			return ErrStandingOrderNotExist
+		}
+		return err
+	})
+	return
+}
+
+// RecordStandingOrderExecution saves the execution of a standing order and
+// where the order goes from there. An order cancelled in the meantime stays
+// cancelled.
+func (s *store) RecordStandingOrderExecution(ctx context.Context, o StandingOrder, e StandingOrderExecution) (err error) {
+	err = s.withTx(ctx, func(ctx context.Context) error {
+		_, err := s.conn(ctx).ExecContext(ctx, updateStandingOrderQuery,
+			o.ID, o.Occurrences, o.Attempts, o.NextRunDate, o.NextAttemptAt, o.Status, o.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.conn(ctx).ExecContext(ctx, createStandingOrderExecutionQuery,
+			e.StandingOrderID, e.RunDate, e.Attempt, e.Outcome, e.TransferRef, e.Error, e.ExecutedAt)
+		return err
+	})
+	return
+}
@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	uuidgen "github.com/pborman/uuid"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_StandingOrders() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(10000, 2))
+	payeeID, _ := sts.createFundedAccount(money.New(0, 2))
+	now := time.Now()
+	runDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	attemptAt := now.Add(-time.Minute)
+
+	o := StandingOrder{
+		ID: uuidgen.New(), UserID: userID, FromAccountID: accID, ToAccountID: payeeID, Amount: money.New(2500, 2),
+		Frequency: FrequencyMonthly, Interval: 1, StartDate: runDate, NextRunDate: &runDate, NextAttemptAt: &attemptAt,
+		Status: StandingOrderActive, CreatedAt: now, UpdatedAt: now,
+	}
+
+	// only the account's user can order payments from it
+	_, err := sts.store.CreateStandingOrder(ctx, StandingOrder{FromAccountID: accID, UserID: "0"})
+	sts.Equal(ErrAccountNotExist, err)
+	missing := o
+	missing.ToAccountID = uuidgen.New()
+	_, err = sts.store.CreateStandingOrder(ctx, missing)
+	sts.Equal(ErrPayeeNotExist, err)
+
+	created, err := sts.store.CreateStandingOrder(ctx, o)
+	sts.Require().NoError(err)
+	sts.Equal(o.ID, created.ID)
+
+	claimed, err := sts.store.ClaimDueStandingOrder(ctx, now, nil)
+	sts.Require().NoError(err)
+	sts.NotEmpty(claimed.ID)
+	sts.Contains(sts.dueOrderIDs(now), o.ID)
+
+	next := runDate.AddDate(0, 1, 0)
+	nextAttempt := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.Local)
+	o.Occurrences, o.NextRunDate, o.NextAttemptAt = 1, &next, &nextAttempt
+	ref := uuidgen.New()
+	sts.Require().NoError(sts.store.RecordStandingOrderExecution(ctx, o, StandingOrderExecution{
+		StandingOrderID: o.ID, RunDate: runDate, Attempt: 1, Outcome: ExecutionPaid, TransferRef: &ref, ExecutedAt: now,
+	}))
+
+	sts.NotContains(sts.dueOrderIDs(now), o.ID)
+
+	executions, err := sts.store.GetStandingOrderExecutions(ctx, accID, o.ID, userID)
+	sts.Require().NoError(err)
+	sts.Require().Len(executions, 1)
+	sts.Equal(ExecutionPaid, executions[0].Outcome)
+	sts.Equal(ref, *executions[0].TransferRef)
+
+	_, err = sts.store.GetStandingOrderExecutions(ctx, payeeID, o.ID, userID)
+	sts.Equal(ErrAccountNotExist, err)
+
+	sts.Require().NoError(sts.store.CancelStandingOrder(ctx, accID, o.ID, userID))
+	sts.Equal(ErrStandingOrderNotActive, sts.store.CancelStandingOrder(ctx, accID, o.ID, userID))
+	sts.Equal(ErrStandingOrderNotExist, sts.store.CancelStandingOrder(ctx, accID, uuidgen.New(), userID))
+
+	// closing an account cancels the orders paying into it
+	other := o
+	other.ID = uuidgen.New()
+	_, err = sts.store.CreateStandingOrder(ctx, other)
+	sts.Require().NoError(err)
+	_, err = sts.store.CloseAccount(ctx, payeeID, []string{AccountActive}, "", false, "customer request")
+	sts.Require().NoError(err)
+	orders, err := sts.store.GetAccountStandingOrders(ctx, accID, userID)
+	sts.Require().NoError(err)
+	sts.Require().Len(orders, 2)
+	for _, order := range orders {
+		sts.Equal(StandingOrderCancelled, order.Status)
+	}
+}
+
+func (sts *StoreTestSuite) Test_store_ClaimDueStandingOrder() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(10000, 2))
+	payeeID, _ := sts.createFundedAccount(money.New(0, 2))
+	now := time.Now()
+	runDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	attemptAt := now.Add(-time.Minute)
+	o := StandingOrder{
+		ID: uuidgen.New(), UserID: userID, FromAccountID: accID, ToAccountID: payeeID, Amount: money.New(2500, 2),
+		Frequency: FrequencyMonthly, Interval: 1, StartDate: runDate, NextRunDate: &runDate, NextAttemptAt: &attemptAt,
+		Status: StandingOrderActive, CreatedAt: now, UpdatedAt: now,
+	}
+	_, err := sts.store.CreateStandingOrder(ctx, o)
+	sts.Require().NoError(err)
+
+	// an order claimed by a run is skipped by the others until it is paid
+	err = sts.store.WithTx(ctx, func(ctx context.Context) error {
+		var skip []string
+		for {
+			claimed, err := sts.store.ClaimDueStandingOrder(ctx, now, skip)
+			if err != nil {
+				return err
+			}
+			if claimed.ID == o.ID {
+				break
+			}
+			skip = append(skip, claimed.ID)
+		}
+		sts.NotContains(sts.dueOrderIDs(now), o.ID)
+		return nil
+	})
+	sts.Require().NoError(err)
+
+	// the transfer is rolled back when its execution cannot be recorded
+	err = sts.store.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := sts.store.TransferAmount(ctx, accID, payeeID, userID, o.Amount); err != nil {
+			return err
+		}
+		e := StandingOrderExecution{StandingOrderID: o.ID, RunDate: runDate, Attempt: 1, Outcome: "unknown", ExecutedAt: now}
+		return sts.store.RecordStandingOrderExecution(ctx, o, e)
+	})
+	sts.Error(err)
+	sts.Equal(money.New(10000, 2), sts.balance(accID))
+	sts.Equal(money.New(0, 2), sts.balance(payeeID))
+
+	// a rejected transfer only undoes its own changes
+	err = sts.store.WithTx(ctx, func(ctx context.Context) error {
+		_, err := sts.store.TransferAmount(ctx, accID, payeeID, userID, money.New(1000000, 2))
+		sts.Equal(ErrInsufficientFunds, err)
+		_, err = sts.store.TransferAmount(ctx, accID, payeeID, userID, o.Amount)
+		return err
+	})
+	sts.Require().NoError(err)
+	sts.Equal(money.New(7500, 2), sts.balance(accID))
+}
+
+// dueOrderIDs claims every order due at now that is not locked by another
+// transaction.
+func (sts *StoreTestSuite) dueOrderIDs(now time.Time) (ids []string) {
+	ctx := context.Background()
+	for {
+		o, err := sts.store.ClaimDueStandingOrder(ctx, now, ids)
+		if err == ErrStandingOrderNotExist {
+			return
+		}
+		sts.Require().NoError(err)
+		ids = append(ids, o.ID)
+	}
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"example.com/banking/money"
+)
+
+// the balance of an account at a time is the sum of its postings before then
+const ledgerBalanceAtQuery = `SELECT COALESCE(SUM(postings.amount), 0) FROM postings
+	INNER JOIN journal_entries ON journal_entries.id=postings.journal_entry_id
+	WHERE postings.account_id=$1 AND journal_entries.created_at < $2`
+
+// creditTypes are the types of the transactions that add money to an account,
+// all others take money out of it
+var creditTypes = []string{"Credit", "Interest", TransactionDebitReversal}
+
+// Statement is what went in and out of an account over a period: the balance
+// at its start, every transaction in the order they were made and the balance
+// at its end. From is inclusive and To exclusive.
+type Statement struct {
+	Account        UserAccountDetails
+	From           time.Time
+	To             time.Time
+	OpeningBalance money.Amount
+	ClosingBalance money.Amount
+	TotalCredits   money.Amount
+	TotalDebits    money.Amount
+	Transactions   []Transaction
+}
+
+// IsCredit tells whether the transaction added money to its account.
+func (t Transaction) IsCredit() bool {
+	return contains(creditTypes, t.Type)
+}
+
+// GetStatement returns the statement of an account for the period from, to.
+// The opening and closing balances are read from the ledger.
+func (s *store) GetStatement(ctx context.Context, accID, userID string, from, to time.Time) (st Statement, err error) {
+	if st.Account, err = s.GetAccountDetails(ctx, accID, userID); err != nil {
+		return
+	}
+	st.From, st.To = from, to
+
+	query, args, err := transactionHistoryQuery(accID, TransactionFilter{From: from, To: to})
+	if err != nil {
+		return
+	}
+
+	st.Transactions = make([]Transaction, 0)
+	err = WithDefaultTimeout(ctx, func(ctx context.Context) error {
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &st.OpeningBalance, ledgerBalanceAtQuery, accID, from); err != nil {
+			return err
+		}
+		if err := sqlx.GetContext(ctx, s.conn(ctx), &st.ClosingBalance, ledgerBalanceAtQuery, accID, to); err != nil {
+			return err
+		}
+		return sqlx.SelectContext(ctx, s.conn(ctx), &st.Transactions, query, args...)
+	})
+	if err != nil {
+		return Statement{}, err
+	}
+
+	for _, t := range st.Transactions {
+		if t.IsCredit() {
+			st.TotalCredits = st.TotalCredits.Add(t.Amount)
+		} else {
+			st.TotalDebits = st.TotalDebits.Add(t.Amount)
+		}
+	}
+	return
+}
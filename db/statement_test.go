@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"example.com/banking/money"
+)
+
+func (sts *StoreTestSuite) Test_store_GetStatement() {
+	ctx := context.Background()
+	accID, userID := sts.createFundedAccount(money.New(10000, 2))
+	from := time.Now()
+
+	_, err := sts.store.DepositAmount(ctx, accID, userID, money.New(5000, 2))
+	sts.Require().NoError(err)
+	_, err = sts.store.WithdrawAmount(ctx, accID, userID, money.New(2000, 2))
+	sts.Require().NoError(err)
+	to := time.Now()
+
+	// the funding before the period is the opening balance
+	st, err := sts.store.GetStatement(ctx, accID, userID, from, to)
+	sts.Require().NoError(err)
+	sts.Equal(accID, st.Account.ID)
+	sts.Equal("100.00", st.OpeningBalance.String())
+	sts.Require().Len(st.Transactions, 2)
+	sts.Equal("Credit", st.Transactions[0].Type)
+	sts.Equal("Debit", st.Transactions[1].Type)
+	sts.Equal("50.00", st.TotalCredits.String())
+	sts.Equal("20.00", st.TotalDebits.String())
+	sts.Equal("130.00", st.ClosingBalance.String())
+	sts.Equal(0, st.OpeningBalance.Add(st.TotalCredits).Sub(st.TotalDebits).Cmp(st.ClosingBalance))
+
+	_, err = sts.store.GetStatement(ctx, accID, "0", from, to)
+	sts.Equal(ErrAccountNotExist, err)
+}
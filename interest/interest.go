@@ -0,0 +1,116 @@
+// Package interest calculates the interest accounts earn: the daily accrual
+// of an interest product and the dates the accrued interest is posted on.
+package interest
+
+import (
+	"time"
+
+	"example.com/banking/money"
+)
+
+// Day count conventions, the fraction of a year one day of interest is
+const (
+	DayCountAct365 = "ACT/365"
+	DayCountAct360 = "ACT/360"
+	DayCount30360  = "30/360"
+)
+
+// Compounding decides what interest accrues on: the balance only, or the
+// balance together with the interest accrued but not yet posted. Posted
+// interest always becomes part of the balance.
+const (
+	CompoundingNone  = "none"
+	CompoundingDaily = "daily"
+)
+
+// Posting frequencies, accrued interest is posted on the last day of each
+// period
+const (
+	PostingDaily     = "daily"
+	PostingMonthly   = "monthly"
+	PostingQuarterly = "quarterly"
+	PostingAnnually  = "annually"
+)
+
+// Kinds of products. Credit products pay interest on positive balances,
+// overdraft products charge interest on negative balances.
+const (
+	KindCredit    = "credit"
+	KindOverdraft = "overdraft"
+)
+
+// AccrualDigits is the precision daily accruals are kept in. Posted interest
+// is rounded to the currency.
+const AccrualDigits = 8
+
+// Product is an interest product. AnnualRate is a fraction, 0.035 is 3.5%.
+type Product struct {
+	Name             string
+	AnnualRate       money.Amount
+	DayCount         string
+	Compounding      string
+	PostingFrequency string
+	Kind             string
+}
+
+// DailyAccrual returns the interest earned on date by an account whose
+// balance at the end of the day is balance, with accrued interest not yet
+// posted. Credit products earn nothing on negative balances; overdraft
+// products charge interest on negative balances only, returned as a negative
+// amount.
+func (p Product) DailyAccrual(balance, accrued money.Amount, date time.Time) money.Amount {
+	base := balance
+	if p.Compounding == CompoundingDaily {
+		base = base.Add(accrued)
+	}
+	if p.Kind == KindOverdraft {
+		base = base.Neg()
+	}
+	if base.Sign() <= 0 {
+		return money.New(0, AccrualDigits)
+	}
+
+	days, basis := int64(1), int64(365)
+	switch p.DayCount {
+	case DayCountAct360:
+		basis = 360
+	case DayCount30360:
+		days, basis = days30360(date, date.AddDate(0, 0, 1)), 360
+	}
+
+	base = base.MulQuo(money.New(days, 0), 1, base.Scale())
+	amount := base.MulQuo(p.AnnualRate, basis, AccrualDigits)
+	if p.Kind == KindOverdraft {
+		amount = amount.Neg()
+	}
+	return amount
+}
+
+// IsPostingDate reports whether date is the last day of a posting period.
+func (p Product) IsPostingDate(date time.Time) bool {
+	next := date.AddDate(0, 0, 1)
+	switch p.PostingFrequency {
+	case PostingDaily:
+		return true
+	case PostingMonthly:
+		return next.Day() == 1
+	case PostingQuarterly:
+		return next.Day() == 1 && next.Month()%3 == 1
+	case PostingAnnually:
+		return next.Day() == 1 && next.Month() == time.January
+	}
+	return false
+}
+
+// days30360 counts the days between two dates as if every month had 30 days
+// (the US 30/360 bond basis).
+func days30360(start, end time.Time) int64 {
+	d1, d2 := start.Day(), end.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return int64(360*(end.Year()-start.Year()) + 30*(int(end.Month())-int(start.Month())) + d2 - d1)
+}
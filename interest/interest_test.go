@@ -0,0 +1,77 @@
+package interest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"example.com/banking/money"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestProduct_DailyAccrual(t *testing.T) {
+	balance := money.New(100000, 2) // 1000.00
+	accrued := money.New(50, 2)     // 0.50
+
+	tests := []struct {
+		name    string
+		product Product
+		date    string
+		want    string
+	}{
+		{name: "act/365", product: Product{AnnualRate: money.New(365, 4), DayCount: DayCountAct365}, date: "2022-10-10", want: "0.10000000"},
+		{name: "act/360", product: Product{AnnualRate: money.New(36, 3), DayCount: DayCountAct360}, date: "2022-10-10", want: "0.10000000"},
+		{name: "30/360 mid month", product: Product{AnnualRate: money.New(36, 3), DayCount: DayCount30360}, date: "2022-10-10", want: "0.10000000"},
+		{name: "30/360 31st is not counted", product: Product{AnnualRate: money.New(36, 3), DayCount: DayCount30360}, date: "2022-10-30", want: "0.00000000"},
+		{name: "30/360 end of february", product: Product{AnnualRate: money.New(36, 3), DayCount: DayCount30360}, date: "2022-02-28", want: "0.30000000"},
+		{name: "daily compounding", product: Product{AnnualRate: money.New(365, 4), DayCount: DayCountAct365, Compounding: CompoundingDaily}, date: "2022-10-10", want: "0.10005000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.product.DailyAccrual(balance, accrued, date(tt.date))
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+
+	// overdrawn accounts earn nothing
+	p := Product{AnnualRate: money.New(365, 4), DayCount: DayCountAct365}
+	assert.True(t, p.DailyAccrual(balance.Neg(), accrued, date("2022-10-10")).IsZero())
+
+	// and are charged overdraft interest, positive balances are not
+	overdraft := Product{AnnualRate: money.New(1825, 4), DayCount: DayCountAct365, Kind: KindOverdraft}
+	assert.Equal(t, "-0.50000000", overdraft.DailyAccrual(balance.Neg(), accrued, date("2022-10-10")).String())
+	assert.True(t, overdraft.DailyAccrual(balance, accrued, date("2022-10-10")).IsZero())
+}
+
+func TestProduct_IsPostingDate(t *testing.T) {
+	tests := []struct {
+		frequency string
+		date      string
+		want      bool
+	}{
+		{PostingDaily, "2022-10-10", true},
+		{PostingMonthly, "2022-10-30", false},
+		{PostingMonthly, "2022-10-31", true},
+		{PostingMonthly, "2024-02-29", true},
+		{PostingQuarterly, "2022-10-31", false},
+		{PostingQuarterly, "2022-09-30", true},
+		{PostingAnnually, "2022-09-30", false},
+		{PostingAnnually, "2022-12-31", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.frequency+" "+tt.date, func(t *testing.T) {
+			p := Product{PostingFrequency: tt.frequency}
+			assert.Equal(t, tt.want, p.IsPostingDate(date(tt.date)))
+		})
+	}
+}
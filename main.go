@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/urfave/cli"
 
 	"example.com/banking/app"
+	"example.com/banking/bank"
 	"example.com/banking/config"
 	"example.com/banking/db"
 	"example.com/banking/server"
@@ -42,6 +46,153 @@ func main() {
 				return err
 			},
 		},
+		{
+			Name:  "reconcile_ledger",
+			Usage: "verify account balances against the ledger postings",
+			Action: func(c *cli.Context) error {
+				mismatches, err := db.NewStorer(app.GetDB()).ReconcileLedger(context.Background())
+				if err != nil {
+					return err
+				}
+
+				for _, m := range mismatches {
+					fmt.Printf("account: %v, balance: %v, ledger balance: %v\n", m.AccountID, m.Balance, m.LedgerBalance)
+				}
+				if len(mismatches) > 0 {
+					return fmt.Errorf("%d account balances do not match the ledger", len(mismatches))
+				}
+
+				fmt.Println("all account balances match the ledger")
+				return nil
+			},
+		},
+		{
+			Name:      "accrue_interest",
+			Usage:     "accrue and post interest for a business date, or every date of a range",
+			ArgsUsage: "[yyyy-mm-dd] [yyyy-mm-dd]",
+			Action: func(c *cli.Context) error {
+				now := time.Now()
+				from := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+				if c.NArg() > 0 {
+					var err error
+					if from, err = time.Parse("2006-01-02", c.Args().Get(0)); err != nil {
+						return fmt.Errorf("error parsing business date: %v", c.Args().Get(0))
+					}
+				}
+				to := from
+				if c.NArg() > 1 {
+					var err error
+					if to, err = time.Parse("2006-01-02", c.Args().Get(1)); err != nil {
+						return fmt.Errorf("error parsing business date: %v", c.Args().Get(1))
+					}
+				}
+
+				service := bank.NewBankService(db.NewStorer(app.GetDB()), app.GetLogger(), nil)
+				for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+					run, err := service.AccrueInterest(context.Background(), date)
+					if err != nil {
+						return err
+					}
+					fmt.Printf("business date: %v, accounts: %v, accrued: %v, posted: %v\n", run.BusinessDate, run.Accounts, run.Accrued, run.Posted)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "charge_monthly_fees",
+			Usage:     "charge the monthly fees of a month, the previous month by default",
+			ArgsUsage: "[yyyy-mm]",
+			Action: func(c *cli.Context) error {
+				now := time.Now()
+				month := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+				if c.NArg() > 0 {
+					var err error
+					if month, err = time.Parse("2006-01", c.Args().Get(0)); err != nil {
+						return fmt.Errorf("error parsing month: %v", c.Args().Get(0))
+					}
+				}
+
+				service := bank.NewBankService(db.NewStorer(app.GetDB()), app.GetLogger(), nil)
+				run, err := service.ChargeMonthlyFees(context.Background(), month)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("month: %v, due: %v, charged: %v, unpaid: %v\n", run.Month, run.Due, run.Charged, run.Unpaid)
+				return nil
+			},
+		},
+		{
+			Name:      "write_statements",
+			Usage:     "write the statements of every account for a month into a directory, the previous month by default",
+			ArgsUsage: "directory [yyyy-mm]",
+			Action: func(c *cli.Context) error {
+				dir := c.Args().Get(0)
+				if dir == "" {
+					return fmt.Errorf("the directory to write the statements into must be given")
+				}
+				now := time.Now()
+				month := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+				if c.NArg() > 1 {
+					var err error
+					if month, err = time.Parse("2006-01", c.Args().Get(1)); err != nil {
+						return fmt.Errorf("error parsing month: %v", c.Args().Get(1))
+					}
+				}
+
+				service := bank.NewBankService(db.NewStorer(app.GetDB()), app.GetLogger(), nil)
+				run, err := service.WriteStatements(context.Background(), month, dir)
+				fmt.Printf("month: %v, accounts: %v, written: %v, failed: %v\n", run.Month, run.Accounts, run.Written, run.Failed)
+				return err
+			},
+		},
+		{
+			Name:      "provision_mfa",
+			Usage:     "provision the mfa enrolment of a user, such as the first accountant",
+			ArgsUsage: "email",
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("the email of the user must be given")
+				}
+
+				storer := db.NewStorer(app.GetDB())
+				user, err := storer.GetUserByEmail(context.Background(), c.Args().Get(0))
+				if err != nil {
+					return err
+				}
+
+				service := bank.NewBankService(storer, app.GetLogger(), nil)
+				enrolment, err := service.ProvisionMFA(context.Background(), nil, user.ID)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("user: %v, secret: %v, provisioning uri: %v\n", user.Email, enrolment.Secret, enrolment.ProvisioningURI)
+				return nil
+			},
+		},
+		{
+			Name:      "assign_role",
+			Usage:     "assign a role to a user, such as the first branch manager",
+			ArgsUsage: "email role",
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 2 {
+					return fmt.Errorf("the email of the user and the role must be given")
+				}
+
+				storer := db.NewStorer(app.GetDB())
+				user, err := storer.GetUserByEmail(context.Background(), c.Args().Get(0))
+				if err != nil {
+					return err
+				}
+
+				service := bank.NewBankService(storer, app.GetLogger(), nil)
+				err = service.SetUserRole(context.Background(), nil, user.ID, bank.SetUserRoleRequest{Role: c.Args().Get(1)})
+				if err != nil {
+					return err
+				}
+				fmt.Printf("user: %v, role: %v\n", user.Email, c.Args().Get(1))
+				return nil
+			},
+		},
 		{
 			Name:  "rollback",
 			Usage: "rollback db migrations",
@@ -0,0 +1 @@
+/* Rounding the money columns cannot be undone */
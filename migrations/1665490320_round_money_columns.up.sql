@@ -0,0 +1,3 @@
+/* Amounts used to be written from float32 values, round them back to paise */
+UPDATE accounts SET balance = ROUND(balance, 2);
+UPDATE transactions SET amount = ROUND(amount, 2), balance = ROUND(balance, 2);
@@ -0,0 +1,3 @@
+DROP INDEX transactions_transfer_ref_idx;
+
+ALTER TABLE transactions DROP COLUMN transfer_ref;
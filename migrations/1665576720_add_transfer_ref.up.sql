@@ -0,0 +1,3 @@
+ALTER TABLE transactions ADD COLUMN transfer_ref UUID;
+
+CREATE INDEX transactions_transfer_ref_idx ON transactions (transfer_ref);
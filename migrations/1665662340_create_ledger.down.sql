@@ -0,0 +1,9 @@
+ALTER TABLE transactions DROP COLUMN journal_entry_id;
+
+DROP TRIGGER postings_balanced ON postings;
+DROP FUNCTION check_journal_entry_balanced();
+
+DROP TABLE postings;
+DROP TABLE journal_entries;
+
+DELETE FROM accounts WHERE id IN ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002');
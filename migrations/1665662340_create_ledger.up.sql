@@ -0,0 +1,57 @@
+/* Bank internal accounts are accounts without an owning user */
+INSERT INTO accounts(id, balance, user_id) VALUES ('00000000-0000-0000-0000-000000000001', 0.0, NULL);
+INSERT INTO accounts(id, balance, user_id) VALUES ('00000000-0000-0000-0000-000000000002', 0.0, NULL);
+
+CREATE TABLE journal_entries(
+    id          UUID PRIMARY KEY,
+    description VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMP NOT NULL
+);
+
+/* Posting amounts are signed: a positive amount increases the account balance.
+   The postings of a journal entry always add up to zero. */
+CREATE TABLE postings(
+    id               UUID PRIMARY KEY,
+    journal_entry_id UUID NOT NULL REFERENCES journal_entries (id),
+    account_id       UUID NOT NULL REFERENCES accounts (id),
+    amount           DECIMAL NOT NULL
+);
+
+CREATE INDEX postings_journal_entry_id_idx ON postings (journal_entry_id);
+CREATE INDEX postings_account_id_idx ON postings (account_id);
+
+CREATE FUNCTION check_journal_entry_balanced() RETURNS TRIGGER AS $$
+BEGIN
+    IF (SELECT SUM(amount) FROM postings WHERE journal_entry_id = NEW.journal_entry_id) <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', NEW.journal_entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER postings_balanced
+    AFTER INSERT OR UPDATE ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE PROCEDURE check_journal_entry_balanced();
+
+ALTER TABLE transactions ADD COLUMN journal_entry_id UUID REFERENCES journal_entries (id);
+
+/* Open the ledger with the existing balances, against the suspense account */
+CREATE TEMPORARY TABLE opening_entries AS
+    SELECT gen_random_uuid() AS entry_id, id AS account_id, balance
+    FROM accounts
+    WHERE user_id IS NOT NULL AND balance <> 0;
+
+INSERT INTO journal_entries(id, description, created_at)
+    SELECT entry_id, 'Opening balance', now() FROM opening_entries;
+
+INSERT INTO postings(id, journal_entry_id, account_id, amount)
+    SELECT gen_random_uuid(), entry_id, account_id, balance FROM opening_entries;
+
+INSERT INTO postings(id, journal_entry_id, account_id, amount)
+    SELECT gen_random_uuid(), entry_id, '00000000-0000-0000-0000-000000000002', -balance FROM opening_entries;
+
+UPDATE accounts SET balance = -(SELECT COALESCE(SUM(balance), 0) FROM opening_entries)
+    WHERE id = '00000000-0000-0000-0000-000000000002';
+
+DROP TABLE opening_entries;
@@ -0,0 +1,11 @@
+CREATE TABLE idempotency_keys(
+    user_id       VARCHAR(20) NOT NULL,
+    key           VARCHAR(255) NOT NULL,
+    fingerprint   CHAR(64) NOT NULL,
+    status_code   INTEGER,
+    response_body BYTEA,
+    created_at    TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idempotency_keys_created_at_idx ON idempotency_keys (created_at);
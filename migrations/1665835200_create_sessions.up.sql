@@ -0,0 +1,28 @@
+CREATE TABLE sessions(
+    id         UUID PRIMARY KEY,
+    user_id    INTEGER NOT NULL REFERENCES users (id),
+    created_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
+
+/* Only the SHA-256 hash of a refresh token is stored. used_at is set when the
+   token is exchanged; presenting it again revokes the whole session. */
+CREATE TABLE refresh_tokens(
+    token_hash CHAR(64) PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES sessions (id),
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP
+);
+
+CREATE INDEX refresh_tokens_session_id_idx ON refresh_tokens (session_id);
+
+/* Access tokens revoked before they expire, by their jti claim */
+CREATE TABLE revoked_tokens(
+    jti        UUID PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX revoked_tokens_expires_at_idx ON revoked_tokens (expires_at);
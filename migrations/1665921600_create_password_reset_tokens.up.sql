@@ -0,0 +1,10 @@
+/* Only the SHA-256 hash of a reset token is stored. A token is used once. */
+CREATE TABLE password_reset_tokens(
+    token_hash CHAR(64) PRIMARY KEY,
+    user_id    INTEGER NOT NULL REFERENCES users (id),
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP
+);
+
+CREATE INDEX password_reset_tokens_user_id_idx ON password_reset_tokens (user_id);
@@ -0,0 +1,20 @@
+/* Consecutive failed logins per email and per client IP */
+CREATE TABLE login_attempts(
+    kind            VARCHAR(5) NOT NULL,
+    key             VARCHAR(255) NOT NULL,
+    failures        INTEGER NOT NULL,
+    last_failure_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (kind, key)
+);
+
+CREATE TABLE audit_log(
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    action     VARCHAR(50) NOT NULL,
+    actor_id   INTEGER REFERENCES users (id),
+    subject    VARCHAR(255) NOT NULL DEFAULT '',
+    ip         VARCHAR(45) NOT NULL DEFAULT '',
+    details    TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
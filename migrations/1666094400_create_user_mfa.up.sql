@@ -0,0 +1,17 @@
+/* TOTP enrolment of a user. The enrolment is pending until confirmed_at is
+   set by the first valid code. last_used_counter stops a code being used twice. */
+CREATE TABLE user_mfa(
+    user_id           INTEGER PRIMARY KEY REFERENCES users (id),
+    secret            VARCHAR(64) NOT NULL,
+    created_at        TIMESTAMP NOT NULL,
+    confirmed_at      TIMESTAMP,
+    last_used_counter BIGINT NOT NULL DEFAULT 0
+);
+
+/* Only the SHA-256 hash of a recovery code is stored. Each code is used once. */
+CREATE TABLE mfa_recovery_codes(
+    code_hash CHAR(64) NOT NULL,
+    user_id   INTEGER NOT NULL REFERENCES users (id),
+    used_at   TIMESTAMP,
+    PRIMARY KEY (user_id, code_hash)
+);
@@ -0,0 +1,64 @@
+CREATE TABLE roles(
+    name        VARCHAR(20) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE permissions(
+    name        VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE role_permissions(
+    role       VARCHAR(20) NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL REFERENCES permissions (name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles(name, description) VALUES
+    ('customer', 'Bank customer, works with their own accounts'),
+    ('teller', 'Front desk staff, opens accounts'),
+    ('accountant', 'Back office staff'),
+    ('branch_manager', 'Manages the staff of a branch'),
+    ('auditor', 'Read-only access for audits');
+
+INSERT INTO permissions(name, description) VALUES
+    ('accounts:create', 'Open an account for a customer'),
+    ('accounts:list', 'List all accounts'),
+    ('accounts:read:own', 'View details of own account'),
+    ('accounts:deposit:own', 'Deposit into own account'),
+    ('accounts:withdraw:own', 'Withdraw from own account'),
+    ('accounts:transfer:own', 'Transfer from own account'),
+    ('transactions:read:own', 'View transactions of own account'),
+    ('transactions:read:any', 'View transactions of any account'),
+    ('users:unlock', 'Unlock a user locked out by failed logins'),
+    ('audit:read', 'View the audit log'),
+    ('profile:password:change', 'Change own password');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('customer', 'accounts:read:own'),
+    ('customer', 'accounts:deposit:own'),
+    ('customer', 'accounts:withdraw:own'),
+    ('customer', 'accounts:transfer:own'),
+    ('customer', 'transactions:read:own'),
+    ('customer', 'profile:password:change'),
+    ('teller', 'accounts:create'),
+    ('teller', 'accounts:list'),
+    ('teller', 'profile:password:change'),
+    ('accountant', 'accounts:create'),
+    ('accountant', 'accounts:list'),
+    ('accountant', 'transactions:read:any'),
+    ('accountant', 'users:unlock'),
+    ('accountant', 'profile:password:change'),
+    ('branch_manager', 'accounts:create'),
+    ('branch_manager', 'accounts:list'),
+    ('branch_manager', 'transactions:read:any'),
+    ('branch_manager', 'users:unlock'),
+    ('branch_manager', 'audit:read'),
+    ('branch_manager', 'profile:password:change'),
+    ('auditor', 'accounts:list'),
+    ('auditor', 'transactions:read:any'),
+    ('auditor', 'audit:read'),
+    ('auditor', 'profile:password:change');
+
+ALTER TABLE users ALTER COLUMN type TYPE VARCHAR(20);
+ALTER TABLE users ADD CONSTRAINT users_type_fkey FOREIGN KEY (type) REFERENCES roles (name);
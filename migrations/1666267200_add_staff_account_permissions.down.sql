@@ -0,0 +1,3 @@
+DROP INDEX audit_log_subject_idx;
+
+DELETE FROM permissions WHERE name IN ('accounts:read:any', 'accounts:deposit:any', 'accounts:withdraw:any', 'accounts:transfer:any');
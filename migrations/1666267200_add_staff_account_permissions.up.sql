@@ -0,0 +1,21 @@
+INSERT INTO permissions(name, description) VALUES
+    ('accounts:read:any', 'View details of any account, with a reason'),
+    ('accounts:deposit:any', 'Deposit into any account, with a reason'),
+    ('accounts:withdraw:any', 'Withdraw from any account, with a reason'),
+    ('accounts:transfer:any', 'Transfer from any account, with a reason');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('teller', 'accounts:read:any'),
+    ('teller', 'accounts:deposit:any'),
+    ('teller', 'accounts:withdraw:any'),
+    ('accountant', 'accounts:read:any'),
+    ('accountant', 'accounts:deposit:any'),
+    ('accountant', 'accounts:withdraw:any'),
+    ('accountant', 'accounts:transfer:any'),
+    ('branch_manager', 'accounts:read:any'),
+    ('branch_manager', 'accounts:deposit:any'),
+    ('branch_manager', 'accounts:withdraw:any'),
+    ('branch_manager', 'accounts:transfer:any'),
+    ('auditor', 'accounts:read:any');
+
+CREATE INDEX audit_log_subject_idx ON audit_log (subject);
@@ -0,0 +1,4 @@
+DROP INDEX accounts_user_id_idx;
+ALTER TABLE accounts DROP COLUMN type;
+
+DROP TABLE account_types;
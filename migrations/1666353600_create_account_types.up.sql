@@ -0,0 +1,18 @@
+/* Account products, the type of an account decides what can be done with it */
+CREATE TABLE account_types(
+    name                VARCHAR(20) PRIMARY KEY,
+    description         VARCHAR(255) NOT NULL,
+    allows_withdrawal   BOOLEAN NOT NULL,
+    allows_transfer_out BOOLEAN NOT NULL
+);
+
+INSERT INTO account_types(name, description, allows_withdrawal, allows_transfer_out) VALUES
+    ('savings', 'Savings account', TRUE, TRUE),
+    ('current', 'Current account for everyday payments', TRUE, TRUE),
+    ('fixed_deposit', 'Fixed deposit, money is locked in until the account is closed', FALSE, FALSE),
+    ('internal', 'Bank internal account', TRUE, TRUE);
+
+ALTER TABLE accounts ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'savings' REFERENCES account_types (name);
+UPDATE accounts SET type = 'internal' WHERE user_id IS NULL;
+
+CREATE INDEX accounts_user_id_idx ON accounts (user_id);
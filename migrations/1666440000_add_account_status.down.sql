@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE name IN ('accounts:status:change', 'accounts:close');
+
+ALTER TABLE accounts DROP COLUMN status_reason;
+ALTER TABLE accounts DROP COLUMN status;
@@ -0,0 +1,13 @@
+ALTER TABLE accounts ADD COLUMN status VARCHAR(15) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('active', 'frozen', 'debit_blocked', 'dormant', 'closed'));
+ALTER TABLE accounts ADD COLUMN status_reason VARCHAR(255);
+
+INSERT INTO permissions(name, description) VALUES
+    ('accounts:status:change', 'Freeze, block, mark dormant or reactivate an account'),
+    ('accounts:close', 'Close an account and pay out its balance');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('accountant', 'accounts:status:change'),
+    ('accountant', 'accounts:close'),
+    ('branch_manager', 'accounts:status:change'),
+    ('branch_manager', 'accounts:close');
@@ -0,0 +1,9 @@
+DROP TABLE interest_accruals;
+ALTER TABLE account_types DROP COLUMN interest_product;
+DROP TABLE interest_products;
+
+DELETE FROM accounts WHERE id = '00000000-0000-0000-0000-000000000003'
+    AND NOT EXISTS (SELECT 1 FROM postings WHERE account_id = '00000000-0000-0000-0000-000000000003');
+
+/* transactions.type is left at VARCHAR(20): Interest, Overdraft Interest and
+   reversal rows do not fit the narrower column and are part of the history */
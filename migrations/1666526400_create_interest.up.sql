@@ -0,0 +1,35 @@
+/* Interest postings need a longer transaction type than Credit or Debit */
+ALTER TABLE transactions ALTER COLUMN type TYPE VARCHAR(20);
+
+/* Interest paid to customers is an expense of the bank */
+INSERT INTO accounts(id, balance, user_id, type) VALUES ('00000000-0000-0000-0000-000000000003', 0.0, NULL, 'internal');
+
+/* annual_rate is a fraction, 0.035 is 3.5% */
+CREATE TABLE interest_products(
+    name              VARCHAR(50) PRIMARY KEY,
+    annual_rate       DECIMAL NOT NULL CHECK (annual_rate >= 0),
+    day_count         VARCHAR(7) NOT NULL CHECK (day_count IN ('ACT/365', 'ACT/360', '30/360')),
+    compounding       VARCHAR(10) NOT NULL CHECK (compounding IN ('none', 'daily')),
+    posting_frequency VARCHAR(10) NOT NULL CHECK (posting_frequency IN ('daily', 'monthly', 'quarterly', 'annually'))
+);
+
+INSERT INTO interest_products(name, annual_rate, day_count, compounding, posting_frequency) VALUES
+    ('savings', 0.035, 'ACT/365', 'none', 'monthly'),
+    ('fixed_deposit', 0.065, 'ACT/365', 'daily', 'quarterly');
+
+ALTER TABLE account_types ADD COLUMN interest_product VARCHAR(50) REFERENCES interest_products (name);
+UPDATE account_types SET interest_product = 'savings' WHERE name = 'savings';
+UPDATE account_types SET interest_product = 'fixed_deposit' WHERE name = 'fixed_deposit';
+
+/* One accrual per account and business date, until posted with a transaction */
+CREATE TABLE interest_accruals(
+    account_id     UUID NOT NULL REFERENCES accounts (id),
+    business_date  DATE NOT NULL,
+    product        VARCHAR(50) NOT NULL REFERENCES interest_products (name),
+    balance        DECIMAL NOT NULL,
+    amount         DECIMAL NOT NULL,
+    transaction_id UUID REFERENCES transactions (id),
+    PRIMARY KEY (account_id, business_date)
+);
+
+CREATE INDEX interest_accruals_unposted_idx ON interest_accruals (account_id) WHERE transaction_id IS NULL;
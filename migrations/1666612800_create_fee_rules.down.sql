@@ -0,0 +1,9 @@
+DELETE FROM permissions WHERE name = 'fees:manage';
+
+DROP TABLE fee_charges;
+DROP TABLE fee_rules;
+
+ALTER TABLE transactions DROP COLUMN linked_transaction_id;
+
+DELETE FROM accounts WHERE id = '00000000-0000-0000-0000-000000000004'
+    AND NOT EXISTS (SELECT 1 FROM postings WHERE account_id = '00000000-0000-0000-0000-000000000004');
@@ -0,0 +1,39 @@
+/* Fees charged to customers are income of the bank */
+INSERT INTO accounts(id, balance, user_id, type) VALUES ('00000000-0000-0000-0000-000000000004', 0.0, NULL, 'internal');
+
+/* A fee links to the transaction that triggered it */
+ALTER TABLE transactions ADD COLUMN linked_transaction_id UUID REFERENCES transactions (id);
+
+/*
+ * withdrawal and deposit fees are charged with the transaction, after the
+ * first free_per_month transactions of the month. monthly fees are charged
+ * once a month. When min_balance is set, the fee is only charged while the
+ * balance is below it. A rule without account_type applies to every type.
+ */
+CREATE TABLE fee_rules(
+    id             SERIAL PRIMARY KEY,
+    name           VARCHAR(100) NOT NULL,
+    trigger        VARCHAR(10) NOT NULL CHECK (trigger IN ('withdrawal', 'deposit', 'monthly')),
+    account_type   VARCHAR(50) REFERENCES account_types (name),
+    amount         DECIMAL NOT NULL CHECK (amount > 0),
+    free_per_month INTEGER NOT NULL DEFAULT 0 CHECK (free_per_month >= 0),
+    min_balance    DECIMAL,
+    active         BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at     TIMESTAMP NOT NULL,
+    updated_at     TIMESTAMP NOT NULL
+);
+
+/* Monthly fees are charged once per rule, account and month */
+CREATE TABLE fee_charges(
+    rule_id        INTEGER NOT NULL REFERENCES fee_rules (id),
+    account_id     UUID NOT NULL REFERENCES accounts (id),
+    period         DATE NOT NULL,
+    transaction_id UUID NOT NULL REFERENCES transactions (id),
+    PRIMARY KEY (rule_id, account_id, period)
+);
+
+INSERT INTO permissions(name, description) VALUES
+    ('fees:manage', 'Create and change the fee rules');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('accountant', 'fees:manage');
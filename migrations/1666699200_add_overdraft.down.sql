@@ -0,0 +1,11 @@
+DELETE FROM permissions WHERE name = 'accounts:overdraft:set';
+
+ALTER TABLE account_types DROP COLUMN overdraft_product;
+DELETE FROM interest_products WHERE name = 'overdraft'
+    AND NOT EXISTS (SELECT 1 FROM interest_accruals WHERE product = 'overdraft');
+ALTER TABLE interest_products DROP COLUMN kind;
+
+ALTER TABLE accounts DROP COLUMN overdraft_limit;
+
+DELETE FROM accounts WHERE id = '00000000-0000-0000-0000-000000000005'
+    AND NOT EXISTS (SELECT 1 FROM postings WHERE account_id = '00000000-0000-0000-0000-000000000005');
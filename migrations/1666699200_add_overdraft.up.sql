@@ -0,0 +1,20 @@
+/* Overdraft interest charged to customers is income of the bank */
+INSERT INTO accounts(id, balance, user_id, type) VALUES ('00000000-0000-0000-0000-000000000005', 0.0, NULL, 'internal');
+
+/* The balance of an account may go down to -overdraft_limit */
+ALTER TABLE accounts ADD COLUMN overdraft_limit DECIMAL NOT NULL DEFAULT 0 CHECK (overdraft_limit >= 0);
+
+/* credit products pay interest on positive balances, overdraft products charge it on negative balances */
+ALTER TABLE interest_products ADD COLUMN kind VARCHAR(10) NOT NULL DEFAULT 'credit' CHECK (kind IN ('credit', 'overdraft'));
+
+INSERT INTO interest_products(name, annual_rate, day_count, compounding, posting_frequency, kind) VALUES
+    ('overdraft', 0.18, 'ACT/365', 'none', 'monthly', 'overdraft');
+
+ALTER TABLE account_types ADD COLUMN overdraft_product VARCHAR(50) REFERENCES interest_products (name);
+UPDATE account_types SET overdraft_product = 'overdraft' WHERE name IN ('savings', 'current');
+
+INSERT INTO permissions(name, description) VALUES
+    ('accounts:overdraft:set', 'Set the overdraft limit of an account');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('accountant', 'accounts:overdraft:set');
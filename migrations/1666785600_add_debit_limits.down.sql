@@ -0,0 +1,11 @@
+DELETE FROM permissions WHERE name = 'accounts:limits:set';
+
+DROP INDEX transactions_account_id_created_at_idx;
+
+ALTER TABLE accounts DROP COLUMN monthly_debit_limit;
+ALTER TABLE accounts DROP COLUMN daily_debit_limit;
+ALTER TABLE accounts DROP COLUMN single_debit_limit;
+
+ALTER TABLE account_types DROP COLUMN monthly_debit_limit;
+ALTER TABLE account_types DROP COLUMN daily_debit_limit;
+ALTER TABLE account_types DROP COLUMN single_debit_limit;
@@ -0,0 +1,25 @@
+/*
+ * Limits on the money taken out of an account: per transaction, and the sum of
+ * the debits of a day or a month. NULL means no limit. Limits set on an
+ * account replace the ones of its type.
+ */
+ALTER TABLE account_types ADD COLUMN single_debit_limit DECIMAL CHECK (single_debit_limit > 0);
+ALTER TABLE account_types ADD COLUMN daily_debit_limit DECIMAL CHECK (daily_debit_limit > 0);
+ALTER TABLE account_types ADD COLUMN monthly_debit_limit DECIMAL CHECK (monthly_debit_limit > 0);
+
+UPDATE account_types SET single_debit_limit = 10000, daily_debit_limit = 25000, monthly_debit_limit = 200000 WHERE name = 'savings';
+UPDATE account_types SET single_debit_limit = 50000, daily_debit_limit = 100000, monthly_debit_limit = 1000000 WHERE name = 'current';
+
+ALTER TABLE accounts ADD COLUMN single_debit_limit DECIMAL CHECK (single_debit_limit > 0);
+ALTER TABLE accounts ADD COLUMN daily_debit_limit DECIMAL CHECK (daily_debit_limit > 0);
+ALTER TABLE accounts ADD COLUMN monthly_debit_limit DECIMAL CHECK (monthly_debit_limit > 0);
+
+/* Debits of the day and month are summed on every withdrawal and transfer */
+CREATE INDEX transactions_account_id_created_at_idx ON transactions (account_id, created_at);
+
+INSERT INTO permissions(name, description) VALUES
+    ('accounts:limits:set', 'Set the debit limits of an account');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('accountant', 'accounts:limits:set'),
+    ('branch_manager', 'accounts:limits:set');
@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE name = 'standing_orders:own';
+
+DROP TABLE standing_order_executions;
+DROP TABLE standing_orders;
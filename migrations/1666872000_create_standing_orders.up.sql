@@ -0,0 +1,43 @@
+CREATE TABLE standing_orders(
+    id              UUID PRIMARY KEY,
+    user_id         INTEGER NOT NULL REFERENCES users (id),
+    from_account_id UUID NOT NULL REFERENCES accounts (id),
+    to_account_id   UUID NOT NULL REFERENCES accounts (id),
+    amount          DECIMAL NOT NULL CHECK (amount > 0),
+    frequency       VARCHAR(10) NOT NULL CHECK (frequency IN ('once', 'daily', 'weekly', 'monthly', 'yearly')),
+    interval        INTEGER NOT NULL DEFAULT 1 CHECK (interval > 0),
+    start_date      DATE NOT NULL,
+    end_date        DATE,
+    max_occurrences INTEGER CHECK (max_occurrences > 0),
+    -- occurrences that are done, paid or given up
+    occurrences     INTEGER NOT NULL DEFAULT 0,
+    -- failed attempts of the next occurrence
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_run_date   DATE,
+    next_attempt_at TIMESTAMP,
+    status          VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'cancelled')),
+    created_at      TIMESTAMP NOT NULL,
+    updated_at      TIMESTAMP NOT NULL
+);
+
+CREATE INDEX standing_orders_next_attempt_at_idx ON standing_orders (next_attempt_at) WHERE status = 'active';
+CREATE INDEX standing_orders_from_account_id_idx ON standing_orders (from_account_id);
+
+CREATE TABLE standing_order_executions(
+    id                SERIAL PRIMARY KEY,
+    standing_order_id UUID NOT NULL REFERENCES standing_orders (id),
+    run_date          DATE NOT NULL,
+    attempt           INTEGER NOT NULL,
+    outcome           VARCHAR(10) NOT NULL CHECK (outcome IN ('paid', 'retrying', 'failed')),
+    transfer_ref      UUID,
+    error             VARCHAR(255),
+    executed_at       TIMESTAMP NOT NULL
+);
+
+CREATE INDEX standing_order_executions_standing_order_id_idx ON standing_order_executions (standing_order_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('standing_orders:own', 'Create and cancel standing orders of own account');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('customer', 'standing_orders:own');
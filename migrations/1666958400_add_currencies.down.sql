@@ -0,0 +1,13 @@
+DELETE FROM permissions WHERE name = 'fx:manage';
+
+DROP TABLE fx_rates;
+
+ALTER TABLE transactions DROP COLUMN counter_currency;
+ALTER TABLE transactions DROP COLUMN counter_amount;
+ALTER TABLE transactions DROP COLUMN fx_rate;
+ALTER TABLE transactions DROP COLUMN currency;
+
+DELETE FROM accounts WHERE id::text LIKE '00000000-0000-0000-0001-%'
+    AND NOT EXISTS (SELECT 1 FROM postings WHERE account_id = accounts.id);
+
+ALTER TABLE accounts DROP COLUMN currency;
@@ -0,0 +1,46 @@
+/* Every account holds money in one ISO 4217 currency */
+ALTER TABLE accounts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'INR';
+
+/*
+ * The position of the bank in every currency. Exchanges between accounts in
+ * different currencies go through them, the IDs end in the numeric ISO 4217
+ * code.
+ */
+INSERT INTO accounts(id, balance, user_id, type, currency) VALUES
+    ('00000000-0000-0000-0001-000000000356', 0.0, NULL, 'internal', 'INR'),
+    ('00000000-0000-0000-0001-000000000840', 0.0, NULL, 'internal', 'USD'),
+    ('00000000-0000-0000-0001-000000000978', 0.0, NULL, 'internal', 'EUR'),
+    ('00000000-0000-0000-0001-000000000826', 0.0, NULL, 'internal', 'GBP'),
+    ('00000000-0000-0000-0001-000000000392', 0.0, NULL, 'internal', 'JPY'),
+    ('00000000-0000-0000-0001-000000000414', 0.0, NULL, 'internal', 'KWD');
+
+/* The legs of an exchange record the rate and the amount on the other side */
+ALTER TABLE transactions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'INR';
+ALTER TABLE transactions ADD COLUMN fx_rate DECIMAL;
+ALTER TABLE transactions ADD COLUMN counter_amount DECIMAL;
+ALTER TABLE transactions ADD COLUMN counter_currency CHAR(3);
+
+/*
+ * One unit of base_currency is worth rate units of quote_currency, at the
+ * middle of the market. Customers get the rate less the spread, a fraction of
+ * it, in either direction. A rate applies from effective_from until the next
+ * rate of the pair.
+ */
+CREATE TABLE fx_rates(
+    id             SERIAL PRIMARY KEY,
+    base_currency  CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL CHECK (quote_currency <> base_currency),
+    rate           DECIMAL NOT NULL CHECK (rate > 0),
+    spread         DECIMAL NOT NULL DEFAULT 0 CHECK (spread >= 0 AND spread < 1),
+    effective_from TIMESTAMP NOT NULL,
+    created_by     INTEGER REFERENCES users (id),
+    created_at     TIMESTAMP NOT NULL
+);
+
+CREATE INDEX fx_rates_pair_idx ON fx_rates (base_currency, quote_currency, effective_from);
+
+INSERT INTO permissions(name, description) VALUES
+    ('fx:manage', 'Manage the exchange rates between currencies');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('accountant', 'fx:manage');
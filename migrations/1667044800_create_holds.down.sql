@@ -0,0 +1,8 @@
+DELETE FROM permissions WHERE name = 'holds:manage';
+
+DELETE FROM accounts WHERE id = '00000000-0000-0000-0000-000000000006'
+    AND NOT EXISTS (SELECT 1 FROM postings WHERE account_id = accounts.id);
+
+ALTER TABLE accounts DROP COLUMN held;
+
+DROP TABLE holds;
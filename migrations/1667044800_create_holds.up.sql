@@ -0,0 +1,34 @@
+/*
+ * Money reserved on an account for a payment that settles later, e.g. a card
+ * authorisation. An active hold is taken out of the available balance until
+ * it is captured, released or expires.
+ */
+CREATE TABLE holds(
+    id              UUID PRIMARY KEY,
+    account_id      UUID NOT NULL REFERENCES accounts (id),
+    amount          DECIMAL NOT NULL CHECK (amount > 0),
+    reference       VARCHAR(64) NOT NULL,
+    status          VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'captured', 'released', 'expired')),
+    captured_amount DECIMAL CHECK (captured_amount > 0),
+    transaction_id  UUID REFERENCES transactions (id),
+    expires_at      TIMESTAMP NOT NULL,
+    created_by      INTEGER REFERENCES users (id),
+    created_at      TIMESTAMP NOT NULL,
+    updated_at      TIMESTAMP NOT NULL,
+    UNIQUE (account_id, reference)
+);
+
+CREATE INDEX holds_expires_at_idx ON holds (expires_at) WHERE status = 'active';
+
+/* The sum of the active holds, kept with the balance it is taken out of */
+ALTER TABLE accounts ADD COLUMN held DECIMAL NOT NULL DEFAULT 0 CHECK (held >= 0);
+
+/* Captured holds are paid out of the bank through the settlement account */
+INSERT INTO accounts(id, balance, user_id, type) VALUES ('00000000-0000-0000-0000-000000000006', 0.0, NULL, 'internal');
+
+INSERT INTO permissions(name, description) VALUES
+    ('holds:manage', 'Place, capture and release holds on any account');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('accountant', 'holds:manage'),
+    ('branch_manager', 'holds:manage');
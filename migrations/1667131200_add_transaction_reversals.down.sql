@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE name = 'transactions:reverse';
+
+DROP INDEX transactions_linked_transaction_id_idx;
+
+ALTER TABLE transactions DROP COLUMN reason_code;
@@ -0,0 +1,13 @@
+/*
+ * A reversal is posted as a transaction linked to the one it reverses, in
+ * whole or in part, with the code of the reason it was reversed for.
+ */
+ALTER TABLE transactions ADD COLUMN reason_code VARCHAR(30);
+
+CREATE INDEX transactions_linked_transaction_id_idx ON transactions (linked_transaction_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('transactions:reverse', 'Reverse or refund a transaction of any account');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('accountant', 'transactions:reverse');
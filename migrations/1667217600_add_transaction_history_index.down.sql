@@ -0,0 +1,2 @@
+CREATE INDEX transactions_account_id_created_at_idx ON transactions (account_id, created_at);
+DROP INDEX transactions_account_id_created_at_id_idx;
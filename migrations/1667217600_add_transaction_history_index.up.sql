@@ -0,0 +1,7 @@
+/*
+ * The history of an account is paged through in the order of creation time
+ * and ID of its transactions. The index also serves the sums of the debit
+ * limits, so it replaces the one on account and creation time.
+ */
+CREATE INDEX transactions_account_id_created_at_id_idx ON transactions (account_id, created_at, id);
+DROP INDEX transactions_account_id_created_at_idx;
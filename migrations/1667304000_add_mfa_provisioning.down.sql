@@ -0,0 +1,2 @@
+DELETE FROM role_permissions WHERE permission = 'users:mfa:provision';
+DELETE FROM permissions WHERE name = 'users:mfa:provision';
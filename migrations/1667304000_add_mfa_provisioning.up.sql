@@ -0,0 +1,10 @@
+/* Enrolments of users whose role requires MFA are provisioned by an
+   administrator. Pending enrolments handed out on login are dropped, anyone
+   with the password could have started them. */
+DELETE FROM user_mfa WHERE confirmed_at IS NULL;
+
+INSERT INTO permissions(name, description) VALUES
+    ('users:mfa:provision', 'Provision the MFA enrolment of a user');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('branch_manager', 'users:mfa:provision');
@@ -0,0 +1,2 @@
+DELETE FROM role_permissions WHERE permission IN ('session:logout', 'profile:mfa', 'fx:read');
+DELETE FROM permissions WHERE name IN ('session:logout', 'profile:mfa', 'fx:read');
@@ -0,0 +1,21 @@
+INSERT INTO permissions(name, description) VALUES
+    ('session:logout', 'Log out of own session'),
+    ('profile:mfa', 'Enrol own user in MFA'),
+    ('fx:read', 'View exchange rates');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('customer', 'session:logout'),
+    ('customer', 'profile:mfa'),
+    ('customer', 'fx:read'),
+    ('teller', 'session:logout'),
+    ('teller', 'profile:mfa'),
+    ('teller', 'fx:read'),
+    ('accountant', 'session:logout'),
+    ('accountant', 'profile:mfa'),
+    ('accountant', 'fx:read'),
+    ('branch_manager', 'session:logout'),
+    ('branch_manager', 'profile:mfa'),
+    ('branch_manager', 'fx:read'),
+    ('auditor', 'session:logout'),
+    ('auditor', 'profile:mfa'),
+    ('auditor', 'fx:read');
@@ -0,0 +1,12 @@
+/* The postings of the accounts in other currencies go back to the INR
+   accounts, whose ID ends in the third group of theirs */
+UPDATE postings SET account_id = ('00000000-0000-0000-0000-00000000000' || substr(account_id::text, 18, 1))::uuid
+    WHERE account_id::text LIKE '00000000-0000-000_-0001-%' AND account_id::text NOT LIKE '00000000-0000-0000-0001-%';
+
+UPDATE accounts SET balance = (SELECT COALESCE(SUM(amount), 0) FROM postings WHERE account_id = accounts.id)
+    WHERE id IN ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003',
+        '00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000005',
+        '00000000-0000-0000-0000-000000000006');
+
+DELETE FROM accounts
+    WHERE id::text LIKE '00000000-0000-000_-0001-%' AND id::text NOT LIKE '00000000-0000-0000-0001-%';
@@ -0,0 +1,48 @@
+/*
+ * The cash, interest expense, fee income, interest income and settlement
+ * accounts hold INR. Each gets a counterpart in every other currency, its ID
+ * that of the position account of the currency with the last digit of the INR
+ * account in the third group.
+ */
+CREATE TEMPORARY TABLE internal_accounts(inr_id, currency, id) AS VALUES
+    ('00000000-0000-0000-0000-000000000001'::uuid, 'USD', '00000000-0000-0001-0001-000000000840'::uuid),
+    ('00000000-0000-0000-0000-000000000001'::uuid, 'EUR', '00000000-0000-0001-0001-000000000978'::uuid),
+    ('00000000-0000-0000-0000-000000000001'::uuid, 'GBP', '00000000-0000-0001-0001-000000000826'::uuid),
+    ('00000000-0000-0000-0000-000000000001'::uuid, 'JPY', '00000000-0000-0001-0001-000000000392'::uuid),
+    ('00000000-0000-0000-0000-000000000001'::uuid, 'KWD', '00000000-0000-0001-0001-000000000414'::uuid),
+    ('00000000-0000-0000-0000-000000000003'::uuid, 'USD', '00000000-0000-0003-0001-000000000840'::uuid),
+    ('00000000-0000-0000-0000-000000000003'::uuid, 'EUR', '00000000-0000-0003-0001-000000000978'::uuid),
+    ('00000000-0000-0000-0000-000000000003'::uuid, 'GBP', '00000000-0000-0003-0001-000000000826'::uuid),
+    ('00000000-0000-0000-0000-000000000003'::uuid, 'JPY', '00000000-0000-0003-0001-000000000392'::uuid),
+    ('00000000-0000-0000-0000-000000000003'::uuid, 'KWD', '00000000-0000-0003-0001-000000000414'::uuid),
+    ('00000000-0000-0000-0000-000000000004'::uuid, 'USD', '00000000-0000-0004-0001-000000000840'::uuid),
+    ('00000000-0000-0000-0000-000000000004'::uuid, 'EUR', '00000000-0000-0004-0001-000000000978'::uuid),
+    ('00000000-0000-0000-0000-000000000004'::uuid, 'GBP', '00000000-0000-0004-0001-000000000826'::uuid),
+    ('00000000-0000-0000-0000-000000000004'::uuid, 'JPY', '00000000-0000-0004-0001-000000000392'::uuid),
+    ('00000000-0000-0000-0000-000000000004'::uuid, 'KWD', '00000000-0000-0004-0001-000000000414'::uuid),
+    ('00000000-0000-0000-0000-000000000005'::uuid, 'USD', '00000000-0000-0005-0001-000000000840'::uuid),
+    ('00000000-0000-0000-0000-000000000005'::uuid, 'EUR', '00000000-0000-0005-0001-000000000978'::uuid),
+    ('00000000-0000-0000-0000-000000000005'::uuid, 'GBP', '00000000-0000-0005-0001-000000000826'::uuid),
+    ('00000000-0000-0000-0000-000000000005'::uuid, 'JPY', '00000000-0000-0005-0001-000000000392'::uuid),
+    ('00000000-0000-0000-0000-000000000005'::uuid, 'KWD', '00000000-0000-0005-0001-000000000414'::uuid),
+    ('00000000-0000-0000-0000-000000000006'::uuid, 'USD', '00000000-0000-0006-0001-000000000840'::uuid),
+    ('00000000-0000-0000-0000-000000000006'::uuid, 'EUR', '00000000-0000-0006-0001-000000000978'::uuid),
+    ('00000000-0000-0000-0000-000000000006'::uuid, 'GBP', '00000000-0000-0006-0001-000000000826'::uuid),
+    ('00000000-0000-0000-0000-000000000006'::uuid, 'JPY', '00000000-0000-0006-0001-000000000392'::uuid),
+    ('00000000-0000-0000-0000-000000000006'::uuid, 'KWD', '00000000-0000-0006-0001-000000000414'::uuid);
+
+INSERT INTO accounts(id, balance, user_id, type, currency)
+    SELECT id, 0.0, NULL, 'internal', currency FROM internal_accounts;
+
+/* Movements of accounts in other currencies booked against the INR accounts
+   move to the account of their currency, and the balances follow */
+UPDATE postings SET account_id = internal_accounts.id
+    FROM postings AS customer_postings, accounts AS customers, internal_accounts
+    WHERE customer_postings.journal_entry_id = postings.journal_entry_id
+    AND customers.id = customer_postings.account_id AND customers.user_id IS NOT NULL
+    AND internal_accounts.inr_id = postings.account_id AND internal_accounts.currency = customers.currency;
+
+UPDATE accounts SET balance = (SELECT COALESCE(SUM(amount), 0) FROM postings WHERE account_id = accounts.id)
+    WHERE id IN (SELECT inr_id FROM internal_accounts UNION SELECT id FROM internal_accounts);
+
+DROP TABLE internal_accounts;
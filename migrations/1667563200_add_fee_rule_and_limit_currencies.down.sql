@@ -0,0 +1,2 @@
+ALTER TABLE account_types DROP COLUMN limit_currency;
+ALTER TABLE fee_rules DROP COLUMN currency;
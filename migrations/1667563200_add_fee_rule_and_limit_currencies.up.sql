@@ -0,0 +1,7 @@
+/* The amount and min_balance of a fee rule are in its currency, the rule
+   applies to the accounts of that currency only */
+ALTER TABLE fee_rules ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'INR';
+
+/* The debit limits of an account type are in its limit_currency, they cap
+   the accounts of that currency only */
+ALTER TABLE account_types ADD COLUMN limit_currency CHAR(3) NOT NULL DEFAULT 'INR';
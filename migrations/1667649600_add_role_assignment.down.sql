@@ -0,0 +1,2 @@
+DELETE FROM role_permissions WHERE permission = 'users:role:assign';
+DELETE FROM permissions WHERE name = 'users:role:assign';
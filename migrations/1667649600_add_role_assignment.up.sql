@@ -0,0 +1,5 @@
+INSERT INTO permissions(name, description) VALUES
+    ('users:role:assign', 'Assign a role to a user');
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('branch_manager', 'users:role:assign');
@@ -0,0 +1,64 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// Currency is an ISO 4217 currency together with the number of fraction
+// digits (minor units) it allows.
+type Currency struct {
+	Code   string
+	Digits int32
+}
+
+var (
+	INR = Currency{Code: "INR", Digits: 2}
+	USD = Currency{Code: "USD", Digits: 2}
+	EUR = Currency{Code: "EUR", Digits: 2}
+	GBP = Currency{Code: "GBP", Digits: 2}
+	JPY = Currency{Code: "JPY", Digits: 0}
+	KWD = Currency{Code: "KWD", Digits: 3}
+
+	// DefaultCurrency is the currency of accounts opened without one.
+	DefaultCurrency = INR
+)
+
+var currencies = map[string]Currency{
+	INR.Code: INR,
+	USD.Code: USD,
+	EUR.Code: EUR,
+	GBP.Code: GBP,
+	JPY.Code: JPY,
+	KWD.Code: KWD,
+}
+
+// LookupCurrency returns the currency for an ISO 4217 code.
+func LookupCurrency(code string) (c Currency, err error) {
+	c, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		err = fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return
+}
+
+// Check returns ErrTooManyFractionDigits if a is written with more fraction
+// digits than the currency allows.
+func (c Currency) Check(a Amount) error {
+	if a.scale > c.Digits {
+		return fmt.Errorf("%w: %v allows %d, got %v", ErrTooManyFractionDigits, c.Code, c.Digits, a)
+	}
+	return nil
+}
+
+// Normalize expresses a with exactly the currency's number of fraction digits.
+func (c Currency) Normalize(a Amount) (Amount, error) {
+	return a.rescale(c.Digits)
+}
+
+func (c Currency) String() string {
+	return c.Code
+}
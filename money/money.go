@@ -0,0 +1,342 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const maxScale = 18
+
+var (
+	ErrInvalidAmount         = errors.New("invalid amount")
+	ErrOutOfRange            = errors.New("amount out of range")
+	ErrTooManyFractionDigits = errors.New("too many fraction digits for currency")
+)
+
+// Amount is an exact decimal value, stored as an integer coefficient and the
+// number of digits after the decimal point: coef * 10^-scale.
+// The zero value is 0.
+type Amount struct {
+	coef  int64
+	scale int32
+}
+
+// New returns the amount coef * 10^-scale.
+func New(coef int64, scale int32) Amount {
+	return Amount{coef: coef, scale: scale}
+}
+
+// ParseAmount parses a plain decimal string such as "100", "-0.5" or "12.30".
+// Exponents, signs other than a leading '-' and surrounding spaces are rejected.
+func ParseAmount(s string) (a Amount, err error) {
+	digits := s
+	neg := false
+	if strings.HasPrefix(digits, "-") {
+		neg = true
+		digits = digits[1:]
+	}
+
+	intPart, fracPart := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, fracPart = digits[:i], digits[i+1:]
+		if fracPart == "" {
+			return a, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+	}
+	if intPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return a, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if len(fracPart) > maxScale {
+		return a, fmt.Errorf("%w: %q", ErrOutOfRange, s)
+	}
+
+	coef, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return a, fmt.Errorf("%w: %q", ErrOutOfRange, s)
+	}
+	if neg {
+		coef = -coef
+	}
+
+	return Amount{coef: coef, scale: int32(len(fracPart))}, nil
+}
+
+// Parse parses s as an amount of the given currency. Values with more fraction
+// digits than the currency allows are rejected; the result is expressed with
+// exactly the currency's number of fraction digits.
+func Parse(s string, cur Currency) (a Amount, err error) {
+	a, err = ParseAmount(s)
+	if err != nil {
+		return
+	}
+
+	if err = cur.Check(a); err != nil {
+		return Amount{}, err
+	}
+
+	return a.rescale(cur.Digits)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Scale returns the number of digits after the decimal point.
+func (a Amount) Scale() int32 {
+	return a.scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of a.
+func (a Amount) Sign() int {
+	switch {
+	case a.coef < 0:
+		return -1
+	case a.coef > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (a Amount) IsZero() bool {
+	return a.coef == 0
+}
+
+func (a Amount) Neg() Amount {
+	return Amount{coef: -a.coef, scale: a.scale}
+}
+
+// Add returns a + b. It panics if the result does not fit, which for money
+// amounts means the data is already corrupt.
+func (a Amount) Add(b Amount) Amount {
+	x, y := align(a, b)
+	sum := x.coef + y.coef
+	if (sum > x.coef) != (y.coef > 0) {
+		panic(ErrOutOfRange)
+	}
+	return Amount{coef: sum, scale: x.scale}
+}
+
+// Sub returns a - b.
+func (a Amount) Sub(b Amount) Amount {
+	return a.Add(b.Neg())
+}
+
+// MulQuo returns a * b / d rounded to the given number of fraction digits,
+// halves away from zero. It is meant for rates, e.g. interest on a balance.
+// It panics if d is zero or the result does not fit.
+func (a Amount) MulQuo(b Amount, d int64, digits int32) Amount {
+	if d == 0 {
+		panic("money: division by zero")
+	}
+
+	// a.coef * b.coef * 10^digits / (10^(a.scale+b.scale) * d)
+	num := new(big.Int).Mul(big.NewInt(a.coef), big.NewInt(b.coef))
+	num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil))
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.scale+b.scale)), nil)
+	den.Mul(den, big.NewInt(d))
+	return quo(num, den, digits)
+}
+
+// Quo returns a / b rounded to the given number of fraction digits, halves
+// away from zero. It is meant for rates, e.g. the inverse of an exchange
+// rate. It panics if b is zero or the result does not fit.
+func (a Amount) Quo(b Amount, digits int32) Amount {
+	if b.coef == 0 {
+		panic("money: division by zero")
+	}
+
+	// a.coef * 10^(b.scale+digits) / (b.coef * 10^a.scale)
+	num := new(big.Int).Mul(big.NewInt(a.coef), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(b.scale+digits)), nil))
+	den := new(big.Int).Mul(big.NewInt(b.coef), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.scale)), nil))
+	return quo(num, den, digits)
+}
+
+// quo divides num by den, rounding halves away from zero, into an amount
+// with the given scale.
+func quo(num, den *big.Int, digits int32) Amount {
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(den) >= 0 {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if !q.IsInt64() {
+		panic(ErrOutOfRange)
+	}
+	return Amount{coef: q.Int64(), scale: digits}
+}
+
+// Cmp returns -1 if a < b, 0 if a == b and +1 if a > b.
+func (a Amount) Cmp(b Amount) int {
+	x, y := align(a, b)
+	switch {
+	case x.coef < y.coef:
+		return -1
+	case x.coef > y.coef:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Equal reports whether a and b represent the same value, regardless of scale.
+func (a Amount) Equal(b Amount) bool {
+	return a.Cmp(b) == 0
+}
+
+// Round rounds a to the given number of fraction digits, halves away from zero.
+func (a Amount) Round(digits int32) Amount {
+	if digits >= a.scale {
+		r, err := a.rescale(digits)
+		if err != nil {
+			panic(err)
+		}
+		return r
+	}
+
+	div := pow10(a.scale - digits)
+	q, r := a.coef/div, a.coef%div
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= div {
+		if a.coef < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Amount{coef: q, scale: digits}
+}
+
+// rescale expresses a with the given scale without losing precision.
+func (a Amount) rescale(scale int32) (Amount, error) {
+	if scale == a.scale {
+		return a, nil
+	}
+	if scale < a.scale {
+		div := pow10(a.scale - scale)
+		if a.coef%div != 0 {
+			return a, ErrTooManyFractionDigits
+		}
+		return Amount{coef: a.coef / div, scale: scale}, nil
+	}
+	if scale > maxScale {
+		return a, ErrOutOfRange
+	}
+
+	mul := pow10(scale - a.scale)
+	if a.coef != 0 && (a.coef > math.MaxInt64/mul || a.coef < math.MinInt64/mul) {
+		return a, ErrOutOfRange
+	}
+	return Amount{coef: a.coef * mul, scale: scale}, nil
+}
+
+func align(a, b Amount) (Amount, Amount) {
+	var err error
+	switch {
+	case a.scale < b.scale:
+		a, err = a.rescale(b.scale)
+	case b.scale < a.scale:
+		b, err = b.rescale(a.scale)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return a, b
+}
+
+func pow10(n int32) int64 {
+	p := int64(1)
+	for i := int32(0); i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+func (a Amount) String() string {
+	s := strconv.FormatInt(a.coef, 10)
+	if a.scale == 0 {
+		return s
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if pad := int(a.scale) + 1 - len(s); pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	s = s[:len(s)-int(a.scale)] + "." + s[len(s)-int(a.scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// MarshalJSON encodes the amount as a JSON string so that clients never see
+// it as a binary floating point number.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON accepts either a JSON string or a JSON number holding a plain
+// decimal value.
+func (a *Amount) UnmarshalJSON(data []byte) (err error) {
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err = json.Unmarshal(data, &s); err != nil {
+			return
+		}
+	}
+
+	parsed, err := ParseAmount(s)
+	if err != nil {
+		return
+	}
+
+	*a = parsed
+	return
+}
+
+// Scan implements sql.Scanner for DECIMAL and integer columns.
+func (a *Amount) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case nil:
+		*a = Amount{}
+	case int64:
+		*a = Amount{coef: v}
+	case []byte:
+		return a.Scan(string(v))
+	case string:
+		var parsed Amount
+		if parsed, err = ParseAmount(v); err == nil {
+			*a = parsed
+		}
+	default:
+		err = fmt.Errorf("money: cannot scan %T into Amount", src)
+	}
+	return
+}
+
+// Value implements driver.Valuer. The amount is sent as its exact decimal text.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
@@ -0,0 +1,188 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		cur     Currency
+		want    string
+		wantErr error
+	}{
+		{name: "whole", input: "100", cur: INR, want: "100.00"},
+		{name: "one digit", input: "100.5", cur: INR, want: "100.50"},
+		{name: "two digits", input: "0.07", cur: INR, want: "0.07"},
+		{name: "negative", input: "-12.30", cur: INR, want: "-12.30"},
+		{name: "three digits", input: "1.005", cur: INR, wantErr: ErrTooManyFractionDigits},
+		{name: "trailing zero beyond digits", input: "1.500", cur: INR, wantErr: ErrTooManyFractionDigits},
+		{name: "zero digit currency", input: "150", cur: JPY, want: "150"},
+		{name: "zero digit currency with fraction", input: "150.0", cur: JPY, wantErr: ErrTooManyFractionDigits},
+		{name: "three digit currency", input: "1.005", cur: KWD, want: "1.005"},
+		{name: "exponent", input: "1e2", cur: INR, wantErr: ErrInvalidAmount},
+		{name: "plus sign", input: "+1", cur: INR, wantErr: ErrInvalidAmount},
+		{name: "missing integer part", input: ".5", cur: INR, wantErr: ErrInvalidAmount},
+		{name: "missing fraction", input: "5.", cur: INR, wantErr: ErrInvalidAmount},
+		{name: "empty", input: "", cur: INR, wantErr: ErrInvalidAmount},
+		{name: "spaces", input: " 5", cur: INR, wantErr: ErrInvalidAmount},
+		{name: "overflow", input: "99999999999999999999", cur: INR, wantErr: ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, tt.cur)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a, err := ParseAmount("0.10")
+	require.NoError(t, err)
+	b, err := ParseAmount("0.2")
+	require.NoError(t, err)
+
+	sum := a.Add(b)
+	assert.Equal(t, "0.30", sum.String())
+	assert.True(t, sum.Equal(New(3, 1)))
+
+	assert.Equal(t, "-0.10", a.Sub(b).String())
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 1, b.Cmp(a))
+	assert.Equal(t, 0, New(100, 2).Cmp(New(1, 0)))
+	assert.Equal(t, -1, a.Sub(b).Sign())
+
+	// Adding 0.10 ten times must be exactly 1, which float32 cannot do
+	total := Amount{}
+	for i := 0; i < 10; i++ {
+		total = total.Add(a)
+	}
+	assert.True(t, total.Equal(New(1, 0)))
+}
+
+func TestAmountRound(t *testing.T) {
+	tests := []struct {
+		input  string
+		digits int32
+		want   string
+	}{
+		{"1.005", 2, "1.01"},
+		{"1.004", 2, "1.00"},
+		{"-1.005", 2, "-1.01"},
+		{"2.5", 0, "3"},
+		{"7", 2, "7.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			a, err := ParseAmount(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, a.Round(tt.digits).String())
+		})
+	}
+}
+
+func TestAmountMulQuo(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		d      int64
+		digits int32
+		want   string
+	}{
+		{"1000.00", "0.035", 365, 6, "0.095890"},
+		{"1000.00", "0.035", 1, 2, "35.00"},
+		{"0.05", "0.5", 1, 2, "0.03"},
+		{"-0.05", "0.5", 1, 2, "-0.03"},
+		{"10", "1", -4, 1, "-2.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"*"+tt.b, func(t *testing.T) {
+			a, err := ParseAmount(tt.a)
+			require.NoError(t, err)
+			b, err := ParseAmount(tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, a.MulQuo(b, tt.d, tt.digits).String())
+		})
+	}
+}
+
+func TestAmountQuo(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		digits int32
+		want   string
+	}{
+		{"1", "82.50", 8, "0.01212121"},
+		{"100.00", "0.5", 2, "200.00"},
+		{"2", "3", 2, "0.67"},
+		{"-2", "3", 2, "-0.67"},
+		{"1", "-8", 2, "-0.13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			a, err := ParseAmount(tt.a)
+			require.NoError(t, err)
+			b, err := ParseAmount(tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, a.Quo(b, tt.digits).String())
+		})
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	var req struct {
+		Amount Amount `json:"amount"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": 10.25}`), &req))
+	assert.Equal(t, "10.25", req.Amount.String())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": "0.10"}`), &req))
+	assert.Equal(t, "0.10", req.Amount.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": 1e3}`), &req))
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": true}`), &req))
+
+	out, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount": "0.10"}`, string(out))
+}
+
+func TestAmountScan(t *testing.T) {
+	var a Amount
+
+	require.NoError(t, a.Scan([]byte("1234.56")))
+	assert.Equal(t, "1234.56", a.String())
+
+	require.NoError(t, a.Scan(int64(42)))
+	assert.Equal(t, "42", a.String())
+
+	assert.Error(t, a.Scan(float64(1.1)))
+
+	v, err := New(-5, 2).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "-0.05", v)
+}
+
+func TestLookupCurrency(t *testing.T) {
+	c, err := LookupCurrency("usd")
+	require.NoError(t, err)
+	assert.Equal(t, USD, c)
+
+	_, err = LookupCurrency("XXX")
+	assert.ErrorIs(t, err, ErrUnknownCurrency)
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Notification is a message to a user, e.g. an email.
+type Notification struct {
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// Notifier delivers notifications to users. Implementations can send emails,
+// text messages etc.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) (err error)
+}
+
+// fileNotifier appends notifications as JSON lines to a file, or writes them
+// to the log when no file is given. It stands in for a mail gateway when
+// running locally.
+type fileNotifier struct {
+	mu     sync.Mutex
+	path   string
+	logger *zap.SugaredLogger
+}
+
+func NewFileNotifier(path string, l *zap.SugaredLogger) Notifier {
+	return &fileNotifier{
+		path:   path,
+		logger: l,
+	}
+}
+
+func (f *fileNotifier) Notify(ctx context.Context, n Notification) (err error) {
+	if n.SentAt.IsZero() {
+		n.SentAt = time.Now()
+	}
+
+	if f.path == "" {
+		f.logger.Infow("Notification", "to", n.To, "subject", n.Subject, "body", n.Body)
+		return
+	}
+
+	line, err := json.Marshal(n)
+	if err != nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return
+}
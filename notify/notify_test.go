@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	n := NewFileNotifier(path, zap.NewNop().Sugar())
+
+	require.NoError(t, n.Notify(context.Background(), Notification{To: "a@test.com", Subject: "one", Body: "first"}))
+	require.NoError(t, n.Notify(context.Background(), Notification{To: "b@test.com", Subject: "two", Body: "second"}))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var got []Notification
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var notification Notification
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &notification))
+		got = append(got, notification)
+	}
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "a@test.com", got[0].To)
+	assert.Equal(t, "second", got[1].Body)
+	assert.False(t, got[1].SentAt.IsZero())
+}
+
+func TestFileNotifier_Log(t *testing.T) {
+	n := NewFileNotifier("", zap.NewNop().Sugar())
+	assert.NoError(t, n.Notify(context.Background(), Notification{To: "a@test.com"}))
+}
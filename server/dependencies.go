@@ -3,7 +3,9 @@ package server
 import (
 	"example.com/banking/app"
 	"example.com/banking/bank"
+	"example.com/banking/config"
 	"example.com/banking/db"
+	"example.com/banking/notify"
 )
 
 type dependencies struct {
@@ -13,10 +15,18 @@ type dependencies struct {
 func initDependencies() (dependencies, error) {
 	logger := app.GetLogger()
 
+	jwtConfig := config.JWT()
+	err := bank.LoadSigningKeys(jwtConfig.KeysDir(), jwtConfig.Secret(), jwtConfig.SigningKeyID(), jwtConfig.TokenTTL())
+	if err != nil {
+		return dependencies{}, err
+	}
+
 	appDB := app.GetDB()
 	dbStore := db.NewStorer(appDB)
 
-	bankService := bank.NewBankService(dbStore, logger)
+	notifier := notify.NewFileNotifier(config.NotifierFilePath(), logger)
+
+	bankService := bank.NewBankService(dbStore, logger, notifier)
 
 	return dependencies{
 		BankService: bankService,
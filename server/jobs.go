@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"example.com/banking/app"
+	"example.com/banking/config"
+)
+
+// startJobs runs the background jobs of the bank next to the API server.
+func startJobs(dep dependencies) {
+	go runDaily(func(ctx context.Context, now time.Time) {
+		// interest is accrued for the day that just ended
+		businessDate := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+		if _, err := dep.BankService.AccrueInterest(ctx, businessDate); err != nil {
+			app.GetLogger().Errorf("Err accruing interest for business date: %v, err: %v", businessDate.Format("2006-01-02"), err)
+		}
+	})
+
+	go runDaily(func(ctx context.Context, now time.Time) {
+		// monthly fees are charged for the month that ended, fees a balance
+		// did not cover are tried again the next day
+		month := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+		if _, err := dep.BankService.ChargeMonthlyFees(ctx, month); err != nil {
+			app.GetLogger().Errorf("Err charging monthly fees for month: %v, err: %v", month.Format("2006-01"), err)
+		}
+	})
+
+	go runEvery(config.StandingOrders().PollInterval(), func(ctx context.Context, now time.Time) {
+		if _, err := dep.BankService.ExecuteStandingOrders(ctx, now); err != nil {
+			app.GetLogger().Errorf("Err executing standing orders, err: %v", err)
+		}
+	})
+
+	go runEvery(config.Holds().SweepInterval(), func(ctx context.Context, now time.Time) {
+		if _, err := dep.BankService.ExpireHolds(ctx, now); err != nil {
+			app.GetLogger().Errorf("Err releasing expired holds, err: %v", err)
+		}
+	})
+}
+
+// runEvery calls job right away and then every interval.
+func runEvery(interval time.Duration, job func(ctx context.Context, now time.Time)) {
+	for {
+		job(context.Background(), time.Now())
+		time.Sleep(interval)
+	}
+}
+
+// runDaily calls job right away and then every day shortly after midnight.
+func runDaily(job func(ctx context.Context, now time.Time)) {
+	for {
+		now := time.Now()
+		job(context.Background(), now)
+
+		next := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 5, 0, 0, now.Location())
+		time.Sleep(time.Until(next))
+	}
+}
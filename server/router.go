@@ -17,15 +17,68 @@ const (
 func initRouter(dep dependencies) (router *mux.Router) {
 	v1 := fmt.Sprintf("application/vnd.%s.v1", config.AppName())
 
+	idempotent := bank.Idempotent(dep.BankService)
+	authenticate := bank.Authenticate(dep.BankService)
+
+	// authorize puts h behind authentication and lets through only callers
+	// whose role is granted the permission.
+	authorize := func(h http.Handler, permission string) http.Handler {
+		return authenticate(bank.RequirePermission(dep.BankService, permission)(h))
+	}
+
 	router = mux.NewRouter()
 	router.HandleFunc("/ping", bank.PingHandler).Methods(http.MethodGet)
+	router.HandleFunc("/.well-known/jwks.json", bank.JWKSHandler).Methods(http.MethodGet)
 
 	router.HandleFunc("/login", bank.LoginHandler(dep.BankService)).Methods(http.MethodPost).Headers(versionHeader, v1)
-	router.HandleFunc("/account", bank.CreateAccountHandler(dep.BankService)).Methods(http.MethodPost).Headers(versionHeader, v1)
-	router.HandleFunc("/accounts", bank.GetAccountsHandler(dep.BankService)).Methods(http.MethodGet).Headers(versionHeader, v1)
-	router.HandleFunc("/account/{account_id}", bank.GetAccountDetailsHandler(dep.BankService)).Methods(http.MethodGet).Headers(versionHeader, v1)
-	router.HandleFunc("/account/{account_id}/deposit", bank.DepositAmountHandler(dep.BankService)).Methods(http.MethodPost).Headers(versionHeader, v1)
-	router.HandleFunc("/account/{account_id}/withdraw", bank.WithdrawAmountHandler(dep.BankService)).Methods(http.MethodPost).Headers(versionHeader, v1)
-	router.HandleFunc("/account/{account_id}/transactions", bank.GetTransactionDetailsHandler(dep.BankService)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.HandleFunc("/login/mfa", bank.LoginMFAHandler(dep.BankService)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.HandleFunc("/token/refresh", bank.RefreshTokenHandler(dep.BankService)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/logout", authorize(bank.LogoutHandler(dep.BankService), bank.PermSessionLogout)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/me/mfa", authorize(bank.EnrolMFAHandler(dep.BankService), bank.PermProfileMFA)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/me/mfa/confirm", authorize(bank.ConfirmMFAHandler(dep.BankService), bank.PermProfileMFA)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/me/password", authorize(bank.ChangePasswordHandler(dep.BankService), bank.PermProfilePasswordChange)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.HandleFunc("/password/forgot", bank.ForgotPasswordHandler(dep.BankService)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.HandleFunc("/password/reset", bank.ResetPasswordHandler(dep.BankService)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/users/{user_id}/unlock", authorize(bank.UnlockUserHandler(dep.BankService), bank.PermUsersUnlock)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/users/{user_id}/role", authorize(bank.SetUserRoleHandler(dep.BankService), bank.PermUsersRoleAssign)).Methods(http.MethodPut).Headers(versionHeader, v1)
+	router.Handle("/users/{user_id}/mfa", authorize(bank.ProvisionMFAHandler(dep.BankService), bank.PermUsersMFAProvision)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account", authorize(idempotent(bank.CreateAccountHandler(dep.BankService)), bank.PermAccountsCreate)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/customers/{customer_id}/accounts", authorize(idempotent(bank.OpenAccountHandler(dep.BankService)), bank.PermAccountsCreate)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/me/accounts", authorize(bank.GetUserAccountsHandler(dep.BankService), bank.PermAccountsReadOwn)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/accounts", authorize(bank.GetAccountsHandler(dep.BankService), bank.PermAccountsList)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}", authorize(bank.GetAccountDetailsHandler(dep.BankService), bank.PermAccountsReadOwn)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/status", authorize(bank.ChangeAccountStatusHandler(dep.BankService), bank.PermAccountsStatusChange)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/close", authorize(idempotent(bank.CloseAccountHandler(dep.BankService)), bank.PermAccountsClose)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/overdraft", authorize(bank.SetOverdraftLimitHandler(dep.BankService), bank.PermAccountsOverdraftSet)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/limits", authorize(bank.GetAccountLimitsHandler(dep.BankService), bank.PermAccountsReadOwn)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/limits", authorize(bank.SetAccountLimitsHandler(dep.BankService), bank.PermAccountsLimitsSet)).Methods(http.MethodPut).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/deposit", authorize(idempotent(bank.DepositAmountHandler(dep.BankService)), bank.PermAccountsDepositOwn)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/withdraw", authorize(idempotent(bank.WithdrawAmountHandler(dep.BankService)), bank.PermAccountsWithdrawOwn)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/transfer", authorize(idempotent(bank.TransferAmountHandler(dep.BankService)), bank.PermAccountsTransferOwn)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/standing_orders", authorize(bank.GetStandingOrdersHandler(dep.BankService), bank.PermStandingOrdersOwn)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/standing_orders", authorize(idempotent(bank.CreateStandingOrderHandler(dep.BankService)), bank.PermStandingOrdersOwn)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/standing_orders/{order_id}", authorize(bank.CancelStandingOrderHandler(dep.BankService), bank.PermStandingOrdersOwn)).Methods(http.MethodDelete).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/standing_orders/{order_id}/executions", authorize(bank.GetStandingOrderExecutionsHandler(dep.BankService), bank.PermStandingOrdersOwn)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/holds", authorize(bank.GetHoldsHandler(dep.BankService), bank.PermHoldsManage)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/holds", authorize(idempotent(bank.CreateHoldHandler(dep.BankService)), bank.PermHoldsManage)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/holds/{hold_id}/capture", authorize(idempotent(bank.CaptureHoldHandler(dep.BankService)), bank.PermHoldsManage)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/holds/{hold_id}/release", authorize(idempotent(bank.ReleaseHoldHandler(dep.BankService)), bank.PermHoldsManage)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/transactions", authorize(bank.GetTransactionDetailsHandler(dep.BankService), bank.PermTransactionsReadOwn)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/transactions", authorize(bank.GetTransactionHistoryHandler(dep.BankService), bank.PermTransactionsReadOwn)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/statements/{month}", authorize(bank.GetStatementHandler(dep.BankService), bank.PermTransactionsReadOwn)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/account/{account_id}/transactions/{transaction_id}/reverse", authorize(idempotent(bank.ReverseTransactionHandler(dep.BankService)), bank.PermTransactionsReverse)).Methods(http.MethodPost).Headers(versionHeader, v1)
+
+	// Staff work on customer accounts, every access needs a reason and is audited
+	router.Handle("/staff/account/{account_id}", authorize(bank.StaffGetAccountDetailsHandler(dep.BankService), bank.PermAccountsReadAny)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/staff/account/{account_id}/deposit", authorize(idempotent(bank.StaffDepositAmountHandler(dep.BankService)), bank.PermAccountsDepositAny)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/staff/account/{account_id}/withdraw", authorize(idempotent(bank.StaffWithdrawAmountHandler(dep.BankService)), bank.PermAccountsWithdrawAny)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/staff/account/{account_id}/transfer", authorize(idempotent(bank.StaffTransferAmountHandler(dep.BankService)), bank.PermAccountsTransferAny)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/staff/account/{account_id}/transactions", authorize(bank.StaffGetTransactionDetailsHandler(dep.BankService), bank.PermTransactionsReadAny)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/fees/rules", authorize(bank.GetFeeRulesHandler(dep.BankService), bank.PermFeesManage)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/fees/rules", authorize(idempotent(bank.CreateFeeRuleHandler(dep.BankService)), bank.PermFeesManage)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/fees/rules/{rule_id}", authorize(bank.UpdateFeeRuleHandler(dep.BankService), bank.PermFeesManage)).Methods(http.MethodPut).Headers(versionHeader, v1)
+	router.Handle("/fx/rates", authorize(bank.GetFXRatesHandler(dep.BankService), bank.PermFXRead)).Methods(http.MethodGet).Headers(versionHeader, v1)
+	router.Handle("/fx/rates", authorize(idempotent(bank.CreateFXRateHandler(dep.BankService)), bank.PermFXManage)).Methods(http.MethodPost).Headers(versionHeader, v1)
+	router.Handle("/audit", authorize(bank.GetAuditLogHandler(dep.BankService), bank.PermAuditRead)).Methods(http.MethodGet).Headers(versionHeader, v1)
 	return
 }
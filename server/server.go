@@ -18,6 +18,8 @@ func StartApiServer() {
 		panic(err)
 	}
 
+	startJobs(dependencies)
+
 	router := initRouter(dependencies)
 	server.UseHandler(router)
 
@@ -0,0 +1,157 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 pages, in points
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 50
+	fontSize     = 9
+	headingSize  = 14
+	lineHeight   = 12
+	footerHeight = 20
+)
+
+// pdfText is a line of text placed on a page. The fonts are F1, Courier, and
+// F2, Courier-Bold for headings, fixed width so that the columns of the table
+// line up.
+type pdfText struct {
+	x, y float64
+	font string
+	size float64
+	text string
+}
+
+// pdfDocument lays out lines of text top to bottom over as many pages as
+// needed. Only the standard fonts every PDF reader has are used, so nothing
+// is embedded.
+type pdfDocument struct {
+	pages [][]pdfText
+	y     float64
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.newPage()
+	return d
+}
+
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, nil)
+	d.y = pageHeight - margin
+}
+
+func (d *pdfDocument) add(font string, size float64, text string) {
+	page := len(d.pages) - 1
+	d.pages[page] = append(d.pages[page], pdfText{x: margin, y: d.y, font: font, size: size, text: text})
+}
+
+// fits tells whether n more lines fit on the current page.
+func (d *pdfDocument) fits(n int) bool {
+	return d.y-float64(n*lineHeight) >= margin+footerHeight
+}
+
+func (d *pdfDocument) heading(text string) {
+	d.add("F2", headingSize, text)
+	d.y -= 2 * lineHeight
+}
+
+func (d *pdfDocument) line(text string) {
+	if !d.fits(1) {
+		d.newPage()
+	}
+	d.add("F1", fontSize, text)
+	d.y -= lineHeight
+}
+
+func (d *pdfDocument) space() {
+	d.y -= lineHeight
+}
+
+// tableHeader starts a table with its header, on a new page unless there is
+// room for a row under it.
+func (d *pdfDocument) tableHeader(header string) {
+	if !d.fits(2) {
+		d.newPage()
+	}
+	d.add("F2", fontSize, header)
+	d.y -= lineHeight
+}
+
+// tableLine adds a row to a table, repeating the header at the top of each
+// new page.
+func (d *pdfDocument) tableLine(header, text string) {
+	if !d.fits(1) {
+		d.newPage()
+		d.tableHeader(header)
+	}
+	d.line(text)
+}
+
+// WriteTo writes the document, numbering its pages.
+func (d *pdfDocument) WriteTo(w io.Writer) (n int64, err error) {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// objects 1 to 4 are the catalog, the page tree and the fonts; each page
+	// is followed by its content stream
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, texts := range d.pages {
+		footer := pdfText{x: margin, y: margin, font: "F1", size: fontSize, text: fmt.Sprintf("Page %d of %d", i+1, len(d.pages))}
+
+		var content bytes.Buffer
+		for _, t := range append(texts, footer) {
+			fmt.Fprintf(&content, "BT /%s %g Tf %g %g Td (%s) Tj ET\n", t.font, t.size, t.x, t.y, escapePDF(t.text))
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, len(offsets)+2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// escapePDF escapes text for a PDF string. Characters the standard fonts do
+// not have are replaced.
+func escapePDF(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > '~':
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
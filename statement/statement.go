@@ -0,0 +1,142 @@
+// Package statement renders account statements: the opening balance, every
+// transaction, the totals and the closing balance of an account for a month,
+// as CSV or PDF.
+package statement
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"example.com/banking/db"
+)
+
+// Formats statements are rendered in
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+)
+
+// ContentType returns the media type of a format.
+func ContentType(format string) string {
+	if format == FormatPDF {
+		return "application/pdf"
+	}
+	return "text/csv"
+}
+
+// FileName returns the name a statement of an account for a month is saved as.
+func FileName(accID string, month time.Time, format string) string {
+	return fmt.Sprintf("statement-%v-%v.%v", accID, month.Format("2006-01"), format)
+}
+
+// Write renders the statement in the format.
+func Write(w io.Writer, s db.Statement, format string) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, s)
+	case FormatPDF:
+		return WritePDF(w, s)
+	}
+	return fmt.Errorf("unknown statement format: %v", format)
+}
+
+// WriteCSV renders the statement as CSV: the account and period, one row per
+// transaction, and the balances and totals.
+func WriteCSV(w io.Writer, s db.Statement) error {
+	cw := csv.NewWriter(w)
+	from, to := period(s)
+	records := [][]string{
+		{"Account", s.Account.ID},
+		{"Account holder", s.Account.Email},
+		{"Account type", s.Account.Type},
+		{"Currency", s.Account.Currency},
+		{"Period", from, to},
+		{"Opening balance", s.OpeningBalance.String()},
+		{},
+		{"Date", "Transaction ID", "Type", "Reference", "Credit", "Debit", "Balance"},
+	}
+	for _, t := range s.Transactions {
+		credit, debit := columns(t)
+		reference := ""
+		if t.TransferRef != nil {
+			reference = *t.TransferRef
+		}
+		records = append(records, []string{date(t.CreatedAt), t.ID, t.Type, reference, credit, debit, t.Balance.String()})
+	}
+	records = append(records,
+		[]string{},
+		[]string{"Total credits", s.TotalCredits.String()},
+		[]string{"Total debits", s.TotalDebits.String()},
+		[]string{"Closing balance", s.ClosingBalance.String()},
+	)
+
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// WritePDF renders the statement as a PDF of A4 pages, the transactions in a
+// table that continues over as many pages as needed.
+func WritePDF(w io.Writer, s db.Statement) error {
+	from, to := period(s)
+	doc := newPDFDocument()
+
+	doc.heading("Account statement")
+	doc.line(fmt.Sprintf("%-16s %v", "Account", s.Account.ID))
+	doc.line(fmt.Sprintf("%-16s %v", "Account holder", s.Account.Email))
+	doc.line(fmt.Sprintf("%-16s %v", "Account type", s.Account.Type))
+	doc.line(fmt.Sprintf("%-16s %v", "Currency", s.Account.Currency))
+	doc.line(fmt.Sprintf("%-16s %v to %v", "Period", from, to))
+	doc.space()
+	doc.line(tableRow("", "Opening balance", "", "", s.OpeningBalance.String()))
+	doc.space()
+
+	header := tableRow("Date", "Type", "Credit", "Debit", "Balance")
+	doc.tableHeader(header)
+	for _, t := range s.Transactions {
+		credit, debit := columns(t)
+		doc.tableLine(header, tableRow(date(t.CreatedAt), t.Type, credit, debit, t.Balance.String()))
+	}
+	if len(s.Transactions) == 0 {
+		doc.line("No transactions in the period")
+	}
+
+	doc.space()
+	doc.line(tableRow("", "Total", s.TotalCredits.String(), s.TotalDebits.String(), ""))
+	doc.line(tableRow("", "Closing balance", "", "", s.ClosingBalance.String()))
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+// tableRow lays out the columns of a transaction in the fixed width font of
+// the PDF, amounts right aligned.
+func tableRow(date, typ, credit, debit, balance string) string {
+	return fmt.Sprintf("%-16s  %-18s %15s %15s %16s", date, typ, credit, debit, balance)
+}
+
+// period returns the first and last day of the statement.
+func period(s db.Statement) (from, to string) {
+	return s.From.Format("2006-01-02"), s.To.AddDate(0, 0, -1).Format("2006-01-02")
+}
+
+// columns puts the amount of a transaction in the credit or debit column.
+func columns(t db.Transaction) (credit, debit string) {
+	if t.IsCredit() {
+		return t.Amount.String(), ""
+	}
+	return "", t.Amount.String()
+}
+
+// date formats the time of a transaction to the minute, transactions read
+// from the database have it as RFC 3339.
+func date(createdAt string) string {
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return createdAt
+	}
+	return t.Format("2006-01-02 15:04")
+}
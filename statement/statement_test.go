@@ -0,0 +1,115 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"example.com/banking/db"
+	"example.com/banking/money"
+)
+
+func testStatement(transactions int) db.Statement {
+	ref := "ref-1"
+	s := db.Statement{
+		Account: db.UserAccountDetails{
+			Account: db.Account{ID: "acc-1", Type: "savings", Currency: "INR"},
+			Email:   "customer@bank.com",
+		},
+		From:           time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: money.New(10000, 2),
+		ClosingBalance: money.New(10000, 2),
+		TotalCredits:   money.New(0, 2),
+		TotalDebits:    money.New(0, 2),
+	}
+	for i := 0; i < transactions; i++ {
+		t := db.Transaction{
+			ID:        fmt.Sprintf("txn-%d", i),
+			Type:      "Credit",
+			Amount:    money.New(2500, 2),
+			CreatedAt: "2022-10-05T10:30:00.123456Z",
+		}
+		if i%2 == 1 {
+			t.Type, t.TransferRef = "Debit", &ref
+		}
+		s.Transactions = append(s.Transactions, t)
+	}
+	return s
+}
+
+func TestWriteCSV(t *testing.T) {
+	s := testStatement(2)
+	s.Transactions[0].Balance = money.New(12500, 2)
+	s.Transactions[1].Balance = money.New(10000, 2)
+	s.TotalCredits, s.TotalDebits = money.New(2500, 2), money.New(2500, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, s))
+	assert.Equal(t, `Account,acc-1
+Account holder,customer@bank.com
+Account type,savings
+Currency,INR
+Period,2022-10-01,2022-10-31
+Opening balance,100.00
+
+Date,Transaction ID,Type,Reference,Credit,Debit,Balance
+2022-10-05 10:30,txn-0,Credit,,25.00,,125.00
+2022-10-05 10:30,txn-1,Debit,ref-1,,25.00,100.00
+
+Total credits,25.00
+Total debits,25.00
+Closing balance,100.00
+`, buf.String())
+}
+
+func TestWritePDF(t *testing.T) {
+	tests := []struct {
+		name         string
+		transactions int
+		pages        int
+	}{
+		{name: "no transactions", transactions: 0, pages: 1},
+		{name: "one page", transactions: 30, pages: 1},
+		{name: "table continues on the next pages", transactions: 200, pages: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WritePDF(&buf, testStatement(tt.transactions)))
+			pdf := buf.String()
+
+			assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+			assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+			assert.Contains(t, pdf, fmt.Sprintf("/Count %d", tt.pages))
+			assert.Contains(t, pdf, fmt.Sprintf("(Page %d of %d)", tt.pages, tt.pages))
+			assert.Contains(t, pdf, "(Account statement)")
+
+			// every object is where the cross-reference table says
+			startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(pdf)
+			require.Len(t, startxref, 2)
+			xref, err := strconv.Atoi(startxref[1])
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(pdf[xref:], "xref\n"))
+			offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(pdf[xref:], -1)
+			assert.Len(t, offsets, 4+2*tt.pages)
+			for i, offset := range offsets {
+				n, err := strconv.Atoi(offset[1])
+				require.NoError(t, err)
+				assert.True(t, strings.HasPrefix(pdf[n:], fmt.Sprintf("%d 0 obj\n", i+1)), "object %d", i+1)
+			}
+		})
+	}
+}
+
+func TestEscapePDF(t *testing.T) {
+	assert.Equal(t, `Savings \(joint\) \\ caf?`, escapePDF(`Savings (joint) \ café`))
+}
@@ -0,0 +1,71 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 appendix B test vectors for SHA1, truncated to 6 digits
+func TestCode_RFC6238(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		code, err := Code(secret, Counter(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, code, "at %v", tt.unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Now()
+	code, err := Code(secret, Counter(now))
+	require.NoError(t, err)
+
+	counter, ok := Validate(secret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Counter(now), counter)
+
+	// the previous period is accepted with skew, older ones are not
+	_, ok = Validate(secret, code, now.Add(Period), 1)
+	assert.True(t, ok)
+	_, ok = Validate(secret, code, now.Add(2*Period), 1)
+	assert.False(t, ok)
+
+	_, ok = Validate(secret, "12345", now, 1)
+	assert.False(t, ok)
+	_, ok = Validate("not base32!", "123456", now, 1)
+	assert.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Banking App", "account@bank.com", "JBSWY3DPEHPK3PXP")
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Banking App:account@bank.com", u.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", u.Query().Get("secret"))
+	assert.Equal(t, "Banking App", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+}